@@ -0,0 +1,51 @@
+data "rustfs_iam_policy_document" "home" {
+  statement = [
+    {
+      sid       = "ListHome"
+      actions   = ["s3:ListBucket"]
+      resources = ["arn:aws:s3:::home"]
+      condition = [{
+        test     = "StringLike"
+        variable = "s3:prefix"
+        values   = ["$${aws:username}/*"]
+      }]
+    },
+    {
+      sid       = "ReadWriteHome"
+      actions   = ["s3:GetObject", "s3:PutObject", "s3:DeleteObject"]
+      resources = ["arn:aws:s3:::home/$${aws:username}/*"]
+    },
+  ]
+}
+
+resource "rustfs_policy" "home" {
+  name   = "home"
+  policy = data.rustfs_iam_policy_document.home.json
+}
+
+# Replace the statement with sid ReadWriteHome and keep the others.
+data "rustfs_iam_policy_document" "home_readonly" {
+  source_policy_documents = [data.rustfs_iam_policy_document.home.json]
+
+  statement = [{
+    sid       = "ReadWriteHome"
+    actions   = ["s3:GetObject"]
+    resources = ["arn:aws:s3:::home/$${aws:username}/*"]
+  }]
+}
+
+data "rustfs_iam_policy_document" "public_read" {
+  statement = [{
+    actions   = ["s3:GetObject"]
+    resources = ["arn:aws:s3:::public/*"]
+    principals = [{
+      type        = "AWS"
+      identifiers = ["*"]
+    }]
+  }]
+}
+
+resource "rustfs_bucket_policy" "public_read" {
+  bucket = "public"
+  policy = data.rustfs_iam_policy_document.public_read.json
+}
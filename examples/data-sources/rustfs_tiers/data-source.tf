@@ -0,0 +1,5 @@
+data "rustfs_tiers" "all" {}
+
+output "tier_usage" {
+  value = { for tier in data.rustfs_tiers.all.tiers : tier.name => tier.total_size }
+}
@@ -0,0 +1,9 @@
+resource "rustfs_audit_kafka" "audit" {
+  name = "audit"
+
+  brokers       = ["kafka-1.example.com:9092", "kafka-2.example.com:9092"]
+  topic         = "rustfs-events"
+  sasl          = true
+  sasl_username = "rustfs"
+  sasl_password = var.kafka_password
+}
@@ -0,0 +1,9 @@
+resource "rustfs_audit_mqtt" "audit" {
+  name = "audit"
+
+  broker   = "tcp://mqtt.example.com:1883"
+  topic    = "rustfs/events"
+  qos      = 1
+  username = "rustfs"
+  password = var.mqtt_password
+}
@@ -0,0 +1,8 @@
+resource "rustfs_audit_nats" "audit" {
+  name = "audit"
+
+  address  = "nats.example.com:4222"
+  subject  = "rustfs.events"
+  username = "rustfs"
+  password = var.nats_password
+}
@@ -0,0 +1,7 @@
+resource "rustfs_audit_postgres" "audit" {
+  name = "audit"
+
+  connection_string = var.postgres_connection_string
+  table             = "rustfs_events"
+  format            = "access"
+}
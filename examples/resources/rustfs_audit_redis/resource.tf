@@ -0,0 +1,8 @@
+resource "rustfs_audit_redis" "audit" {
+  name = "audit"
+
+  address  = "redis.example.com:6379"
+  key      = "rustfs-events"
+  format   = "namespace"
+  password = var.redis_password
+}
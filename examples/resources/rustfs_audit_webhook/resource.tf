@@ -0,0 +1,6 @@
+resource "rustfs_audit_webhook" "audit" {
+  name = "audit"
+
+  endpoint   = "https://hooks.example.com/rustfs"
+  auth_token = var.webhook_token
+}
@@ -1,3 +1,6 @@
 resource "rustfs_bucket" "example" {
   name = "my-bucket"
+
+  # Remove all objects and versions when the bucket is destroyed.
+  force_destroy = true
 }
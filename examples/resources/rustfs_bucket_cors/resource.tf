@@ -0,0 +1,21 @@
+resource "rustfs_bucket" "web" {
+  name = "web-assets"
+}
+
+resource "rustfs_bucket_cors" "example" {
+  bucket = rustfs_bucket.web.name
+
+  cors_rule {
+    id              = "browser-uploads"
+    allowed_methods = ["PUT", "POST"]
+    allowed_origins = ["https://app.example.com"]
+    allowed_headers = ["*"]
+    expose_headers  = ["ETag"]
+    max_age_seconds = 3000
+  }
+
+  cors_rule {
+    allowed_methods = ["GET", "HEAD"]
+    allowed_origins = ["*"]
+  }
+}
@@ -0,0 +1,51 @@
+resource "rustfs_bucket" "logs" {
+  name = "logs"
+}
+
+resource "rustfs_bucket_lifecycle_configuration" "example" {
+  bucket = rustfs_bucket.logs.name
+
+  # Move large application logs to a tier after 30 days, delete them after a year.
+  rule {
+    id     = "archive"
+    status = "Enabled"
+
+    filter {
+      and {
+        prefix                   = "app/"
+        tags                     = { retention = "long" }
+        object_size_greater_than = 1048576
+      }
+    }
+
+    transition {
+      days          = 30
+      storage_class = rustfs_tier.warm.name
+    }
+
+    expiration {
+      days = 365
+    }
+
+    noncurrent_version_expiration {
+      noncurrent_days           = 30
+      newer_noncurrent_versions = 3
+    }
+  }
+
+  # Clean up everything else.
+  rule {
+    id     = "cleanup"
+    status = "Enabled"
+
+    filter {}
+
+    expiration {
+      expired_object_delete_marker = true
+    }
+
+    abort_incomplete_multipart_upload {
+      days_after_initiation = 7
+    }
+  }
+}
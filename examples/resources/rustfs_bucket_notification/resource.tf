@@ -5,10 +5,16 @@ resource "rustfs_bucket" "events" {
 resource "rustfs_bucket_notification" "example" {
   bucket = rustfs_bucket.events.name
 
-  queue {
-    arn    = "arn:minio:sqs::PRIMARY:amqp"
-    events = ["s3:ObjectCreated:*", "s3:ObjectRemoved:*"]
+  queue = [{
+    id            = "uploads"
+    arn           = "arn:rustfs:sqs::primary:webhook"
+    events        = ["s3:ObjectCreated:*", "s3:ObjectRemoved:*"]
     filter_prefix = "uploads/"
     filter_suffix = ".jpg"
-  }
+  }]
+
+  topic = [{
+    arn    = "arn:rustfs:sns::primary:kafka"
+    events = ["s3:ObjectRemoved:*"]
+  }]
 }
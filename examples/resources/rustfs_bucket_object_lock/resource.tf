@@ -1,3 +1,8 @@
+resource "rustfs_bucket" "example" {
+  name                = "my-bucket"
+  object_lock_enabled = true
+}
+
 resource "rustfs_bucket_object_lock" "example" {
   bucket = rustfs_bucket.example.name
   mode   = "COMPLIANCE"
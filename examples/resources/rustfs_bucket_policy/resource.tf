@@ -0,0 +1,17 @@
+resource "rustfs_bucket" "public" {
+  name = "public-assets"
+}
+
+# Allow anonymous read access to all objects.
+resource "rustfs_bucket_policy" "example" {
+  bucket = rustfs_bucket.public.name
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect    = "Allow"
+      Principal = { AWS = ["*"] }
+      Action    = ["s3:GetObject"]
+      Resource  = ["arn:aws:s3:::${rustfs_bucket.public.name}/*"]
+    }]
+  })
+}
@@ -0,0 +1,11 @@
+resource "rustfs_bucket" "source" {
+  name = "source-bucket"
+}
+
+resource "rustfs_bucket_remote_target" "replica" {
+  bucket        = rustfs_bucket.source.name
+  endpoint      = "replica.example.com:9000"
+  target_bucket = "replica-bucket"
+  access_key    = var.replica_access_key
+  secret_key    = var.replica_secret_key
+}
@@ -2,10 +2,34 @@ resource "rustfs_bucket" "source" {
   name = "source-bucket"
 }
 
+resource "rustfs_bucket_remote_target" "replica" {
+  bucket        = rustfs_bucket.source.name
+  endpoint      = "replica.example.com:9000"
+  target_bucket = "replica-bucket"
+  access_key    = var.replica_access_key
+  secret_key    = var.replica_secret_key
+}
+
 resource "rustfs_bucket_replication" "example" {
-  bucket            = rustfs_bucket.source.name
-  role              = "arn:minio:replication::id:source-bucket"
-  destination_bucket = "arn:aws:s3:::dest-bucket"
-  priority          = 1
-  status            = "Enabled"
+  bucket = rustfs_bucket.source.name
+
+  rule {
+    id                          = "logs"
+    priority                    = 2
+    destination_bucket          = rustfs_bucket_remote_target.replica.arn
+    existing_object_replication = "Enabled"
+    delete_marker_replication   = "Enabled"
+
+    filter {
+      prefix = "logs/"
+      tags   = { replicate = "true" }
+    }
+  }
+
+  rule {
+    id                 = "everything"
+    priority           = 1
+    destination_bucket = rustfs_bucket_remote_target.replica.arn
+    storage_class      = "STANDARD"
+  }
 }
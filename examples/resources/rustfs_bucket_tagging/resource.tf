@@ -0,0 +1,11 @@
+resource "rustfs_bucket" "example" {
+  name = "my-bucket"
+}
+
+resource "rustfs_bucket_tagging" "example" {
+  bucket = rustfs_bucket.example.name
+  tags = {
+    cost-center = "4711"
+    owner       = "team-a"
+  }
+}
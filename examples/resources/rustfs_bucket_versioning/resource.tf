@@ -1,8 +1,12 @@
 resource "rustfs_bucket" "example" {
-  name = "my-versioned-bucket"
+  name = "my-bucket"
 }
 
 resource "rustfs_bucket_versioning" "example" {
   bucket = rustfs_bucket.example.name
   status = "Enabled"
+
+  # Spark writes intermediate files below _temporary/.
+  excluded_prefixes = ["_temporary/"]
+  exclude_folders   = true
 }
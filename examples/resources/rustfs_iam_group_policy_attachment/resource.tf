@@ -0,0 +1,9 @@
+resource "rustfs_group" "developers" {
+  name    = "developers"
+  members = [rustfs_user.alice.access_key]
+}
+
+resource "rustfs_iam_group_policy_attachment" "developers" {
+  group    = rustfs_group.developers.name
+  policies = ["readwrite"]
+}
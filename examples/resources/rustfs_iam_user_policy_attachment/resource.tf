@@ -0,0 +1,9 @@
+resource "rustfs_user" "alice" {
+  access_key = "alice"
+  secret_key = "superSecret123!"
+}
+
+resource "rustfs_iam_user_policy_attachment" "alice" {
+  user     = rustfs_user.alice.access_key
+  policies = ["readonly", "diagnostics"]
+}
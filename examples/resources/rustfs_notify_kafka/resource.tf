@@ -0,0 +1,22 @@
+resource "rustfs_notify_kafka" "primary" {
+  name = "primary"
+
+  brokers       = ["kafka-1.example.com:9092", "kafka-2.example.com:9092"]
+  topic         = "rustfs-events"
+  sasl          = true
+  sasl_username = "rustfs"
+  sasl_password = var.kafka_password
+}
+
+resource "rustfs_bucket" "events" {
+  name = "my-event-bucket"
+}
+
+resource "rustfs_bucket_notification" "events" {
+  bucket = rustfs_bucket.events.name
+
+  queue = [{
+    arn    = rustfs_notify_kafka.primary.arn
+    events = ["s3:ObjectCreated:*"]
+  }]
+}
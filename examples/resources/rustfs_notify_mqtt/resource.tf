@@ -0,0 +1,22 @@
+resource "rustfs_notify_mqtt" "primary" {
+  name = "primary"
+
+  broker   = "tcp://mqtt.example.com:1883"
+  topic    = "rustfs/events"
+  qos      = 1
+  username = "rustfs"
+  password = var.mqtt_password
+}
+
+resource "rustfs_bucket" "events" {
+  name = "my-event-bucket"
+}
+
+resource "rustfs_bucket_notification" "events" {
+  bucket = rustfs_bucket.events.name
+
+  queue = [{
+    arn    = rustfs_notify_mqtt.primary.arn
+    events = ["s3:ObjectCreated:*"]
+  }]
+}
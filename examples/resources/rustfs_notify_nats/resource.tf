@@ -0,0 +1,21 @@
+resource "rustfs_notify_nats" "primary" {
+  name = "primary"
+
+  address  = "nats.example.com:4222"
+  subject  = "rustfs.events"
+  username = "rustfs"
+  password = var.nats_password
+}
+
+resource "rustfs_bucket" "events" {
+  name = "my-event-bucket"
+}
+
+resource "rustfs_bucket_notification" "events" {
+  bucket = rustfs_bucket.events.name
+
+  queue = [{
+    arn    = rustfs_notify_nats.primary.arn
+    events = ["s3:ObjectCreated:*"]
+  }]
+}
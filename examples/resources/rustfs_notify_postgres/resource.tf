@@ -0,0 +1,20 @@
+resource "rustfs_notify_postgres" "primary" {
+  name = "primary"
+
+  connection_string = var.postgres_connection_string
+  table             = "rustfs_events"
+  format            = "access"
+}
+
+resource "rustfs_bucket" "events" {
+  name = "my-event-bucket"
+}
+
+resource "rustfs_bucket_notification" "events" {
+  bucket = rustfs_bucket.events.name
+
+  queue = [{
+    arn    = rustfs_notify_postgres.primary.arn
+    events = ["s3:ObjectCreated:*"]
+  }]
+}
@@ -0,0 +1,21 @@
+resource "rustfs_notify_redis" "primary" {
+  name = "primary"
+
+  address  = "redis.example.com:6379"
+  key      = "rustfs-events"
+  format   = "namespace"
+  password = var.redis_password
+}
+
+resource "rustfs_bucket" "events" {
+  name = "my-event-bucket"
+}
+
+resource "rustfs_bucket_notification" "events" {
+  bucket = rustfs_bucket.events.name
+
+  queue = [{
+    arn    = rustfs_notify_redis.primary.arn
+    events = ["s3:ObjectCreated:*"]
+  }]
+}
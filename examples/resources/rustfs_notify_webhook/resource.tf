@@ -0,0 +1,19 @@
+resource "rustfs_notify_webhook" "primary" {
+  name = "primary"
+
+  endpoint   = "https://hooks.example.com/rustfs"
+  auth_token = var.webhook_token
+}
+
+resource "rustfs_bucket" "events" {
+  name = "my-event-bucket"
+}
+
+resource "rustfs_bucket_notification" "events" {
+  bucket = rustfs_bucket.events.name
+
+  queue = [{
+    arn    = rustfs_notify_webhook.primary.arn
+    events = ["s3:ObjectCreated:*"]
+  }]
+}
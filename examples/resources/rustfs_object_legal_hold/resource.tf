@@ -0,0 +1,10 @@
+resource "rustfs_bucket" "records" {
+  name                = "records"
+  object_lock_enabled = true
+}
+
+resource "rustfs_object_legal_hold" "audit_report" {
+  bucket = rustfs_bucket.records.name
+  key    = "reports/2026-audit.pdf"
+  status = "ON"
+}
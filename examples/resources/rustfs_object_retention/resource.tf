@@ -0,0 +1,14 @@
+resource "rustfs_bucket" "records" {
+  name                = "records"
+  object_lock_enabled = true
+}
+
+resource "rustfs_object_retention" "audit_report" {
+  bucket            = rustfs_bucket.records.name
+  key               = "reports/2026-audit.pdf"
+  mode              = "GOVERNANCE"
+  retain_until_date = "2033-01-01T00:00:00Z"
+
+  # Allows shortening the retention and removing it on destroy.
+  bypass_governance_retention = true
+}
@@ -1,8 +1,7 @@
 resource "rustfs_policy" "readwrite" {
-  name    = "readwrite"
-  version = "2012-10-17"
+  name = "readwrite"
 
-  statement {
+  statement = [{
     effect = "Allow"
     action = [
       "s3:GetObject",
@@ -14,5 +13,39 @@ resource "rustfs_policy" "readwrite" {
       "arn:aws:s3:::my-bucket",
       "arn:aws:s3:::my-bucket/*",
     ]
-  }
+  }]
+}
+
+resource "rustfs_policy" "home" {
+  name = "home"
+
+  statement = [
+    {
+      sid       = "ListHome"
+      effect    = "Allow"
+      action    = ["s3:ListBucket"]
+      ressource = ["arn:aws:s3:::home"]
+      condition = [{
+        test     = "StringLike"
+        variable = "s3:prefix"
+        values   = ["$${aws:username}/*"]
+      }]
+    },
+    {
+      sid          = "DenyOutsideOffice"
+      effect       = "Deny"
+      action       = ["s3:*"]
+      not_resource = ["arn:aws:s3:::public/*"]
+      condition = [{
+        test     = "NotIpAddress"
+        variable = "aws:SourceIp"
+        values   = ["10.0.0.0/8"]
+      }]
+    },
+  ]
+}
+
+resource "rustfs_policy" "from_json" {
+  name   = "from-json"
+  policy = file("${path.module}/policies/readonly.json")
 }
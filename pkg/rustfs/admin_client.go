@@ -6,18 +6,20 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
-	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio-go/v7/pkg/s3utils"
 	"github.com/minio/minio-go/v7/pkg/signer"
 )
 
 const (
 	rustfsApiVersion = "v3"
+	// DefaultRegion is used for signing when no region is configured.
+	DefaultRegion = "us-east-1"
 )
 
 type RustfsAdminConfig struct {
@@ -26,6 +28,30 @@ type RustfsAdminConfig struct {
 	Endpoint     string
 	Ssl          bool
 	Insecure     bool
+	// Region is used to sign requests and as location of new buckets.
+	// Defaults to DefaultRegion.
+	Region string
+	// SessionToken is sent along with temporary STS credentials.
+	SessionToken string
+	// Credentials overrides the static keys above, e.g. with credentials
+	// that are refreshed through STS AssumeRole.
+	Credentials *credentials.Credentials
+	// MaxRetries is the number of times a failed request is retried. Zero
+	// disables retries.
+	MaxRetries int
+	// RetryMaxBackoff caps the wait between two attempts. Defaults to
+	// DefaultRetryMaxBackoff.
+	RetryMaxBackoff time.Duration
+	// CACertFile and CACertPEM add certificates to the trusted root pool.
+	CACertFile string
+	CACertPEM  string
+	// ClientCert and ClientKey hold either PEM data or a path to it and are
+	// used for mutual TLS.
+	ClientCert    string
+	ClientKey     string
+	TLSServerName string
+	// Transport overrides the transport built from the TLS settings above.
+	Transport http.RoundTripper
 }
 
 type RustfsAdmin struct {
@@ -33,6 +59,13 @@ type RustfsAdmin struct {
 	endpointURL  string
 	accessKey    string
 	accessSecret string
+	sessionToken string
+	credentials  *credentials.Credentials
+	region       string
+	retry        retryPolicy
+	// configErr is returned by every request when the client could not be
+	// set up, e.g. because of an unreadable CA bundle.
+	configErr error
 }
 
 type RequestData struct {
@@ -45,19 +78,32 @@ type RequestData struct {
 
 func New(config *RustfsAdminConfig) (client RustfsAdmin) {
 	client.endpointURL = client.createEndpointUrl(config.Endpoint, config.Ssl)
-	client.httpClient = &http.Client{}
+	client.httpClient = &http.Client{Transport: config.Transport}
+	if config.Transport == nil {
+		tr, err := NewTransport(config)
+		if err != nil {
+			client.configErr = err
+		} else {
+			client.httpClient.Transport = tr
+		}
+	}
 	client.accessKey = config.AccessKey
 	client.accessSecret = config.AccessSecret
+	client.sessionToken = config.SessionToken
+	client.credentials = config.Credentials
+	client.region = config.Region
+	if client.region == "" {
+		client.region = DefaultRegion
+	}
+	client.retry = newRetryPolicy(config.MaxRetries, config.RetryMaxBackoff)
 	return
 }
 
-func (c *RustfsAdmin) IsAdmin() (bool, error) {
+func (c *RustfsAdmin) IsAdmin(ctx context.Context) (bool, error) {
 	data := RequestData{
 		RelPath: "is-admin",
 		Method:  "GET",
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, data)
 	if err != nil {
 		return false, err
@@ -72,21 +118,11 @@ func (c *RustfsAdmin) IsAdmin() (bool, error) {
 }
 
 func (c *RustfsAdmin) doRequest(ctx context.Context, reqData RequestData) (res *http.Response, err error) {
-	req, err := c.createRequest(ctx, reqData)
-	if err != nil {
-		return
-	}
-
-	res, err = c.httpClient.Do(req)
-	if err != nil {
-		return
-	}
-	if res.StatusCode > 299 {
-		body, _ := io.ReadAll(res.Body)
-		return res, errors.New(string(body))
-	}
-
-	return
+	return c.execute(ctx, reqData.Method, func() (*http.Request, error) {
+		return c.createRequest(ctx, reqData)
+	}, func(status int) bool {
+		return status <= 299
+	})
 }
 
 func (c *RustfsAdmin) createEndpointUrl(endpoint string, secure bool) string {
@@ -124,12 +160,18 @@ func (c *RustfsAdmin) createRequest(ctx context.Context, request RequestData) (*
 	sum := sha256.Sum256(request.Content)
 	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
 
-	// sign using minio go (too stupid to get it done self)
-	req = signer.SignV4(*req, c.accessKey, c.accessSecret, "", "us-east-01")
-	return req, nil
+	return c.sign(req)
 }
 
 func (c *RustfsAdmin) DoDirectRequest(ctx context.Context, request RequestData) (res *http.Response, err error) {
+	return c.execute(ctx, request.Method, func() (*http.Request, error) {
+		return c.createDirectRequest(ctx, request)
+	}, func(status int) bool {
+		return status == 200 || status == 204
+	})
+}
+
+func (c *RustfsAdmin) createDirectRequest(ctx context.Context, request RequestData) (*http.Request, error) {
 	urlStr := strings.Replace(c.endpointURL, "/rustfs/admin/"+rustfsApiVersion, "", 1) + "/" + request.RelPath
 	// If there are any query values, add them to the end.
 	if len(request.QueryValues) > 0 {
@@ -146,17 +188,21 @@ func (c *RustfsAdmin) DoDirectRequest(ctx context.Context, request RequestData)
 	sum := sha256.Sum256(request.Content)
 	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
 
-	// sign using minio go (too stupid to get it done self)
-	req = signer.SignV4(*req, c.accessKey, c.accessSecret, "", "us-east-01")
+	return c.sign(req)
+}
 
-	res, err = c.httpClient.Do(req)
-	if err != nil {
-		return
-	}
-	if res.StatusCode != 200 && res.StatusCode != 204 {
-		body, _ := io.ReadAll(res.Body)
-		return res, errors.New(string(body))
+// sign signs req with the current credentials. Temporary credentials are
+// refreshed by the credentials provider once they expire.
+func (c *RustfsAdmin) sign(req *http.Request) (*http.Request, error) {
+	accessKey, accessSecret, sessionToken := c.accessKey, c.accessSecret, c.sessionToken
+	if c.credentials != nil {
+		value, err := c.credentials.Get()
+		if err != nil {
+			return nil, err
+		}
+		accessKey, accessSecret, sessionToken = value.AccessKeyID, value.SecretAccessKey, value.SessionToken
 	}
 
-	return
+	// sign using minio go (too stupid to get it done self)
+	return signer.SignV4(*req, accessKey, accessSecret, sessionToken, c.region), nil
 }
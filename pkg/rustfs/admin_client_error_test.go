@@ -2,9 +2,11 @@ package rustfs
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestDoRequest_NetworkError(t *testing.T) {
@@ -80,3 +82,30 @@ func TestDoDirectRequest_HTTPError(t *testing.T) {
 		t.Fatal("expected non-nil response for HTTP error")
 	}
 }
+
+func TestDoRequest_ContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := New(&RustfsAdminConfig{
+		Endpoint:  server.Listener.Addr().String(),
+		AccessKey: "test",
+	})
+	client.accessSecret = "test"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.ListPools(ctx)
+	if err == nil {
+		t.Fatal("expected error for exceeded deadline, got nil")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package rustfs_test
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -21,7 +22,7 @@ func TestIsAdmin(t *testing.T) {
 	}
 
 	dut := rustfs.New(&config)
-	admin, _ := dut.IsAdmin()
+	admin, _ := dut.IsAdmin(context.Background())
 	if !admin {
 		t.Error("User is no admin")
 	}
@@ -2,6 +2,7 @@ package rustfs
 
 import (
 	"context"
+	"encoding/xml"
 	"strings"
 )
 
@@ -9,14 +10,24 @@ type Bucket struct {
 	Name string
 }
 
-func (c *RustfsAdmin) CreateBucket(bucket string) (err error) {
+type createBucketConfiguration struct {
+	XMLName            xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CreateBucketConfiguration"`
+	LocationConstraint string   `xml:"LocationConstraint"`
+}
+
+func (c *RustfsAdmin) CreateBucket(ctx context.Context, bucket string) (err error) {
 	bucket = strings.ToLower(bucket)
 	req_data := RequestData{
 		Method:  "PUT",
 		RelPath: bucket,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// Like S3, the default region is implied by an empty body.
+	if c.region != DefaultRegion {
+		req_data.Content, err = xml.Marshal(createBucketConfiguration{LocationConstraint: c.region})
+		if err != nil {
+			return err
+		}
+	}
 	_, err = c.DoDirectRequest(ctx, req_data)
 	if err != nil {
 		return err
@@ -24,14 +35,12 @@ func (c *RustfsAdmin) CreateBucket(bucket string) (err error) {
 	return nil
 }
 
-func (c *RustfsAdmin) DeleteBucket(bucket string) (err error) {
+func (c *RustfsAdmin) DeleteBucket(ctx context.Context, bucket string) (err error) {
 	bucket = strings.ToLower(bucket)
 	req_data := RequestData{
 		Method:  "DELETE",
 		RelPath: bucket,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	_, err = c.DoDirectRequest(ctx, req_data)
 	if err != nil {
 		return err
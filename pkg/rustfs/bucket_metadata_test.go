@@ -1,6 +1,7 @@
 package rustfs
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -23,7 +24,7 @@ func TestExportBucketMetadata(t *testing.T) {
 	})
 	client.accessSecret = "secret"
 
-	data, err := client.ExportBucketMetadata()
+	data, err := client.ExportBucketMetadata(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -47,7 +48,7 @@ func TestImportBucketMetadata(t *testing.T) {
 	})
 	client.accessSecret = "secret"
 
-	err := client.ImportBucketMetadata([]byte("fake-metadata-zip"))
+	err := client.ImportBucketMetadata(context.Background(), []byte("fake-metadata-zip"))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
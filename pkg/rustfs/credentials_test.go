@@ -0,0 +1,121 @@
+package rustfs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestSessionToken_Static(t *testing.T) {
+	var token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("X-Amz-Security-Token")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := New(&RustfsAdminConfig{
+		Endpoint:     server.Listener.Addr().String(),
+		AccessKey:    "admin",
+		AccessSecret: "secret",
+		SessionToken: "session",
+	})
+	if _, err := client.ListPools(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "session" {
+		t.Errorf("expected session token to be sent, got %q", token)
+	}
+}
+
+// expiringProvider hands out new credentials on every retrieval and reports
+// them as expired right away.
+type expiringProvider struct {
+	retrieved int
+}
+
+func (p *expiringProvider) Retrieve() (credentials.Value, error) {
+	p.retrieved++
+	return credentials.Value{
+		AccessKeyID:     fmt.Sprintf("key-%d", p.retrieved),
+		SecretAccessKey: "secret",
+		SessionToken:    fmt.Sprintf("token-%d", p.retrieved),
+		SignerType:      credentials.SignatureV4,
+	}, nil
+}
+
+func (p *expiringProvider) IsExpired() bool {
+	return true
+}
+
+func TestSessionToken_Refresh(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("X-Amz-Security-Token"))
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := New(&RustfsAdminConfig{
+		Endpoint:    server.Listener.Addr().String(),
+		Credentials: credentials.New(&expiringProvider{}),
+	})
+	for i := 0; i < 2; i++ {
+		if _, err := client.ListPools(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(tokens) != 2 || tokens[0] != "token-1" || tokens[1] != "token-2" {
+		t.Errorf("expected refreshed tokens, got %v", tokens)
+	}
+}
+
+func TestSessionToken_AssumeRole(t *testing.T) {
+	var token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/" {
+			r.ParseForm()
+			if r.Form.Get("Action") != "AssumeRole" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			expiration := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+			fmt.Fprintf(w, `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+<AssumeRoleResult><Credentials><AccessKeyId>TEMPKEY</AccessKeyId><SecretAccessKey>TEMPSECRET</SecretAccessKey><SessionToken>TEMPTOKEN</SessionToken><Expiration>%s</Expiration></Credentials></AssumeRoleResult>
+</AssumeRoleResponse>`, expiration)
+			return
+		}
+		if !strings.Contains(r.Header.Get("Authorization"), "Credential=TEMPKEY/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		token = r.Header.Get("X-Amz-Security-Token")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	creds := credentials.New(&credentials.STSAssumeRole{
+		Client:      server.Client(),
+		STSEndpoint: server.URL,
+		Options: credentials.STSAssumeRoleOptions{
+			AccessKey: "admin",
+			SecretKey: "secret",
+		},
+	})
+	client := New(&RustfsAdminConfig{
+		Endpoint:    server.Listener.Addr().String(),
+		Credentials: creds,
+	})
+	if _, err := client.ListPools(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "TEMPTOKEN" {
+		t.Errorf("expected temporary session token, got %q", token)
+	}
+}
@@ -0,0 +1,162 @@
+package rustfs
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// APIError is returned for every non-successful reply of the RustFS admin
+// API and the S3 API. It carries enough information to classify the failure
+// without matching on the error text.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+	Resource   string
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.Code != "" {
+		msg = e.Code + ": " + msg
+	}
+	if e.RequestID != "" {
+		return fmt.Sprintf("%s (status %d, request id %s)", msg, e.StatusCode, e.RequestID)
+	}
+	return fmt.Sprintf("%s (status %d)", msg, e.StatusCode)
+}
+
+// Error codes RustFS (and the MinIO compatible admin API) use to report a
+// missing entity. Some of them are sent with a 400 or 500 status.
+var notFoundCodes = map[string]bool{
+	"NoSuchBucket":                                   true,
+	"NoSuchKey":                                      true,
+	"NoSuchLifecycleConfiguration":                   true,
+	"NoSuchBucketPolicy":                             true,
+	"NoSuchTagSet":                                   true,
+	"NoSuchCORSConfiguration":                        true,
+	"ReplicationConfigurationNotFoundError":          true,
+	"ObjectLockConfigurationNotFoundError":           true,
+	"NoSuchObjectLockConfiguration":                  true,
+	"NoSuchVersion":                                  true,
+	"ServerSideEncryptionConfigurationNotFoundError": true,
+	"XMinioAdminNoSuchUser":                          true,
+	"XMinioAdminNoSuchGroup":                         true,
+	"XMinioAdminNoSuchPolicy":                        true,
+	"XMinioAdminServiceAccountNotFound":              true,
+	"XMinioAdminNoSuchQuotaConfiguration":            true,
+	"XMinioAdminTierNotFound":                        true,
+	"XMinioAdminRemoteTargetNotFoundError":           true,
+	"XRustFSAdminTargetNotFound":                     true,
+}
+
+var conflictCodes = map[string]bool{
+	"BucketAlreadyExists":          true,
+	"BucketAlreadyOwnedByYou":      true,
+	"BucketNotEmpty":               true,
+	"XMinioAdminTierAlreadyExists": true,
+	"XMinioAdminGroupNotEmpty":     true,
+}
+
+var accessDeniedCodes = map[string]bool{
+	"AccessDenied":          true,
+	"InvalidAccessKeyId":    true,
+	"SignatureDoesNotMatch": true,
+	"ExpiredToken":          true,
+	"InvalidToken":          true,
+}
+
+// errorStatus extracts status code and error code from an APIError or a
+// minio-go ErrorResponse.
+func errorStatus(err error) (int, string, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode, apiErr.Code, true
+	}
+	var minioErr minio.ErrorResponse
+	if errors.As(err, &minioErr) {
+		return minioErr.StatusCode, minioErr.Code, true
+	}
+	return 0, "", false
+}
+
+// IsNotFound reports whether err was caused by a missing entity.
+func IsNotFound(err error) bool {
+	status, code, ok := errorStatus(err)
+	return ok && (status == http.StatusNotFound || notFoundCodes[code])
+}
+
+// IsConflict reports whether err was caused by a conflicting entity state,
+// e.g. an already existing or non-empty bucket.
+func IsConflict(err error) bool {
+	status, code, ok := errorStatus(err)
+	return ok && (status == http.StatusConflict || conflictCodes[code])
+}
+
+// IsAccessDenied reports whether err was caused by missing permissions or
+// invalid credentials.
+func IsAccessDenied(err error) bool {
+	status, code, ok := errorStatus(err)
+	return ok && (status == http.StatusForbidden || accessDeniedCodes[code])
+}
+
+// errorBody matches both the JSON error document of the admin API and the
+// XML <Error> document of the S3 API.
+type errorBody struct {
+	Code      string `json:"Code" xml:"Code"`
+	Message   string `json:"Message" xml:"Message"`
+	Resource  string `json:"Resource" xml:"Resource"`
+	RequestID string `json:"RequestId" xml:"RequestId"`
+}
+
+func newAPIError(res *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		RequestID:  res.Header.Get("X-Amz-Request-Id"),
+	}
+	if res.Request != nil {
+		apiErr.Resource = res.Request.URL.Path
+	}
+
+	body, _ := io.ReadAll(res.Body)
+	body = bytes.TrimSpace(body)
+	var parsed errorBody
+	switch {
+	case len(body) == 0:
+		return apiErr
+	case body[0] == '{':
+		if json.Unmarshal(body, &parsed) != nil {
+			parsed = errorBody{}
+		}
+	case body[0] == '<':
+		if xml.Unmarshal(body, &parsed) != nil {
+			parsed = errorBody{}
+		}
+	}
+
+	if parsed.Code == "" && parsed.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(body))
+		return apiErr
+	}
+	apiErr.Code = parsed.Code
+	apiErr.Message = parsed.Message
+	if parsed.Resource != "" {
+		apiErr.Resource = parsed.Resource
+	}
+	if parsed.RequestID != "" {
+		apiErr.RequestID = parsed.RequestID
+	}
+	return apiErr
+}
@@ -0,0 +1,150 @@
+package rustfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestDoRequest_APIErrorJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"Code":"XMinioAdminNoSuchUser","Message":"The specified user does not exist","Resource":"/rustfs/admin/v3/user-info","RequestId":"17A2B3C4"}`))
+	}))
+	defer server.Close()
+
+	client := New(&RustfsAdminConfig{
+		Endpoint:  server.Listener.Addr().String(),
+		AccessKey: "admin",
+	})
+	client.accessSecret = "secret"
+
+	_, err := client.ReadUserAccount(context.Background(), "ghost")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", apiErr.StatusCode)
+	}
+	if apiErr.Code != "XMinioAdminNoSuchUser" {
+		t.Errorf("unexpected code: %s", apiErr.Code)
+	}
+	if apiErr.RequestID != "17A2B3C4" {
+		t.Errorf("unexpected request id: %s", apiErr.RequestID)
+	}
+	if apiErr.Resource != "/rustfs/admin/v3/user-info" {
+		t.Errorf("unexpected resource: %s", apiErr.Resource)
+	}
+	if !IsNotFound(err) {
+		t.Error("expected IsNotFound to be true")
+	}
+	if IsAccessDenied(err) || IsConflict(err) {
+		t.Error("404 must not be classified as access denied or conflict")
+	}
+}
+
+func TestDoDirectRequest_APIErrorXML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.Header().Set("X-Amz-Request-Id", "HEADER-ID")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<Error><Code>NoSuchLifecycleConfiguration</Code><Message>The lifecycle configuration does not exist</Message><Resource>/bucket</Resource></Error>`))
+	}))
+	defer server.Close()
+
+	client := New(&RustfsAdminConfig{
+		Endpoint:  server.Listener.Addr().String(),
+		AccessKey: "admin",
+	})
+	client.accessSecret = "secret"
+
+	_, err := client.GetBucketLifecycleConfiguration(context.Background(), "bucket")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.Code != "NoSuchLifecycleConfiguration" {
+		t.Errorf("unexpected code: %s", apiErr.Code)
+	}
+	if apiErr.RequestID != "HEADER-ID" {
+		t.Errorf("expected request id from header, got %s", apiErr.RequestID)
+	}
+	if !IsNotFound(err) {
+		t.Error("expected NoSuchLifecycleConfiguration to be classified as not found")
+	}
+}
+
+func TestDoRequest_APIErrorPlainText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("access denied"))
+	}))
+	defer server.Close()
+
+	client := New(&RustfsAdminConfig{
+		Endpoint:  server.Listener.Addr().String(),
+		AccessKey: "admin",
+	})
+	client.accessSecret = "secret"
+
+	_, err := client.ListPools(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.Message != "access denied" {
+		t.Errorf("expected raw body as message, got %q", apiErr.Message)
+	}
+	if apiErr.Resource != "/rustfs/admin/v3/list-pools" {
+		t.Errorf("expected request path as resource, got %s", apiErr.Resource)
+	}
+	if !IsAccessDenied(err) {
+		t.Error("expected IsAccessDenied to be true")
+	}
+	if IsNotFound(err) {
+		t.Error("403 must not be classified as not found")
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		notFound bool
+		conflict bool
+		denied   bool
+	}{
+		{"nil", nil, false, false, false},
+		{"plain error", errors.New("NoSuchBucket"), false, false, false},
+		{"status 404", &APIError{StatusCode: 404}, true, false, false},
+		{"code on 400", &APIError{StatusCode: 400, Code: "NoSuchBucket"}, true, false, false},
+		{"status 409", &APIError{StatusCode: 409}, false, true, false},
+		{"bucket not empty", &APIError{StatusCode: 400, Code: "BucketNotEmpty"}, false, true, false},
+		{"signature mismatch", &APIError{StatusCode: 400, Code: "SignatureDoesNotMatch"}, false, false, true},
+		{"wrapped", fmt.Errorf("reading user: %w", &APIError{StatusCode: 404}), true, false, false},
+		{"server error", &APIError{StatusCode: 500, Message: "internal error"}, false, false, false},
+		{"minio not found", minio.ErrorResponse{StatusCode: 404, Code: "NoSuchBucketPolicy"}, true, false, false},
+		{"minio conflict", fmt.Errorf("deleting: %w", minio.ErrorResponse{StatusCode: 409, Code: "BucketNotEmpty"}), false, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotFound(tt.err); got != tt.notFound {
+				t.Errorf("IsNotFound = %v, want %v", got, tt.notFound)
+			}
+			if got := IsConflict(tt.err); got != tt.conflict {
+				t.Errorf("IsConflict = %v, want %v", got, tt.conflict)
+			}
+			if got := IsAccessDenied(tt.err); got != tt.denied {
+				t.Errorf("IsAccessDenied = %v, want %v", got, tt.denied)
+			}
+		})
+	}
+}
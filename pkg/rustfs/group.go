@@ -19,7 +19,7 @@ type GroupAddRemove struct {
 	Status   string   `json:"status"`
 }
 
-func (c *RustfsAdmin) GetGroup(name string) (GroupInfo, error) {
+func (c *RustfsAdmin) GetGroup(ctx context.Context, name string) (GroupInfo, error) {
 	query := url.Values{}
 	query.Set("group", name)
 	reqData := RequestData{
@@ -27,8 +27,6 @@ func (c *RustfsAdmin) GetGroup(name string) (GroupInfo, error) {
 		RelPath:     "group",
 		QueryValues: query,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, reqData)
 	if err != nil {
 		return GroupInfo{}, err
@@ -39,7 +37,7 @@ func (c *RustfsAdmin) GetGroup(name string) (GroupInfo, error) {
 	return info, err
 }
 
-func (c *RustfsAdmin) UpdateGroupMembers(req GroupAddRemove) error {
+func (c *RustfsAdmin) UpdateGroupMembers(ctx context.Context, req GroupAddRemove) error {
 	bytes, err := json.Marshal(req)
 	if err != nil {
 		return err
@@ -49,8 +47,6 @@ func (c *RustfsAdmin) UpdateGroupMembers(req GroupAddRemove) error {
 		RelPath: "update-group-members",
 		Content: bytes,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, reqData)
 	if err != nil {
 		return err
@@ -59,13 +55,11 @@ func (c *RustfsAdmin) UpdateGroupMembers(req GroupAddRemove) error {
 	return nil
 }
 
-func (c *RustfsAdmin) DeleteGroup(name string) error {
+func (c *RustfsAdmin) DeleteGroup(ctx context.Context, name string) error {
 	reqData := RequestData{
 		Method:  "DELETE",
 		RelPath: "group/" + name,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, reqData)
 	if err != nil {
 		return err
@@ -74,7 +68,7 @@ func (c *RustfsAdmin) DeleteGroup(name string) error {
 	return nil
 }
 
-func (c *RustfsAdmin) SetGroupStatus(name, status string) error {
+func (c *RustfsAdmin) SetGroupStatus(ctx context.Context, name, status string) error {
 	query := url.Values{}
 	query.Set("group", name)
 	query.Set("status", status)
@@ -83,8 +77,6 @@ func (c *RustfsAdmin) SetGroupStatus(name, status string) error {
 		RelPath:     "set-group-status",
 		QueryValues: query,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, reqData)
 	if err != nil {
 		return err
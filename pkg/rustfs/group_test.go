@@ -1,6 +1,7 @@
 package rustfs
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -32,7 +33,7 @@ func TestGetGroup(t *testing.T) {
 	})
 	client.accessSecret = "secret"
 
-	info, err := client.GetGroup("developers")
+	info, err := client.GetGroup(context.Background(), "developers")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -76,7 +77,7 @@ func TestUpdateGroupMembers(t *testing.T) {
 	})
 	client.accessSecret = "secret"
 
-	err := client.UpdateGroupMembers(GroupAddRemove{
+	err := client.UpdateGroupMembers(context.Background(), GroupAddRemove{
 		Group:    "developers",
 		Members:  []string{"alice", "bob"},
 		IsRemove: false,
@@ -102,7 +103,7 @@ func TestDeleteGroup(t *testing.T) {
 	})
 	client.accessSecret = "secret"
 
-	err := client.DeleteGroup("developers")
+	err := client.DeleteGroup(context.Background(), "developers")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -129,7 +130,7 @@ func TestSetGroupStatus(t *testing.T) {
 	})
 	client.accessSecret = "secret"
 
-	err := client.SetGroupStatus("developers", "disabled")
+	err := client.SetGroupStatus(context.Background(), "developers", "disabled")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
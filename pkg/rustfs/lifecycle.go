@@ -13,22 +13,85 @@ type LifecycleConfiguration struct {
 	Rules   []LifecycleRule `xml:"Rule"`
 }
 
+// LifecycleRule is a single S3 lifecycle rule. A rule needs at least one
+// action: Expiration, Transitions, NoncurrentVersionExpiration,
+// NoncurrentVersionTransitions or AbortIncompleteMultipartUpload. Filter is
+// nil for rules without a filter.
 type LifecycleRule struct {
-	ID         string               `xml:"ID,omitempty"`
-	Status     string               `xml:"Status"`
-	Filter     LifecycleFilter      `xml:"Filter"`
-	Expiration *LifecycleExpiration `xml:"Expiration,omitempty"`
+	ID     string `xml:"ID,omitempty"`
+	Status string `xml:"Status"`
+	// Prefix is the deprecated rule level prefix. New rules use Filter.
+	Prefix                         string                          `xml:"Prefix,omitempty"`
+	Filter                         *LifecycleFilter                `xml:"Filter,omitempty"`
+	Expiration                     *LifecycleExpiration            `xml:"Expiration,omitempty"`
+	Transitions                    []LifecycleTransition           `xml:"Transition,omitempty"`
+	NoncurrentVersionExpiration    *NoncurrentVersionExpiration    `xml:"NoncurrentVersionExpiration,omitempty"`
+	NoncurrentVersionTransitions   []NoncurrentVersionTransition   `xml:"NoncurrentVersionTransition,omitempty"`
+	AbortIncompleteMultipartUpload *AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
 }
 
+// LifecycleFilter selects the objects a rule applies to. S3 allows only one
+// of its predicates, combine several with And. An empty filter matches all
+// objects.
 type LifecycleFilter struct {
-	Prefix string `xml:"Prefix"`
+	Prefix                string        `xml:"Prefix,omitempty"`
+	Tag                   *LifecycleTag `xml:"Tag,omitempty"`
+	ObjectSizeGreaterThan int64         `xml:"ObjectSizeGreaterThan,omitempty"`
+	ObjectSizeLessThan    int64         `xml:"ObjectSizeLessThan,omitempty"`
+	And                   *LifecycleAnd `xml:"And,omitempty"`
 }
 
+// IsEmpty reports whether the filter matches all objects. A nil filter is
+// empty.
+func (f *LifecycleFilter) IsEmpty() bool {
+	return f == nil || f.Prefix == "" && f.Tag == nil && f.ObjectSizeGreaterThan == 0 && f.ObjectSizeLessThan == 0 && f.And == nil
+}
+
+type LifecycleTag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+type LifecycleAnd struct {
+	Prefix                string         `xml:"Prefix,omitempty"`
+	Tags                  []LifecycleTag `xml:"Tag,omitempty"`
+	ObjectSizeGreaterThan int64          `xml:"ObjectSizeGreaterThan,omitempty"`
+	ObjectSizeLessThan    int64          `xml:"ObjectSizeLessThan,omitempty"`
+}
+
+// LifecycleExpiration expires current object versions. Only one of Days,
+// Date and ExpiredObjectDeleteMarker may be set. Date is an ISO 8601 date at
+// midnight UTC, e.g. 2030-01-01T00:00:00Z.
 type LifecycleExpiration struct {
-	Days *int `xml:"Days,omitempty"`
+	Days                      *int   `xml:"Days,omitempty"`
+	Date                      string `xml:"Date,omitempty"`
+	ExpiredObjectDeleteMarker *bool  `xml:"ExpiredObjectDeleteMarker,omitempty"`
+}
+
+// LifecycleTransition moves current object versions to StorageClass, which
+// is the name of a tier, after Days or at Date.
+type LifecycleTransition struct {
+	Days         *int   `xml:"Days,omitempty"`
+	Date         string `xml:"Date,omitempty"`
+	StorageClass string `xml:"StorageClass"`
 }
 
-func (c *RustfsAdmin) SetBucketLifecycleConfiguration(bucket string, config *LifecycleConfiguration) error {
+type NoncurrentVersionExpiration struct {
+	NoncurrentDays          int `xml:"NoncurrentDays"`
+	NewerNoncurrentVersions int `xml:"NewerNoncurrentVersions,omitempty"`
+}
+
+type NoncurrentVersionTransition struct {
+	NoncurrentDays          int    `xml:"NoncurrentDays"`
+	NewerNoncurrentVersions int    `xml:"NewerNoncurrentVersions,omitempty"`
+	StorageClass            string `xml:"StorageClass"`
+}
+
+type AbortIncompleteMultipartUpload struct {
+	DaysAfterInitiation int `xml:"DaysAfterInitiation"`
+}
+
+func (c *RustfsAdmin) SetBucketLifecycleConfiguration(ctx context.Context, bucket string, config *LifecycleConfiguration) error {
 	var buf bytes.Buffer
 	err := xml.NewEncoder(&buf).Encode(config)
 	if err != nil {
@@ -42,9 +105,6 @@ func (c *RustfsAdmin) SetBucketLifecycleConfiguration(bucket string, config *Lif
 		QueryValues: url.Values{"lifecycle": []string{""}},
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	resp, err := c.DoDirectRequest(ctx, reqData)
 	if resp != nil && resp.Body != nil {
 		_ = resp.Body.Close()
@@ -52,16 +112,13 @@ func (c *RustfsAdmin) SetBucketLifecycleConfiguration(bucket string, config *Lif
 	return err
 }
 
-func (c *RustfsAdmin) GetBucketLifecycleConfiguration(bucket string) (*LifecycleConfiguration, error) {
+func (c *RustfsAdmin) GetBucketLifecycleConfiguration(ctx context.Context, bucket string) (*LifecycleConfiguration, error) {
 	reqData := RequestData{
 		Method:      "GET",
 		RelPath:     bucket,
 		QueryValues: url.Values{"lifecycle": []string{""}},
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	resp, err := c.DoDirectRequest(ctx, reqData)
 	if err != nil {
 		if resp != nil && resp.Body != nil {
@@ -85,16 +142,13 @@ func (c *RustfsAdmin) GetBucketLifecycleConfiguration(bucket string) (*Lifecycle
 	return &config, nil
 }
 
-func (c *RustfsAdmin) DeleteBucketLifecycleConfiguration(bucket string) error {
+func (c *RustfsAdmin) DeleteBucketLifecycleConfiguration(ctx context.Context, bucket string) error {
 	reqData := RequestData{
 		Method:      "DELETE",
 		RelPath:     bucket,
 		QueryValues: url.Values{"lifecycle": []string{""}},
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	resp, err := c.DoDirectRequest(ctx, reqData)
 	if resp != nil && resp.Body != nil {
 		_ = resp.Body.Close()
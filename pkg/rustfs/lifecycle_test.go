@@ -1,6 +1,9 @@
 package rustfs_test
 
 import (
+	"context"
+	"encoding/xml"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -13,14 +16,14 @@ func TestCreateUpdateDelete(t *testing.T) {
 	name = strings.ToLower(name)
 	days := 20
 	dut := getClient()
-	dut.CreateBucket(name)
+	dut.CreateBucket(context.Background(), name)
 
 	lifecycleConfig := rustfs.LifecycleConfiguration{
 		Rules: []rustfs.LifecycleRule{
 			{
 				ID:     "TestRule",
 				Status: "Enabled",
-				Filter: rustfs.LifecycleFilter{
+				Filter: &rustfs.LifecycleFilter{
 					Prefix: "test",
 				},
 				Expiration: &rustfs.LifecycleExpiration{
@@ -30,7 +33,7 @@ func TestCreateUpdateDelete(t *testing.T) {
 		},
 	}
 
-	err := dut.SetBucketLifecycleConfiguration(name, &lifecycleConfig)
+	err := dut.SetBucketLifecycleConfiguration(context.Background(), name, &lifecycleConfig)
 	if err != nil {
 		t.Error("Eror during create", err)
 	}
@@ -40,23 +43,133 @@ func TestCreateUpdateDelete(t *testing.T) {
 	lifecycleConfig.Rules = append(lifecycleConfig.Rules, rustfs.LifecycleRule{
 		ID:     "TestRule2",
 		Status: "Disabled",
-		Filter: rustfs.LifecycleFilter{
+		Filter: &rustfs.LifecycleFilter{
 			Prefix: "test",
 		},
 		Expiration: &rustfs.LifecycleExpiration{
 			Days: &days,
 		},
 	})
-	err = dut.SetBucketLifecycleConfiguration(name, &lifecycleConfig)
+	err = dut.SetBucketLifecycleConfiguration(context.Background(), name, &lifecycleConfig)
 	if err != nil {
 		t.Error("Eror during update", err)
 	}
 	time.Sleep(5 * time.Second)
 
-	err = dut.DeleteBucketLifecycleConfiguration(name)
+	err = dut.DeleteBucketLifecycleConfiguration(context.Background(), name)
 	if err != nil {
 		t.Error("Eror during delete", err)
 	}
 
-	dut.DeleteBucket(name)
+	dut.DeleteBucket(context.Background(), name)
+}
+
+const fullLifecycleConfiguration = `<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Rule>
+    <ID>archive</ID>
+    <Status>Enabled</Status>
+    <Filter>
+      <And>
+        <Prefix>logs/</Prefix>
+        <Tag><Key>class</Key><Value>cold</Value></Tag>
+        <Tag><Key>team</Key><Value>ops</Value></Tag>
+        <ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+        <ObjectSizeLessThan>1048576</ObjectSizeLessThan>
+      </And>
+    </Filter>
+    <Expiration><Days>365</Days></Expiration>
+    <Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition>
+    <NoncurrentVersionExpiration><NoncurrentDays>90</NoncurrentDays><NewerNoncurrentVersions>3</NewerNoncurrentVersions></NoncurrentVersionExpiration>
+    <NoncurrentVersionTransition><NoncurrentDays>7</NoncurrentDays><StorageClass>WARM</StorageClass></NoncurrentVersionTransition>
+    <AbortIncompleteMultipartUpload><DaysAfterInitiation>2</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+  </Rule>
+  <Rule>
+    <ID>markers</ID>
+    <Status>Disabled</Status>
+    <Filter><Tag><Key>class</Key><Value>tmp</Value></Tag></Filter>
+    <Expiration><ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker></Expiration>
+  </Rule>
+  <Rule>
+    <ID>dated</ID>
+    <Status>Enabled</Status>
+    <Filter></Filter>
+    <Expiration><Date>2030-01-01T00:00:00Z</Date></Expiration>
+  </Rule>
+</LifecycleConfiguration>`
+
+func TestLifecycleConfigurationRoundTrip(t *testing.T) {
+	var config rustfs.LifecycleConfiguration
+	if err := xml.Unmarshal([]byte(fullLifecycleConfiguration), &config); err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Rules) != 3 {
+		t.Fatalf("expected 3 rules, got %d", len(config.Rules))
+	}
+	and := config.Rules[0].Filter.And
+	if and == nil || and.Prefix != "logs/" || len(and.Tags) != 2 || and.ObjectSizeLessThan != 1048576 {
+		t.Errorf("unexpected And filter %+v", and)
+	}
+	if !config.Rules[2].Filter.IsEmpty() {
+		t.Errorf("expected empty filter, got %+v", config.Rules[2].Filter)
+	}
+
+	encoded, err := xml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded rustfs.LifecycleConfiguration
+	if err := xml.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, config) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", decoded, config)
+	}
+}
+
+func TestLifecycleRuleWithoutFilter(t *testing.T) {
+	days := 7
+	encoded, err := xml.Marshal(rustfs.LifecycleConfiguration{Rules: []rustfs.LifecycleRule{{
+		ID:         "all",
+		Status:     "Enabled",
+		Expiration: &rustfs.LifecycleExpiration{Days: &days},
+	}, {
+		ID:         "empty",
+		Status:     "Enabled",
+		Filter:     &rustfs.LifecycleFilter{},
+		Expiration: &rustfs.LifecycleExpiration{Days: &days},
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(encoded), "<Filter>"); n != 1 {
+		t.Errorf("expected only the configured empty filter to be sent, got %s", encoded)
+	}
+}
+
+func TestSetGetFullLifecycleConfiguration(t *testing.T) {
+	name := strings.ToLower(randomString(8))
+	dut := getClient()
+	if err := dut.CreateBucket(context.Background(), name); err != nil {
+		t.Fatal(err)
+	}
+	defer dut.DeleteBucket(context.Background(), name)
+
+	var config rustfs.LifecycleConfiguration
+	if err := xml.Unmarshal([]byte(fullLifecycleConfiguration), &config); err != nil {
+		t.Fatal(err)
+	}
+	// Transitions need an existing tier on a real server.
+	config.Rules[0].Transitions = nil
+	config.Rules[0].NoncurrentVersionTransitions = nil
+	if err := dut.SetBucketLifecycleConfiguration(context.Background(), name, &config); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := dut.GetBucketLifecycleConfiguration(context.Background(), name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read.Rules) != 3 || read.Rules[1].Filter.Tag == nil || read.Rules[2].Expiration.Date == "" {
+		t.Errorf("unexpected lifecycle configuration %+v", read)
+	}
 }
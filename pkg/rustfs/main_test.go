@@ -0,0 +1,23 @@
+package rustfs_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs/rustfstest"
+)
+
+// TestMain runs the tests against an in-process fake unless RUSTFS_ENDPOINT
+// points to a live server.
+func TestMain(m *testing.M) {
+	if os.Getenv("RUSTFS_ENDPOINT") != "" {
+		os.Exit(m.Run())
+	}
+	server := rustfstest.Start()
+	os.Setenv("RUSTFS_ENDPOINT", server.Endpoint())
+	os.Setenv("RUSTFS_USER", rustfstest.AccessKey)
+	os.Setenv("RUSTFS_SECRET", rustfstest.SecretKey)
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
@@ -0,0 +1,190 @@
+package rustfs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// NotificationTarget is a notification or audit target as listed by the
+// server. AccountID is the name the target was configured with and Service
+// its type, e.g. webhook.
+type NotificationTarget struct {
+	AccountID string `json:"account_id"`
+	Service   string `json:"service"`
+	Status    string `json:"status,omitempty"`
+}
+
+// TargetKeyValue is one configuration key of a target, e.g. endpoint.
+type TargetKeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Target types are the config subsystem followed by the service.
+const (
+	NotifyTargetPrefix = "notify_"
+	AuditTargetPrefix  = "audit_"
+)
+
+// SetNotificationTarget creates or replaces the target name of targetType,
+// e.g. notify_webhook or audit_kafka.
+func (c *RustfsAdmin) SetNotificationTarget(ctx context.Context, targetType, name string, keyValues []TargetKeyValue) error {
+	content, err := json.Marshal(struct {
+		KeyValues []TargetKeyValue `json:"key_values"`
+	}{keyValues})
+	if err != nil {
+		return err
+	}
+	reqData := RequestData{
+		Method:  "PUT",
+		RelPath: "target/" + targetType + "/" + name,
+		Content: content,
+	}
+	resp, err := c.doRequest(ctx, reqData)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
+// ListNotificationTargets returns the notification and audit targets
+// configured on the server.
+func (c *RustfsAdmin) ListNotificationTargets(ctx context.Context) ([]NotificationTarget, error) {
+	reqData := RequestData{
+		Method:  "GET",
+		RelPath: "target/list",
+	}
+	resp, err := c.doRequest(ctx, reqData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var list struct {
+		Endpoints []NotificationTarget `json:"notification_endpoints"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&list)
+	return list.Endpoints, err
+}
+
+// GetNotificationTarget returns the target name of targetType.
+func (c *RustfsAdmin) GetNotificationTarget(ctx context.Context, targetType, name string) (NotificationTarget, error) {
+	targets, err := c.ListNotificationTargets(ctx)
+	if err != nil {
+		return NotificationTarget{}, err
+	}
+	// Notification targets are listed by service only, audit targets with
+	// their subsystem.
+	service := strings.TrimPrefix(targetType, NotifyTargetPrefix)
+	for _, target := range targets {
+		if target.AccountID == name && target.Service == service {
+			return target, nil
+		}
+	}
+	return NotificationTarget{}, &APIError{
+		StatusCode: http.StatusNotFound,
+		Code:       "XRustFSAdminTargetNotFound",
+		Message:    "The notification target does not exist",
+		Resource:   targetType + ":" + name,
+	}
+}
+
+// GetNotificationTargetConfig returns the configuration keys of the target
+// name of targetType as stored by the server. The server replies with the
+// config line of the target, e.g.
+// notify_webhook:primary enable=on endpoint="https://hooks.example.com".
+func (c *RustfsAdmin) GetNotificationTargetConfig(ctx context.Context, targetType, name string) ([]TargetKeyValue, error) {
+	query := url.Values{}
+	query.Set("key", targetType+":"+name)
+	reqData := RequestData{
+		Method:      "GET",
+		RelPath:     "get-config-kv",
+		QueryValues: query,
+	}
+	resp, err := c.doRequest(ctx, reqData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(body), "\n") {
+		key, keyValues, _ := strings.Cut(strings.TrimSpace(line), " ")
+		if key == targetType+":"+name {
+			return parseConfigKeyValues(keyValues)
+		}
+	}
+	return nil, &APIError{
+		StatusCode: http.StatusNotFound,
+		Code:       "XRustFSAdminTargetNotFound",
+		Message:    "The notification target does not exist",
+		Resource:   targetType + ":" + name,
+	}
+}
+
+// parseConfigKeyValues parses the key=value pairs of a config line. Values
+// with spaces are quoted.
+func parseConfigKeyValues(line string) ([]TargetKeyValue, error) {
+	var keyValues []TargetKeyValue
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimSpace(line) {
+		key, rest, ok := strings.Cut(line, "=")
+		if !ok || key == "" || strings.Contains(key, " ") {
+			return nil, fmt.Errorf("invalid config key value %q", line)
+		}
+		value := rest
+		line = ""
+		if strings.HasPrefix(rest, `"`) {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of %s: %w", key, err)
+			}
+			value, _ = strconv.Unquote(quoted)
+			line = rest[len(quoted):]
+		} else if i := strings.IndexByte(rest, ' '); i >= 0 {
+			value, line = rest[:i], rest[i:]
+		}
+		keyValues = append(keyValues, TargetKeyValue{Key: key, Value: value})
+	}
+	return keyValues, nil
+}
+
+// RemoveNotificationTarget removes the target name of targetType.
+func (c *RustfsAdmin) RemoveNotificationTarget(ctx context.Context, targetType, name string) error {
+	reqData := RequestData{
+		Method:  "DELETE",
+		RelPath: "target/" + targetType + "/" + name + "/reset",
+	}
+	resp, err := c.doRequest(ctx, reqData)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
+
+// ListNotificationTargetARNs returns the ARNs of the notification targets
+// configured on the server, e.g. arn:rustfs:sqs::primary:webhook.
+func (c *RustfsAdmin) ListNotificationTargetARNs(ctx context.Context) ([]string, error) {
+	reqData := RequestData{
+		Method:  "GET",
+		RelPath: "target/arns",
+	}
+	resp, err := c.doRequest(ctx, reqData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var arns []string
+	err = json.NewDecoder(resp.Body).Decode(&arns)
+	return arns, err
+}
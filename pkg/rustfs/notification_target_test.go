@@ -0,0 +1,71 @@
+package rustfs_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+func TestNotificationTargetLifecycle(t *testing.T) {
+	ctx := context.Background()
+	name := strings.ToLower(randomString(8))
+	dut := getClient()
+
+	err := dut.SetNotificationTarget(ctx, "notify_webhook", name, []rustfs.TargetKeyValue{
+		{Key: "enable", Value: "on"},
+		{Key: "endpoint", Value: "https://hooks.example.com/rustfs"},
+		{Key: "queue_dir", Value: "/tmp/rustfs events"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target, err := dut.GetNotificationTarget(ctx, "notify_webhook", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target.AccountID != name || target.Service != "webhook" {
+		t.Errorf("unexpected target %+v", target)
+	}
+	arns, err := dut.ListNotificationTargetARNs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(arns, "arn:rustfs:sqs::"+name+":webhook") {
+		t.Errorf("expected the target ARN in %v", arns)
+	}
+
+	config, err := dut.GetNotificationTargetConfig(ctx, "notify_webhook", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, kv := range []rustfs.TargetKeyValue{
+		{Key: "enable", Value: "on"},
+		{Key: "endpoint", Value: "https://hooks.example.com/rustfs"},
+		{Key: "queue_dir", Value: "/tmp/rustfs events"},
+		// Unset keys are returned with their default.
+		{Key: "queue_limit", Value: "0"},
+	} {
+		if !slices.Contains(config, kv) {
+			t.Errorf("expected %+v in the target configuration %+v", kv, config)
+		}
+	}
+
+	// Audit targets with the same name are separate targets.
+	if _, err := dut.GetNotificationTarget(ctx, "audit_webhook", name); !rustfs.IsNotFound(err) {
+		t.Errorf("expected not found for the audit target, got %v", err)
+	}
+	if _, err := dut.GetNotificationTargetConfig(ctx, "audit_webhook", name); !rustfs.IsNotFound(err) {
+		t.Errorf("expected not found for the audit target configuration, got %v", err)
+	}
+
+	if err := dut.RemoveNotificationTarget(ctx, "notify_webhook", name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dut.GetNotificationTarget(ctx, "notify_webhook", name); !rustfs.IsNotFound(err) {
+		t.Errorf("expected not found after removal, got %v", err)
+	}
+}
@@ -1,24 +1,43 @@
 package rustfs
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"net/url"
-	"strings"
+	"slices"
 )
 
+// PolicyStatement is a statement of an IAM or bucket policy. Either Action
+// or NotAction and either Resource or NotResource are set.
 type PolicyStatement struct {
-	Effect   string   `json:"Effect"`
-	Action   []string `json:"Action"`
-	Resource []string `json:"Resource"`
+	Sid         string          `json:"Sid,omitempty"`
+	Effect      string          `json:"Effect"`
+	Principal   PolicyPrincipal `json:"Principal,omitempty"`
+	Action      StringList      `json:"Action,omitempty"`
+	NotAction   StringList      `json:"NotAction,omitempty"`
+	Resource    StringList      `json:"Resource,omitempty"`
+	NotResource StringList      `json:"NotResource,omitempty"`
+	Condition   PolicyCondition `json:"Condition,omitempty"`
 }
 
-type PolicyStatementSinle struct {
-	Effect   string   `json:"Effect"`
-	Action   string   `json:"Action"`
-	Resource []string `json:"Resource"`
-}
+// PolicyPrincipal maps a principal type, e.g. AWS, to its principals. The
+// wildcard principal "*" is read as {"AWS":["*"]}.
+type PolicyPrincipal map[string]StringList
+
+// PolicyCondition maps a condition operator, e.g. StringLike, to its keys
+// and their values.
+type PolicyCondition map[string]map[string]ConditionValues
+
+// StringList is a policy value written either as a single string or as an
+// array.
+type StringList []string
+
+// ConditionValues are the values of a condition key, written either as a
+// single value or as an array. Numbers and booleans are valid condition
+// values, so every value is kept as its JSON text and written back unchanged.
+type ConditionValues []json.RawMessage
 
 type Policy struct {
 	Version   string            `json:"Version"`
@@ -26,12 +45,104 @@ type Policy struct {
 	Name      string
 }
 
+func (l *StringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = StringList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("policy value %s is neither a string nor a list of strings", data)
+	}
+	*l = list
+	return nil
+}
+
+// ConditionStrings returns the condition values for the given strings.
+func ConditionStrings(values ...string) ConditionValues {
+	list := ConditionValues{}
+	for _, value := range values {
+		encoded, _ := json.Marshal(value)
+		list = append(list, encoded)
+	}
+	return list
+}
+
+// Strings returns the values as text. Strings are unquoted, numbers and
+// booleans are returned in their JSON form.
+func (l ConditionValues) Strings() []string {
+	list := []string{}
+	for _, value := range l {
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			s = string(value)
+		}
+		list = append(list, s)
+	}
+	return list
+}
+
+func (l *ConditionValues) UnmarshalJSON(data []byte) error {
+	var values []json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		values = []json.RawMessage{data}
+	}
+	list := ConditionValues{}
+	for _, value := range values {
+		var scalar any
+		if err := json.Unmarshal(value, &scalar); err != nil {
+			return err
+		}
+		switch scalar.(type) {
+		case string, bool, float64:
+		default:
+			return fmt.Errorf("condition value %s is neither a string, a number nor a boolean", value)
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, value); err != nil {
+			return err
+		}
+		list = append(list, compact.Bytes())
+	}
+	*l = list
+	return nil
+}
+
+func (p *PolicyPrincipal) UnmarshalJSON(data []byte) error {
+	var wildcard string
+	if err := json.Unmarshal(data, &wildcard); err == nil {
+		*p = PolicyPrincipal{"AWS": {wildcard}}
+		return nil
+	}
+	var principal map[string]StringList
+	if err := json.Unmarshal(data, &principal); err != nil {
+		return err
+	}
+	*p = principal
+	return nil
+}
+
 type statementReply struct {
-	Statement []PolicyStatement `json:"Statement"`
+	Statement StatementList `json:"Statement"`
 }
 
-type statementReplySingle struct {
-	Statement []PolicyStatementSinle `json:"Statement"`
+// StatementList is the Statement of a policy, which may be a single
+// statement object instead of an array.
+type StatementList []PolicyStatement
+
+func (l *StatementList) UnmarshalJSON(data []byte) error {
+	var statements []PolicyStatement
+	if err := json.Unmarshal(data, &statements); err == nil {
+		*l = statements
+		return nil
+	}
+	var statement PolicyStatement
+	if err := json.Unmarshal(data, &statement); err != nil {
+		return err
+	}
+	*l = StatementList{statement}
+	return nil
 }
 
 type policyReply struct {
@@ -44,7 +155,7 @@ type policyPost struct {
 	Statement []PolicyStatement `json:"Statement"`
 }
 
-func (c *RustfsAdmin) CreatePolicy(policy Policy) error {
+func (c *RustfsAdmin) CreatePolicy(ctx context.Context, policy Policy) error {
 	urlValues := make(url.Values)
 	urlValues.Set("name", policy.Name)
 	policy.Version = "2012-10-17" // only this is working
@@ -63,8 +174,6 @@ func (c *RustfsAdmin) CreatePolicy(policy Policy) error {
 		Content:     bytes,
 		QueryValues: urlValues,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	_, err = c.doRequest(ctx, req_data)
 	if err != nil {
 		return err
@@ -72,10 +181,8 @@ func (c *RustfsAdmin) CreatePolicy(policy Policy) error {
 	return nil
 }
 
-func (c *RustfsAdmin) ReadPolicy(policy string) (Policy, error) {
+func (c *RustfsAdmin) ReadPolicy(ctx context.Context, policy string) (Policy, error) {
 	var instance policyReply
-	var statement statementReply
-	var statement_single statementReplySingle
 	var read Policy
 	urlValues := make(url.Values)
 	urlValues.Set("name", policy)
@@ -85,8 +192,6 @@ func (c *RustfsAdmin) ReadPolicy(policy string) (Policy, error) {
 		QueryValues: urlValues,
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, req_data)
 	if err != nil {
 		return Policy{}, err
@@ -110,33 +215,83 @@ func (c *RustfsAdmin) ReadPolicy(policy string) (Policy, error) {
 		policyBytes = []byte(asString)
 	}
 
-	err = json.NewDecoder(strings.NewReader(string(policyBytes))).Decode(&statement)
-	// We have no error!
-	if err == nil {
-		read.Statement = statement.Statement
-		return read, nil
-	}
-	err_single := json.NewDecoder(strings.NewReader(string(policyBytes))).Decode(&statement_single)
-	if err_single == nil {
-		statements := []PolicyStatement{}
-		for _, got := range statement_single.Statement {
-			statements = append(statements,
-				PolicyStatement{
-					Effect:   got.Effect,
-					Action:   []string{got.Action},
-					Resource: got.Resource,
-				},
-			)
+	parsed, err := ParsePolicy(policyBytes)
+	if err != nil {
+		return Policy{}, err
+	}
+	read.Statement = parsed.Statement
+	return read, nil
+}
+
+// ParsePolicy parses a policy document. Every field may use the single
+// string form.
+func ParsePolicy(document []byte) (Policy, error) {
+	var statement statementReply
+	if err := json.Unmarshal(document, &statement); err != nil {
+		return Policy{}, err
+	}
+	return Policy{
+		Version:   "2012-10-17",
+		Statement: statement.Statement,
+	}, nil
+}
+
+// CanonicalJSON returns the policy document with all values as sorted arrays
+// and keys in a fixed order. Documents that only differ in the form of their
+// values have the same canonical form.
+func (p Policy) CanonicalJSON() ([]byte, error) {
+	statements := make([]PolicyStatement, 0, len(p.Statement))
+	for _, statement := range p.Statement {
+		statement.Action = sortedList(statement.Action)
+		statement.NotAction = sortedList(statement.NotAction)
+		statement.Resource = sortedList(statement.Resource)
+		statement.NotResource = sortedList(statement.NotResource)
+		if statement.Principal != nil {
+			principal := PolicyPrincipal{}
+			for principalType, values := range statement.Principal {
+				principal[principalType] = sortedList(values)
+			}
+			statement.Principal = principal
+		}
+		if statement.Condition != nil {
+			condition := PolicyCondition{}
+			for operator, keys := range statement.Condition {
+				condition[operator] = map[string]ConditionValues{}
+				for key, values := range keys {
+					condition[operator][key] = sortedConditionValues(values)
+				}
+			}
+			statement.Condition = condition
 		}
-		read.Statement = statements
-		return read, nil
+		statements = append(statements, statement)
 	}
+	return json.Marshal(policyPost{
+		Version:   "2012-10-17",
+		Statement: statements,
+	})
+}
 
-	return Policy{}, errors.Join(err, err_single)
+func sortedList(values StringList) StringList {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return sorted
+}
 
+func sortedConditionValues(values ConditionValues) ConditionValues {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, func(a, b json.RawMessage) int {
+		return bytes.Compare(a, b)
+	})
+	return sorted
 }
 
-func (c *RustfsAdmin) DeletePolicy(policy string) error {
+func (c *RustfsAdmin) DeletePolicy(ctx context.Context, policy string) error {
 
 	urlValues := make(url.Values)
 	urlValues.Set("name", policy)
@@ -146,8 +301,6 @@ func (c *RustfsAdmin) DeletePolicy(policy string) error {
 		QueryValues: urlValues,
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	_, err := c.doRequest(ctx, req_data)
 	return err
 
@@ -0,0 +1,138 @@
+package rustfs
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+// S3Actions are the S3 actions RustFS evaluates in policies.
+var S3Actions = []string{
+	"s3:AbortMultipartUpload",
+	"s3:BypassGovernanceRetention",
+	"s3:CreateBucket",
+	"s3:DeleteBucket",
+	"s3:DeleteBucketCors",
+	"s3:DeleteBucketPolicy",
+	"s3:DeleteBucketTagging",
+	"s3:DeleteObject",
+	"s3:DeleteObjectTagging",
+	"s3:DeleteObjectVersion",
+	"s3:DeleteObjectVersionTagging",
+	"s3:ForceDeleteBucket",
+	"s3:GetBucketAcl",
+	"s3:GetBucketCors",
+	"s3:GetBucketLocation",
+	"s3:GetBucketNotification",
+	"s3:GetBucketObjectLockConfiguration",
+	"s3:GetBucketPolicy",
+	"s3:GetBucketPolicyStatus",
+	"s3:GetBucketTagging",
+	"s3:GetBucketVersioning",
+	"s3:GetEncryptionConfiguration",
+	"s3:GetLifecycleConfiguration",
+	"s3:GetObject",
+	"s3:GetObjectAcl",
+	"s3:GetObjectAttributes",
+	"s3:GetObjectLegalHold",
+	"s3:GetObjectRetention",
+	"s3:GetObjectTagging",
+	"s3:GetObjectVersion",
+	"s3:GetObjectVersionAttributes",
+	"s3:GetObjectVersionForReplication",
+	"s3:GetObjectVersionTagging",
+	"s3:GetReplicationConfiguration",
+	"s3:ListAllMyBuckets",
+	"s3:ListBucket",
+	"s3:ListBucketMultipartUploads",
+	"s3:ListBucketVersions",
+	"s3:ListMultipartUploadParts",
+	"s3:ListenBucketNotification",
+	"s3:ListenNotification",
+	"s3:PutBucketAcl",
+	"s3:PutBucketCors",
+	"s3:PutBucketNotification",
+	"s3:PutBucketObjectLockConfiguration",
+	"s3:PutBucketPolicy",
+	"s3:PutBucketTagging",
+	"s3:PutBucketVersioning",
+	"s3:PutEncryptionConfiguration",
+	"s3:PutLifecycleConfiguration",
+	"s3:PutObject",
+	"s3:PutObjectAcl",
+	"s3:PutObjectFanOut",
+	"s3:PutObjectLegalHold",
+	"s3:PutObjectRetention",
+	"s3:PutObjectTagging",
+	"s3:PutObjectVersionTagging",
+	"s3:PutReplicationConfiguration",
+	"s3:ReplicateDelete",
+	"s3:ReplicateObject",
+	"s3:ReplicateTags",
+	"s3:ResetBucketReplicationState",
+	"s3:RestoreObject",
+}
+
+// AdminActions are the admin API actions RustFS evaluates in policies.
+var AdminActions = []string{
+	"admin:AddUserToGroup",
+	"admin:AttachUserOrGroupPolicy",
+	"admin:BandwidthMonitor",
+	"admin:ConfigUpdate",
+	"admin:CreatePolicy",
+	"admin:CreateServiceAccount",
+	"admin:CreateUser",
+	"admin:DataUsageInfo",
+	"admin:DeletePolicy",
+	"admin:DeleteUser",
+	"admin:DisableGroup",
+	"admin:DisableUser",
+	"admin:EnableGroup",
+	"admin:EnableUser",
+	"admin:ExportBucketMetadata",
+	"admin:ExportIAM",
+	"admin:GetBucketQuota",
+	"admin:GetBucketTarget",
+	"admin:GetGroup",
+	"admin:GetPolicy",
+	"admin:GetUser",
+	"admin:Heal",
+	"admin:ImportBucketMetadata",
+	"admin:ImportIAM",
+	"admin:ListGroups",
+	"admin:ListServiceAccounts",
+	"admin:ListTemporaryAccounts",
+	"admin:ListTier",
+	"admin:ListUserPolicies",
+	"admin:ListUsers",
+	"admin:Prometheus",
+	"admin:Rebalance",
+	"admin:RemoveServiceAccount",
+	"admin:RemoveUserFromGroup",
+	"admin:ServerInfo",
+	"admin:ServerTrace",
+	"admin:ServiceRestart",
+	"admin:ServiceStop",
+	"admin:SetBucketQuota",
+	"admin:SetBucketTarget",
+	"admin:SetTier",
+	"admin:StorageInfo",
+	"admin:TopLocksInfo",
+	"admin:UpdateServiceAccount",
+}
+
+// IsPolicyAction reports whether action is "*", a known S3 or admin action
+// or a wildcard pattern such as s3:Get* matching at least one of them.
+// Actions are case insensitive.
+func IsPolicyAction(action string) bool {
+	if action == "*" {
+		return true
+	}
+	pattern := strings.ToLower(action)
+	for _, known := range slices.Concat(S3Actions, AdminActions) {
+		if ok, err := path.Match(pattern, strings.ToLower(known)); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package rustfs
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PolicyEntities are the effective policy mappings of users, groups and
+// policies.
+type PolicyEntities struct {
+	UserMappings   []UserPolicyMapping   `json:"userMappings"`
+	GroupMappings  []GroupPolicyMapping  `json:"groupMappings"`
+	PolicyMappings []PolicyEntityMapping `json:"policyMappings"`
+}
+
+type UserPolicyMapping struct {
+	User     string   `json:"user"`
+	Policies []string `json:"policies"`
+}
+
+type GroupPolicyMapping struct {
+	Group    string   `json:"group"`
+	Policies []string `json:"policies"`
+}
+
+type PolicyEntityMapping struct {
+	Policy string   `json:"policy"`
+	Users  []string `json:"users"`
+	Groups []string `json:"groups"`
+}
+
+// PolicyEntitiesQuery restricts the mappings returned by GetPolicyEntities.
+type PolicyEntitiesQuery struct {
+	Users    []string
+	Groups   []string
+	Policies []string
+}
+
+// GetPolicyEntities returns the policies attached to the queried users and
+// groups and the users and groups the queried policies are attached to.
+func (c *RustfsAdmin) GetPolicyEntities(ctx context.Context, query PolicyEntitiesQuery) (PolicyEntities, error) {
+	urlValues := make(url.Values)
+	for _, user := range query.Users {
+		urlValues.Add("user", user)
+	}
+	for _, group := range query.Groups {
+		urlValues.Add("group", group)
+	}
+	for _, policy := range query.Policies {
+		urlValues.Add("policy", policy)
+	}
+	reqData := RequestData{
+		Method:      "GET",
+		RelPath:     "idp/builtin/policy-entities",
+		QueryValues: urlValues,
+	}
+	resp, err := c.doRequest(ctx, reqData)
+	if err != nil {
+		return PolicyEntities{}, err
+	}
+	defer resp.Body.Close()
+	var entities PolicyEntities
+	err = json.NewDecoder(resp.Body).Decode(&entities)
+	return entities, err
+}
+
+// SetUserPolicies replaces the policies attached to a user. No policies
+// detach all of them.
+func (c *RustfsAdmin) SetUserPolicies(ctx context.Context, user string, policies []string) error {
+	return c.setUserOrGroupPolicy(ctx, user, false, strings.Join(policies, ","))
+}
+
+// SetGroupPolicies replaces the policies attached to a group. No policies
+// detach all of them.
+func (c *RustfsAdmin) SetGroupPolicies(ctx context.Context, group string, policies []string) error {
+	return c.setUserOrGroupPolicy(ctx, group, true, strings.Join(policies, ","))
+}
+
+// setUserOrGroupPolicy attaches the comma separated policies to a user or
+// group, replacing the policies attached before.
+func (c *RustfsAdmin) setUserOrGroupPolicy(ctx context.Context, entity string, isGroup bool, policies string) error {
+	urlValues := make(url.Values)
+	urlValues.Set("userOrGroup", entity)
+	urlValues.Set("policyName", policies)
+	urlValues.Set("isGroup", strconv.FormatBool(isGroup))
+	reqData := RequestData{
+		Method:      "PUT",
+		RelPath:     "set-user-or-group-policy",
+		QueryValues: urlValues,
+	}
+	resp, err := c.doRequest(ctx, reqData)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
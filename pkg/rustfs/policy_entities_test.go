@@ -0,0 +1,68 @@
+package rustfs_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+func TestUserAndGroupPolicies(t *testing.T) {
+	ctx := context.Background()
+	dut := getClient()
+	account := rustfs.UserAccount{
+		AccessKey: randomString(8),
+		SecretKey: randomString(8),
+	}
+	if err := dut.CreateUserAccount(ctx, account); err != nil {
+		t.Fatal(err)
+	}
+	defer dut.DeleteUserAccount(ctx, account)
+	group := randomString(8)
+	if err := dut.UpdateGroupMembers(ctx, rustfs.GroupAddRemove{Group: group, Members: []string{account.AccessKey}}); err != nil {
+		t.Fatal(err)
+	}
+	defer dut.DeleteGroup(ctx, group)
+
+	if err := dut.SetUserPolicies(ctx, account.AccessKey, []string{"readonly", "diagnostics"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dut.SetGroupPolicies(ctx, group, []string{"readwrite"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entities, err := dut.GetPolicyEntities(ctx, rustfs.PolicyEntitiesQuery{
+		Users:    []string{account.AccessKey},
+		Groups:   []string{group},
+		Policies: []string{"readwrite"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entities.UserMappings) != 1 || !slices.Equal(entities.UserMappings[0].Policies, []string{"readonly", "diagnostics"}) {
+		t.Errorf("unexpected user mappings %+v", entities.UserMappings)
+	}
+	if len(entities.GroupMappings) != 1 || !slices.Equal(entities.GroupMappings[0].Policies, []string{"readwrite"}) {
+		t.Errorf("unexpected group mappings %+v", entities.GroupMappings)
+	}
+	if len(entities.PolicyMappings) != 1 || !slices.Contains(entities.PolicyMappings[0].Groups, group) {
+		t.Errorf("unexpected policy mappings %+v", entities.PolicyMappings)
+	}
+
+	// No policies detach all of them.
+	if err := dut.SetUserPolicies(ctx, account.AccessKey, nil); err != nil {
+		t.Fatal(err)
+	}
+	entities, err = dut.GetPolicyEntities(ctx, rustfs.PolicyEntitiesQuery{Users: []string{account.AccessKey}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entities.UserMappings) != 1 || len(entities.UserMappings[0].Policies) != 0 {
+		t.Errorf("expected no user policies, got %+v", entities.UserMappings)
+	}
+
+	if _, err := dut.GetPolicyEntities(ctx, rustfs.PolicyEntitiesQuery{Users: []string{randomString(8)}}); !rustfs.IsNotFound(err) {
+		t.Errorf("expected an unknown user to be not found, got %v", err)
+	}
+}
@@ -1,6 +1,10 @@
 package rustfs_test
 
 import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
@@ -27,18 +31,169 @@ func TestCreateAndDeletePolicy(t *testing.T) {
 		Name:      name,
 		Statement: statements,
 	}
-	err := dut.CreatePolicy(policy)
+	err := dut.CreatePolicy(context.Background(), policy)
 	if err != nil {
 		t.Error(err)
 	}
 
-	read, _ := dut.ReadPolicy(policy.Name)
+	read, _ := dut.ReadPolicy(context.Background(), policy.Name)
 	if read.Name != policy.Name {
 		t.Error("read back not working")
 	}
 
-	err = dut.DeletePolicy(name)
+	err = dut.DeletePolicy(context.Background(), name)
 	if err != nil {
 		t.Error(err)
 	}
 }
+
+func TestPolicyStatementMixedForms(t *testing.T) {
+	var policy struct {
+		Statement rustfs.StatementList `json:"Statement"`
+	}
+	doc := `{"Statement":{"Sid":"Home","Effect":"Allow","Principal":"*","NotAction":"s3:DeleteObject","Resource":"arn:aws:s3:::home/*",` +
+		`"Condition":{"StringLike":{"s3:prefix":["home/${aws:username}/*","shared/"]},"Bool":{"aws:SecureTransport":true},"NumericLessThanEquals":{"s3:max-keys":"10"}}}}`
+	if err := json.Unmarshal([]byte(doc), &policy); err != nil {
+		t.Fatal(err)
+	}
+	if len(policy.Statement) != 1 {
+		t.Fatalf("expected one statement, got %+v", policy.Statement)
+	}
+	statement := policy.Statement[0]
+	if statement.Sid != "Home" || !slices.Equal(statement.NotAction, []string{"s3:DeleteObject"}) || !slices.Equal(statement.Resource, []string{"arn:aws:s3:::home/*"}) {
+		t.Errorf("unexpected statement %+v", statement)
+	}
+	if !slices.Equal(statement.Principal["AWS"], []string{"*"}) {
+		t.Errorf("expected the wildcard principal, got %v", statement.Principal)
+	}
+	if !slices.Equal(statement.Condition["StringLike"]["s3:prefix"].Strings(), []string{"home/${aws:username}/*", "shared/"}) {
+		t.Errorf("unexpected condition %v", statement.Condition)
+	}
+	if !slices.Equal(statement.Condition["Bool"]["aws:SecureTransport"].Strings(), []string{"true"}) || !slices.Equal(statement.Condition["NumericLessThanEquals"]["s3:max-keys"].Strings(), []string{"10"}) {
+		t.Errorf("unexpected condition %v", statement.Condition)
+	}
+
+	encoded, err := json.Marshal(statement)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(encoded), `"Action"`) || strings.Contains(string(encoded), "NotResource") {
+		t.Errorf("expected unset fields to be omitted, got %s", encoded)
+	}
+}
+
+func TestReadPolicyWithConditions(t *testing.T) {
+	dut := getClient()
+	name := "conditions" + randomString(6)
+	policy := rustfs.Policy{
+		Name: name,
+		Statement: []rustfs.PolicyStatement{
+			{
+				Sid:      "ListHome",
+				Effect:   "Allow",
+				Action:   rustfs.StringList{"s3:ListBucket"},
+				Resource: rustfs.StringList{"arn:aws:s3:::home"},
+				Condition: rustfs.PolicyCondition{
+					"StringLike": {"s3:prefix": rustfs.ConditionStrings("home/${aws:username}/*")},
+					"IpAddress":  {"aws:SourceIp": rustfs.ConditionStrings("10.0.0.0/8", "192.168.0.0/16")},
+				},
+			},
+			{
+				Effect:      "Deny",
+				NotAction:   rustfs.StringList{"s3:GetObject"},
+				NotResource: rustfs.StringList{"arn:aws:s3:::public/*"},
+			},
+		},
+	}
+	if err := dut.CreatePolicy(context.Background(), policy); err != nil {
+		t.Fatal(err)
+	}
+	defer dut.DeletePolicy(context.Background(), name)
+
+	read, err := dut.ReadPolicy(context.Background(), name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read.Statement) != 2 {
+		t.Fatalf("expected 2 statements, got %+v", read.Statement)
+	}
+	if read.Statement[0].Sid != "ListHome" || !slices.Equal(read.Statement[0].Condition["IpAddress"]["aws:SourceIp"].Strings(), []string{"10.0.0.0/8", "192.168.0.0/16"}) {
+		t.Errorf("unexpected statement %+v", read.Statement[0])
+	}
+	if !slices.Equal(read.Statement[1].NotAction, []string{"s3:GetObject"}) || !slices.Equal(read.Statement[1].NotResource, []string{"arn:aws:s3:::public/*"}) {
+		t.Errorf("unexpected statement %+v", read.Statement[1])
+	}
+}
+
+func TestPolicyCanonicalJSON(t *testing.T) {
+	single, err := rustfs.ParsePolicy([]byte(`{"Statement":{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::bucket/*","Condition":{"IpAddress":{"aws:SourceIp":"10.0.0.0/8"}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := rustfs.ParsePolicy([]byte(`{"Version":"2012-10-17","Statement":[{"Resource":["arn:aws:s3:::bucket/*"],"Condition":{"IpAddress":{"aws:SourceIp":["10.0.0.0/8"]}},"Action":["s3:GetObject"],"Effect":"Allow"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	singleJSON, _ := single.CanonicalJSON()
+	listJSON, _ := list.CanonicalJSON()
+	if string(singleJSON) != string(listJSON) {
+		t.Errorf("expected equal canonical forms, got %s and %s", singleJSON, listJSON)
+	}
+	expected := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::bucket/*"],"Condition":{"IpAddress":{"aws:SourceIp":["10.0.0.0/8"]}}}]}`
+	if string(listJSON) != expected {
+		t.Errorf("expected %s, got %s", expected, listJSON)
+	}
+}
+
+func TestPolicyConditionValuesRoundTrip(t *testing.T) {
+	document := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:ListBucket"],"Resource":["arn:aws:s3:::bucket"],` +
+		`"Condition":{"Bool":{"aws:SecureTransport":[true]},"NumericLessThan":{"s3:max-keys":[5]},"StringEquals":{"s3:prefix":["5"]}}}]}`
+	policy, err := rustfs.ParsePolicy([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := policy.CanonicalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != document {
+		t.Errorf("expected %s, got %s", document, encoded)
+	}
+
+	single, err := rustfs.ParsePolicy([]byte(`{"Statement":{"Effect":"Allow","Action":"s3:ListBucket","Resource":"arn:aws:s3:::bucket",` +
+		`"Condition":{"Bool":{"aws:SecureTransport":true},"NumericLessThan":{"s3:max-keys": 5},"StringEquals":{"s3:prefix":"5"}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	singleJSON, err := single.CanonicalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(singleJSON) != document {
+		t.Errorf("expected %s, got %s", document, singleJSON)
+	}
+
+	if _, err := rustfs.ParsePolicy([]byte(`{"Statement":{"Effect":"Allow","Condition":{"StringEquals":{"s3:prefix":{"a":"b"}}}}}`)); err == nil {
+		t.Error("expected an object condition value to be rejected")
+	}
+}
+
+func TestIsPolicyAction(t *testing.T) {
+	for action, expected := range map[string]bool{
+		"*":                true,
+		"s3:*":             true,
+		"s3:GetObject":     true,
+		"s3:getobject":     true,
+		"s3:Get*":          true,
+		"admin:*":          true,
+		"admin:CreateUser": true,
+		"s3:GetObjekt":     false,
+		"s3:Frobnicate*":   false,
+		"ec2:*":            false,
+		"GetObject":        false,
+	} {
+		if rustfs.IsPolicyAction(action) != expected {
+			t.Errorf("expected IsPolicyAction(%q) to be %v", action, expected)
+		}
+	}
+}
@@ -9,13 +9,11 @@ type PoolInfo struct {
 	Name string `json:"name"`
 }
 
-func (c *RustfsAdmin) ListPools() ([]PoolInfo, error) {
+func (c *RustfsAdmin) ListPools(ctx context.Context) ([]PoolInfo, error) {
 	reqData := RequestData{
 		Method:  "GET",
 		RelPath: "list-pools",
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, reqData)
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package rustfs
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -27,7 +28,7 @@ func TestListPools(t *testing.T) {
 	})
 	client.accessSecret = "secret"
 
-	pools, err := client.ListPools()
+	pools, err := client.ListPools(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
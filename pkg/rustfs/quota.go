@@ -11,13 +11,11 @@ type Quota struct {
 	Quota_Type string `json:"quota_type"`
 }
 
-func (c *RustfsAdmin) ReadQuota(bucket string) (quota Quota, err error) {
+func (c *RustfsAdmin) ReadQuota(ctx context.Context, bucket string) (quota Quota, err error) {
 	req_data := RequestData{
 		Method:  "GET",
 		RelPath: "quota/" + bucket,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, req_data)
 	if err != nil {
 		return quota, err
@@ -26,7 +24,7 @@ func (c *RustfsAdmin) ReadQuota(bucket string) (quota Quota, err error) {
 	return quota, err
 }
 
-func (c *RustfsAdmin) SetQuota(new Quota) (quota Quota, err error) {
+func (c *RustfsAdmin) SetQuota(ctx context.Context, new Quota) (quota Quota, err error) {
 	new.Quota_Type = "HARD"
 	bytes, err := json.Marshal(new)
 	if err != nil {
@@ -37,8 +35,6 @@ func (c *RustfsAdmin) SetQuota(new Quota) (quota Quota, err error) {
 		RelPath: "quota/" + new.Bucket,
 		Content: bytes,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, req_data)
 	if err != nil {
 		return Quota{}, err
@@ -48,7 +44,7 @@ func (c *RustfsAdmin) SetQuota(new Quota) (quota Quota, err error) {
 	return quota, err
 }
 
-func (c *RustfsAdmin) DeletQuota(bucket string) (err error) {
+func (c *RustfsAdmin) DeletQuota(ctx context.Context, bucket string) (err error) {
 
 	if err != nil {
 		return err
@@ -57,8 +53,6 @@ func (c *RustfsAdmin) DeletQuota(bucket string) (err error) {
 		Method:  "DELETE",
 		RelPath: "quota/" + bucket,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, req_data)
 	if err != nil {
 		return err
@@ -1,6 +1,7 @@
 package rustfs_test
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
@@ -12,17 +13,17 @@ func TestReadQuota(t *testing.T) {
 	name := randomString(8)
 	dut := getClient()
 	name = strings.ToLower(name)
-	if err := dut.CreateBucket(name); err != nil {
+	if err := dut.CreateBucket(context.Background(), name); err != nil {
 		t.Fatal(err)
 	}
-	resp, err := dut.ReadQuota(name)
+	resp, err := dut.ReadQuota(context.Background(), name)
 	if err != nil {
 		t.Error(err)
 	}
 	if resp.Bucket != name {
 		t.Error("Bucket readback unexpected value")
 	}
-	if err := dut.DeleteBucket(name); err != nil {
+	if err := dut.DeleteBucket(context.Background(), name); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -35,19 +36,19 @@ func TestCRDQuota(t *testing.T) {
 		Quota:  100054541,
 	}
 	dut := getClient()
-	if err := dut.CreateBucket(name); err != nil {
+	if err := dut.CreateBucket(context.Background(), name); err != nil {
 		t.Fatal(err)
 	}
-	_, err := dut.ReadQuota(name)
+	_, err := dut.ReadQuota(context.Background(), name)
 	if err != nil {
 		t.Fatal(err)
 	}
 	time.Sleep(5 * time.Second)
-	resp, err := dut.SetQuota(quota)
+	resp, err := dut.SetQuota(context.Background(), quota)
 	if err != nil {
 		t.Error(err)
 	}
-	resp, err = dut.ReadQuota(name)
+	resp, err = dut.ReadQuota(context.Background(), name)
 	if err != nil {
 		t.Error(err)
 	}
@@ -55,7 +56,7 @@ func TestCRDQuota(t *testing.T) {
 		t.Error("Readback gave wrong quota")
 	}
 
-	if err := dut.DeletQuota(name); err != nil {
+	if err := dut.DeletQuota(context.Background(), name); err != nil {
 		t.Error("error during quota remove")
 	}
 }
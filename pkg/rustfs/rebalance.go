@@ -4,13 +4,11 @@ import (
 	"context"
 )
 
-func (c *RustfsAdmin) StartRebalance() error {
+func (c *RustfsAdmin) StartRebalance(ctx context.Context) error {
 	reqData := RequestData{
 		Method:  "PUT",
 		RelPath: "rebalance/start",
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, reqData)
 	if err != nil {
 		return err
@@ -1,6 +1,7 @@
 package rustfs
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -21,7 +22,7 @@ func TestStartRebalance(t *testing.T) {
 	})
 	client.accessSecret = "secret"
 
-	err := client.StartRebalance()
+	err := client.StartRebalance(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
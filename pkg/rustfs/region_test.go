@@ -0,0 +1,81 @@
+package rustfs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegion_Signing(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		want   string
+	}{
+		{"default", "", "/us-east-1/s3/aws4_request"},
+		{"configured", "eu-central-1", "/eu-central-1/s3/aws4_request"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authorization string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+				w.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+
+			client := New(&RustfsAdminConfig{
+				Endpoint:     server.Listener.Addr().String(),
+				AccessKey:    "admin",
+				AccessSecret: "secret",
+				Region:       tt.region,
+			})
+			if _, err := client.ListPools(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(authorization, tt.want) {
+				t.Errorf("expected credential scope %s, got %s", tt.want, authorization)
+			}
+		})
+	}
+}
+
+func TestRegion_CreateBucketLocation(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		body   string
+	}{
+		{"default", "", ""},
+		{"configured", "eu-central-1", "<LocationConstraint>eu-central-1</LocationConstraint>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+			}))
+			defer server.Close()
+
+			client := New(&RustfsAdminConfig{
+				Endpoint:     server.Listener.Addr().String(),
+				AccessKey:    "admin",
+				AccessSecret: "secret",
+				Region:       tt.region,
+			})
+			if err := client.CreateBucket(context.Background(), "bucket"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.body == "" && body != "" {
+				t.Errorf("expected empty body, got %s", body)
+			}
+			if !strings.Contains(body, tt.body) {
+				t.Errorf("expected body to contain %s, got %s", tt.body, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package rustfs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// BucketTarget is a remote endpoint a bucket replicates to. The server
+// assigns the Arn, which replication rules use as destination bucket.
+type BucketTarget struct {
+	SourceBucket    string             `json:"sourcebucket"`
+	Endpoint        string             `json:"endpoint"`
+	Credentials     *TargetCredentials `json:"credentials"`
+	TargetBucket    string             `json:"targetbucket"`
+	Secure          bool               `json:"secure"`
+	Path            string             `json:"path,omitempty"`
+	Arn             string             `json:"arn,omitempty"`
+	Type            string             `json:"type"`
+	Region          string             `json:"region,omitempty"`
+	BandwidthLimit  int64              `json:"bandwidthlimit,omitempty"`
+	ReplicationSync bool               `json:"replicationSync"`
+	StorageClass    string             `json:"storageclass,omitempty"`
+	DisableProxy    bool               `json:"disableProxy"`
+}
+
+type TargetCredentials struct {
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+}
+
+// ReplicationTargetType is the only target type replication rules accept.
+const ReplicationTargetType = "replication"
+
+// SetRemoteTarget registers target for target.SourceBucket and returns the
+// ARN assigned by the server.
+func (c *RustfsAdmin) SetRemoteTarget(ctx context.Context, target BucketTarget) (string, error) {
+	return c.putRemoteTarget(ctx, target, false)
+}
+
+// UpdateRemoteTarget changes the remote target with target.Arn.
+func (c *RustfsAdmin) UpdateRemoteTarget(ctx context.Context, target BucketTarget) (string, error) {
+	return c.putRemoteTarget(ctx, target, true)
+}
+
+func (c *RustfsAdmin) putRemoteTarget(ctx context.Context, target BucketTarget, update bool) (string, error) {
+	if target.Type == "" {
+		target.Type = ReplicationTargetType
+	}
+	content, err := json.Marshal(target)
+	if err != nil {
+		return "", err
+	}
+	urlValues := make(url.Values)
+	urlValues.Set("bucket", target.SourceBucket)
+	if update {
+		urlValues.Set("update", "true")
+	}
+	reqData := RequestData{
+		Method:      "PUT",
+		RelPath:     "set-remote-target",
+		Content:     content,
+		QueryValues: urlValues,
+	}
+	resp, err := c.doRequest(ctx, reqData)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// The ARN is sent as JSON string, older servers send it verbatim.
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	var arn string
+	if json.Unmarshal(body, &arn) != nil {
+		arn = string(bytes.TrimSpace(body))
+	}
+	if arn == "" {
+		arn = target.Arn
+	}
+	return arn, nil
+}
+
+// ListRemoteTargets returns the replication targets of bucket.
+func (c *RustfsAdmin) ListRemoteTargets(ctx context.Context, bucket string) ([]BucketTarget, error) {
+	urlValues := make(url.Values)
+	urlValues.Set("bucket", bucket)
+	urlValues.Set("type", ReplicationTargetType)
+	reqData := RequestData{
+		Method:      "GET",
+		RelPath:     "list-remote-targets",
+		QueryValues: urlValues,
+	}
+	resp, err := c.doRequest(ctx, reqData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var targets []BucketTarget
+	err = json.NewDecoder(resp.Body).Decode(&targets)
+	return targets, err
+}
+
+// GetRemoteTarget returns the remote target of bucket with arn.
+func (c *RustfsAdmin) GetRemoteTarget(ctx context.Context, bucket, arn string) (BucketTarget, error) {
+	targets, err := c.ListRemoteTargets(ctx, bucket)
+	if err != nil {
+		return BucketTarget{}, err
+	}
+	for _, target := range targets {
+		if target.Arn == arn {
+			return target, nil
+		}
+	}
+	return BucketTarget{}, &APIError{
+		StatusCode: http.StatusNotFound,
+		Code:       "XMinioAdminRemoteTargetNotFoundError",
+		Message:    "The remote target does not exist",
+		Resource:   arn,
+	}
+}
+
+// RemoveRemoteTarget removes the remote target of bucket with arn.
+func (c *RustfsAdmin) RemoveRemoteTarget(ctx context.Context, bucket, arn string) error {
+	urlValues := make(url.Values)
+	urlValues.Set("bucket", bucket)
+	urlValues.Set("arn", arn)
+	reqData := RequestData{
+		Method:      "DELETE",
+		RelPath:     "remove-remote-target",
+		QueryValues: urlValues,
+	}
+	resp, err := c.doRequest(ctx, reqData)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
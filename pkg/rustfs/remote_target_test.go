@@ -0,0 +1,65 @@
+package rustfs_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+func TestRemoteTargetLifecycle(t *testing.T) {
+	ctx := context.Background()
+	source := strings.ToLower(randomString(8))
+	dut := getClient()
+	if err := dut.CreateBucket(ctx, source); err != nil {
+		t.Fatal(err)
+	}
+	defer dut.DeleteBucket(ctx, source)
+
+	target := rustfs.BucketTarget{
+		SourceBucket: source,
+		Endpoint:     "replica.example.com:9000",
+		TargetBucket: "replica",
+		Secure:       true,
+		Credentials: &rustfs.TargetCredentials{
+			AccessKey: "replicator",
+			SecretKey: "replicator-secret",
+		},
+	}
+	arn, err := dut.SetRemoteTarget(ctx, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if arn == "" {
+		t.Fatal("expected an ARN for the remote target")
+	}
+
+	read, err := dut.GetRemoteTarget(ctx, source, arn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Endpoint != target.Endpoint || read.TargetBucket != "replica" || read.Type != rustfs.ReplicationTargetType {
+		t.Errorf("unexpected remote target %+v", read)
+	}
+
+	target.Arn = arn
+	target.BandwidthLimit = 1 << 20
+	if _, err := dut.UpdateRemoteTarget(ctx, target); err != nil {
+		t.Fatal(err)
+	}
+	read, err = dut.GetRemoteTarget(ctx, source, arn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.BandwidthLimit != 1<<20 {
+		t.Errorf("expected updated bandwidth limit, got %d", read.BandwidthLimit)
+	}
+
+	if err := dut.RemoveRemoteTarget(ctx, source, arn); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dut.GetRemoteTarget(ctx, source, arn); !rustfs.IsNotFound(err) {
+		t.Errorf("expected not found after removal, got %v", err)
+	}
+}
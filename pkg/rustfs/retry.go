@@ -0,0 +1,159 @@
+package rustfs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of retries the provider configures
+	// when the user does not set max_retries.
+	DefaultMaxRetries = 5
+	// DefaultRetryMaxBackoff caps the wait between two attempts.
+	DefaultRetryMaxBackoff = 10 * time.Second
+
+	retryMinBackoff = 200 * time.Millisecond
+)
+
+// Error codes the server uses to signal that the request was rejected before
+// it was processed. These are safe to retry for every HTTP method.
+var retryableCodes = map[string]bool{
+	"SlowDown":                   true,
+	"ServiceUnavailable":         true,
+	"RequestTimeout":             true,
+	"Throttling":                 true,
+	"RequestThrottled":           true,
+	"XMinioServerNotInitialized": true,
+}
+
+// HTTP status codes which are retried for idempotent methods.
+var retryableStatusCodes = map[int]bool{
+	http.StatusRequestTimeout:      true,
+	http.StatusTooManyRequests:     true,
+	http.StatusInternalServerError: true,
+	http.StatusBadGateway:          true,
+	http.StatusServiceUnavailable:  true,
+	http.StatusGatewayTimeout:      true,
+}
+
+type retryPolicy struct {
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+func newRetryPolicy(maxRetries int, maxBackoff time.Duration) retryPolicy {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+	return retryPolicy{
+		maxRetries: maxRetries,
+		minBackoff: min(retryMinBackoff, maxBackoff),
+		maxBackoff: maxBackoff,
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func (p retryPolicy) shouldRetry(method string, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if retryableCodes[apiErr.Code] {
+			return true
+		}
+		return isIdempotent(method) && retryableStatusCodes[apiErr.StatusCode]
+	}
+	// Transport level failure (connection reset, refused, ...). We cannot
+	// know whether the server processed the request.
+	return isIdempotent(method) && isNetworkError(err)
+}
+
+// isNetworkError reports whether err was raised while talking to the server,
+// as opposed to e.g. a malformed request URL which will never succeed.
+func isNetworkError(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// backoff returns the wait before the given retry using exponential backoff
+// with equal jitter.
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	d := p.maxBackoff
+	if attempt < 32 {
+		d = min(p.minBackoff<<attempt, p.maxBackoff)
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+func (p retryPolicy) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(p.backoff(attempt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// execute sends the request built by newRequest until it succeeds, fails
+// with a non-retryable error or the retry budget is exhausted. The request
+// is rebuilt, and therefore re-signed, for every attempt.
+func (c *RustfsAdmin) execute(ctx context.Context, method string, newRequest func() (*http.Request, error), success func(status int) bool) (*http.Response, error) {
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := c.httpClient.Do(req)
+		if err == nil && success(res.StatusCode) {
+			return res, nil
+		}
+		if err == nil {
+			err = newAPIError(res)
+		}
+
+		if attempt >= c.retry.maxRetries || !c.retry.shouldRetry(method, err) {
+			return res, err
+		}
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+		if waitErr := c.retry.wait(ctx, attempt); waitErr != nil {
+			return nil, errors.Join(err, waitErr)
+		}
+	}
+}
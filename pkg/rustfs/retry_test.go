@@ -0,0 +1,230 @@
+package rustfs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(server *httptest.Server, maxRetries int) RustfsAdmin {
+	client := New(&RustfsAdminConfig{
+		Endpoint:        server.Listener.Addr().String(),
+		AccessKey:       "admin",
+		AccessSecret:    "secret",
+		MaxRetries:      maxRetries,
+		RetryMaxBackoff: 5 * time.Millisecond,
+	})
+	return client
+}
+
+func TestRetry_IdempotentServiceUnavailable(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"name":"pool-0"}]`))
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server, 3)
+	pools, err := client.ListPools(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pools) != 1 {
+		t.Errorf("expected 1 pool, got %d", len(pools))
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestRetry_BodyResignedOnEveryAttempt(t *testing.T) {
+	var calls atomic.Int32
+	var dates []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"tier_type":"s3"}` {
+			t.Errorf("attempt %d: unexpected body %q", calls.Load()+1, string(body))
+		}
+		if r.Header.Get("Authorization") == "" {
+			t.Error("expected signed request")
+		}
+		dates = append(dates, r.Header.Get("X-Amz-Date"))
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"Code":"SlowDown","Message":"Please reduce your request rate"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server, 2)
+	if err := client.AddTier(context.Background(), []byte(`{"tier_type":"s3"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+	if len(dates) != 2 || dates[1] == "" {
+		t.Errorf("expected every attempt to carry a signature date, got %v", dates)
+	}
+}
+
+func TestRetry_NonIdempotentNotRetriedOnStatus(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server, 3)
+	err := client.EditTier(context.Background(), "MYTIER", []byte(`{}`))
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected POST to be sent once, got %d", got)
+	}
+}
+
+func TestRetry_NonIdempotentRetriedOnCode(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"Code":"XMinioServerNotInitialized","Message":"Server not initialized, please try again."}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server, 3)
+	if err := client.EditTier(context.Background(), "MYTIER", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestRetry_ExhaustedReturnsLastError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server, 2)
+	_, err := client.ListPools(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", apiErr.StatusCode)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestRetry_NotFoundNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server, 3)
+	if _, err := client.GetGroup(context.Background(), "ghost"); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestRetry_ContextCanceledDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New(&RustfsAdminConfig{
+		Endpoint:        server.Listener.Addr().String(),
+		AccessKey:       "admin",
+		AccessSecret:    "secret",
+		MaxRetries:      10,
+		RetryMaxBackoff: time.Hour,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.ListPools(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("backoff did not honor context cancellation, took %s", elapsed)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := newRetryPolicy(5, time.Second)
+	for attempt := 0; attempt < 40; attempt++ {
+		d := p.backoff(attempt)
+		if d <= 0 || d > time.Second {
+			t.Fatalf("attempt %d: backoff %s out of range", attempt, d)
+		}
+	}
+	if d := p.backoff(0); d > retryMinBackoff {
+		t.Errorf("first backoff %s exceeds minimum backoff %s", d, retryMinBackoff)
+	}
+}
+
+func TestRetry_ConnectionRefused(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	endpoint := server.Listener.Addr().String()
+	server.Close()
+
+	client := New(&RustfsAdminConfig{
+		Endpoint:        endpoint,
+		AccessKey:       "admin",
+		AccessSecret:    "secret",
+		MaxRetries:      2,
+		RetryMaxBackoff: 5 * time.Millisecond,
+	})
+	policy := client.retry
+	_, err := client.ListPools(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !policy.shouldRetry(http.MethodGet, err) {
+		t.Errorf("expected connection refused to be retryable: %v", err)
+	}
+	if policy.shouldRetry(http.MethodPost, err) {
+		t.Error("expected POST not to be retried on transport errors")
+	}
+
+	client = New(&RustfsAdminConfig{AccessKey: "admin", AccessSecret: "secret", MaxRetries: 2})
+	_, err = client.ListPools(context.Background())
+	if err == nil || client.retry.shouldRetry(http.MethodGet, err) {
+		t.Errorf("expected request without host not to be retried: %v", err)
+	}
+}
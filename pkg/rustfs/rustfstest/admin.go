@@ -0,0 +1,464 @@
+package rustfstest
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type tier struct {
+	Type   string
+	Config json.RawMessage
+	Usage  TierUsage
+}
+
+// TierUsage is the usage the server reports for a tier.
+type TierUsage struct {
+	TotalSize   int64 `json:"total_size"`
+	NumVersions int64 `json:"num_versions"`
+	NumObjects  int64 `json:"num_objects"`
+}
+
+// tierSecrets are the backend settings the server redacts when listing
+// tiers.
+var tierSecrets = []string{"secret_key", "account_key", "creds"}
+
+// tierCredentials are the backend settings the tier edit endpoint accepts.
+var tierCredentials = []string{"access_key", "secret_key", "account_name", "account_key", "creds"}
+
+func (s *Server) handleAdmin(w http.ResponseWriter, r *http.Request, route string) {
+	switch {
+	case route == "is-admin" && r.Method == http.MethodGet:
+		writeJSON(w, map[string]bool{"is_admin": true})
+	case route == "list-pools" && r.Method == http.MethodGet:
+		writeJSON(w, []map[string]string{{"name": "pool-0"}})
+	case route == "rebalance/start" && r.Method == http.MethodPut:
+		writeJSON(w, map[string]string{"id": randomString(8)})
+
+	case route == "add-user" && r.Method == http.MethodPut:
+		s.handleAddUser(w, r)
+	case route == "user-info" && (r.Method == http.MethodGet || r.Method == http.MethodPut):
+		s.handleUserInfo(w, r)
+	case route == "remove-user" && r.Method == http.MethodDelete:
+		s.handleRemoveUser(w, r)
+	case route == "list-users" && r.Method == http.MethodGet:
+		s.handleListUsers(w, r)
+	case route == "set-user-or-group-policy" && r.Method == http.MethodPut:
+		s.handleSetPolicy(w, r)
+	case route == "idp/builtin/policy-entities" && r.Method == http.MethodGet:
+		s.handlePolicyEntities(w, r)
+
+	case route == "group" && r.Method == http.MethodGet:
+		s.handleGetGroup(w, r)
+	case route == "update-group-members" && r.Method == http.MethodPut:
+		s.handleUpdateGroupMembers(w, r)
+	case strings.HasPrefix(route, "group/") && r.Method == http.MethodDelete:
+		s.handleDeleteGroup(w, strings.TrimPrefix(route, "group/"))
+	case route == "set-group-status" && r.Method == http.MethodPut:
+		s.handleSetGroupStatus(w, r)
+
+	case route == "add-canned-policy" && r.Method == http.MethodPut:
+		s.handleAddPolicy(w, r)
+	case route == "info-canned-policy" && r.Method == http.MethodGet:
+		s.handlePolicyInfo(w, r)
+	case route == "remove-canned-policy" && r.Method == http.MethodDelete:
+		s.handleRemovePolicy(w, r)
+
+	case route == "add-service-accounts" && r.Method == http.MethodPut:
+		s.handleAddServiceAccount(w, r)
+	case route == "info-service-account" && r.Method == http.MethodGet:
+		s.handleServiceAccountInfo(w, r)
+	case route == "update-service-account" && r.Method == http.MethodPost:
+		s.handleUpdateServiceAccount(w, r)
+	case route == "delete-service-accounts" && r.Method == http.MethodDelete:
+		s.handleDeleteServiceAccount(w, r)
+
+	case strings.HasPrefix(route, "quota/"):
+		s.handleQuota(w, r, strings.TrimPrefix(route, "quota/"))
+
+	case route == "tier" && r.Method == http.MethodGet:
+		s.handleListTiers(w)
+	case route == "tier-stats" && r.Method == http.MethodGet:
+		s.handleTierStats(w)
+	case route == "tier" && r.Method == http.MethodPut:
+		s.handleAddTier(w, r)
+	case strings.HasPrefix(route, "tier/") && r.Method == http.MethodPost:
+		s.handleEditTier(w, r, strings.TrimPrefix(route, "tier/"))
+	case strings.HasPrefix(route, "tier/") && r.Method == http.MethodDelete:
+		s.handleRemoveTier(w, strings.TrimPrefix(route, "tier/"))
+
+	case route == "set-remote-target" && r.Method == http.MethodPut:
+		s.handleSetRemoteTarget(w, r)
+	case route == "list-remote-targets" && r.Method == http.MethodGet:
+		s.handleListRemoteTargets(w, r)
+	case route == "remove-remote-target" && r.Method == http.MethodDelete:
+		s.handleRemoveRemoteTarget(w, r)
+
+	case route == "target/arns" && r.Method == http.MethodGet:
+		writeJSON(w, slices.Sorted(maps.Keys(s.notifyTargets)))
+	case route == "get-config-kv" && r.Method == http.MethodGet:
+		s.handleGetConfigKV(w, r)
+	case route == "target/list" && r.Method == http.MethodGet:
+		s.handleListNotificationTargets(w)
+	case strings.HasPrefix(route, "target/") && r.Method == http.MethodPut:
+		s.handleSetNotificationTarget(w, r, strings.TrimPrefix(route, "target/"))
+	case strings.HasPrefix(route, "target/") && strings.HasSuffix(route, "/reset") && r.Method == http.MethodDelete:
+		s.handleRemoveNotificationTarget(w, strings.TrimSuffix(strings.TrimPrefix(route, "target/"), "/reset"))
+
+	case route == "export-iam" && r.Method == http.MethodGet:
+		s.handleExportIam(w)
+	case route == "import-iam" && r.Method == http.MethodPut:
+	case route == "export-bucket-metadata" && r.Method == http.MethodGet:
+		s.handleExportBucketMetadata(w)
+	case route == "import-bucket-metadata" && r.Method == http.MethodPut:
+
+	default:
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminAPINotFound", r.Method+" "+route+" is not implemented by rustfstest"))
+	}
+}
+
+func (s *Server) handleQuota(w http.ResponseWriter, r *http.Request, name string) {
+	b, ok := s.buckets[name]
+	if !ok {
+		writeError(w, true, newError(http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist"))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var body struct {
+			Quota int64 `json:"quota"`
+		}
+		if json.NewDecoder(r.Body).Decode(&body) != nil {
+			writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "invalid quota"))
+			return
+		}
+		b.quota = body.Quota
+	case http.MethodDelete:
+		b.quota = 0
+		return
+	default:
+		writeError(w, true, newError(http.StatusMethodNotAllowed, "MethodNotAllowed", "method not allowed"))
+		return
+	}
+	writeJSON(w, map[string]any{"bucket": name, "quota": b.quota, "quota_type": "HARD"})
+}
+
+// tierName extracts type and name from a tier configuration such as
+// {"tier_type":"s3","s3":{"name":"COLD",...}}.
+func tierName(config json.RawMessage) (string, string) {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(config, &fields) != nil {
+		return "", ""
+	}
+	var tierType string
+	_ = json.Unmarshal(fields["tier_type"], &tierType)
+	if tierType == "" {
+		_ = json.Unmarshal(fields["type"], &tierType)
+	}
+	for key, value := range fields {
+		var backend struct {
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(value, &backend) == nil && backend.Name != "" {
+			if tierType == "" {
+				tierType = key
+			}
+			return tierType, backend.Name
+		}
+	}
+	return tierType, ""
+}
+
+func (s *Server) handleAddTier(w http.ResponseWriter, r *http.Request) {
+	var config json.RawMessage
+	if json.NewDecoder(r.Body).Decode(&config) != nil {
+		writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "invalid tier configuration"))
+		return
+	}
+	tierType, name := tierName(config)
+	if name == "" {
+		writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "tier name missing"))
+		return
+	}
+	if _, ok := s.tiers[name]; ok {
+		writeError(w, true, newError(http.StatusConflict, "XMinioAdminTierAlreadyExists", "The tier already exists"))
+		return
+	}
+	s.tiers[name] = &tier{Type: tierType, Config: config}
+}
+
+func (s *Server) handleEditTier(w http.ResponseWriter, r *http.Request, name string) {
+	t, ok := s.tiers[name]
+	if !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminTierNotFound", "The tier does not exist"))
+		return
+	}
+	var config json.RawMessage
+	if json.NewDecoder(r.Body).Decode(&config) != nil {
+		writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "invalid tier configuration"))
+		return
+	}
+	// Like on the real server only credentials can be edited, they are
+	// merged into the backend.
+	var creds, fields, backend map[string]json.RawMessage
+	if json.Unmarshal(config, &creds) != nil || json.Unmarshal(t.Config, &fields) != nil || json.Unmarshal(fields[t.Type], &backend) != nil {
+		writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "invalid tier credentials"))
+		return
+	}
+	for key := range creds {
+		if !slices.Contains(tierCredentials, key) {
+			writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "only tier credentials can be edited"))
+			return
+		}
+	}
+	maps.Copy(backend, creds)
+	fields[t.Type], _ = json.Marshal(backend)
+	t.Config, _ = json.Marshal(fields)
+}
+
+func (s *Server) handleListTiers(w http.ResponseWriter) {
+	tiers := []json.RawMessage{}
+	for _, name := range slices.Sorted(maps.Keys(s.tiers)) {
+		tiers = append(tiers, redactTier(s.tiers[name]))
+	}
+	writeJSON(w, tiers)
+}
+
+// redactTier returns the configuration of t without secrets.
+func redactTier(t *tier) json.RawMessage {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(t.Config, &fields) != nil {
+		return t.Config
+	}
+	var backend map[string]any
+	if json.Unmarshal(fields[t.Type], &backend) != nil {
+		return t.Config
+	}
+	for _, key := range tierSecrets {
+		if _, ok := backend[key]; ok {
+			backend[key] = "REDACTED"
+		}
+	}
+	fields[t.Type], _ = json.Marshal(backend)
+	config, _ := json.Marshal(fields)
+	return config
+}
+
+func (s *Server) handleTierStats(w http.ResponseWriter) {
+	stats := []map[string]any{}
+	for _, name := range slices.Sorted(maps.Keys(s.tiers)) {
+		stats = append(stats, map[string]any{"name": name, "type": s.tiers[name].Type, "stats": s.tiers[name].Usage})
+	}
+	writeJSON(w, stats)
+}
+
+func (s *Server) handleRemoveTier(w http.ResponseWriter, name string) {
+	if _, ok := s.tiers[name]; !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminTierNotFound", "The tier does not exist"))
+		return
+	}
+	delete(s.tiers, name)
+}
+
+func (s *Server) handleExportIam(w http.ResponseWriter) {
+	users := map[string]any{}
+	for name, u := range s.users {
+		users[name] = map[string]string{"status": u.Status, "policyName": u.Policy}
+	}
+	writeJSON(w, map[string]any{"users": users, "policies": s.policies})
+}
+
+func (s *Server) handleExportBucketMetadata(w http.ResponseWriter) {
+	writeJSON(w, map[string]any{"buckets": sortedKeys(s.buckets)})
+}
+
+func noSuchRemoteTarget() *apiError {
+	return newError(http.StatusNotFound, "XMinioAdminRemoteTargetNotFoundError", "The remote target does not exist")
+}
+
+// handleSetRemoteTarget stores a remote target and replies with its ARN.
+// Existing targets are only replaced with update=true.
+func (s *Server) handleSetRemoteTarget(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("bucket")
+	b, ok := s.buckets[name]
+	if !ok {
+		writeError(w, true, noSuchBucket())
+		return
+	}
+	var target map[string]any
+	if json.NewDecoder(r.Body).Decode(&target) != nil {
+		writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "invalid remote target"))
+		return
+	}
+	targetBucket, _ := target["targetbucket"].(string)
+	endpoint, _ := target["endpoint"].(string)
+	if targetBucket == "" || endpoint == "" {
+		writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "endpoint and target bucket are required"))
+		return
+	}
+
+	arn, _ := target["arn"].(string)
+	if r.URL.Query().Get("update") == "true" {
+		i := b.remoteTarget(arn)
+		if i < 0 {
+			writeError(w, true, noSuchRemoteTarget())
+			return
+		}
+		b.targets[i] = target
+	} else {
+		for _, existing := range b.targets {
+			if existing["endpoint"] == endpoint && existing["targetbucket"] == targetBucket {
+				writeError(w, true, newError(http.StatusConflict, "XMinioAdminRemoteTargetAlreadyExists", "The remote target already exists"))
+				return
+			}
+		}
+		arn = "arn:rustfs:replication::" + randomString(16) + ":" + targetBucket
+		target["arn"] = arn
+		b.targets = append(b.targets, target)
+	}
+	writeJSON(w, arn)
+}
+
+// handleListRemoteTargets replies with the remote targets of a bucket. Like
+// the real server it does not return secret keys.
+func (s *Server) handleListRemoteTargets(w http.ResponseWriter, r *http.Request) {
+	b, ok := s.buckets[r.URL.Query().Get("bucket")]
+	if !ok {
+		writeError(w, true, noSuchBucket())
+		return
+	}
+	targets := []map[string]any{}
+	for _, target := range b.targets {
+		listed := maps.Clone(target)
+		if credentials, ok := target["credentials"].(map[string]any); ok {
+			listed["credentials"] = map[string]any{"accessKey": credentials["accessKey"]}
+		}
+		targets = append(targets, listed)
+	}
+	writeJSON(w, targets)
+}
+
+func (s *Server) handleRemoveRemoteTarget(w http.ResponseWriter, r *http.Request) {
+	b, ok := s.buckets[r.URL.Query().Get("bucket")]
+	if !ok {
+		writeError(w, true, noSuchBucket())
+		return
+	}
+	i := b.remoteTarget(r.URL.Query().Get("arn"))
+	if i < 0 {
+		writeError(w, true, noSuchRemoteTarget())
+		return
+	}
+	b.targets = slices.Delete(b.targets, i, i+1)
+}
+
+// notificationTargetARN returns the ARN bucket notifications use for a
+// notify_* target. Audit targets have none.
+func notificationTargetARN(targetType, name string) string {
+	service, ok := strings.CutPrefix(targetType, "notify_")
+	if !ok {
+		return ""
+	}
+	return "arn:rustfs:sqs::" + name + ":" + service
+}
+
+// handleSetNotificationTarget stores the key values of "<type>/<name>".
+func (s *Server) handleSetNotificationTarget(w http.ResponseWriter, r *http.Request, route string) {
+	targetType, name, ok := strings.Cut(route, "/")
+	if !ok || name == "" || strings.Contains(name, "/") ||
+		!(strings.HasPrefix(targetType, "notify_") || strings.HasPrefix(targetType, "audit_")) {
+		writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "invalid target type or name"))
+		return
+	}
+	var body struct {
+		KeyValues []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"key_values"`
+	}
+	if json.NewDecoder(r.Body).Decode(&body) != nil {
+		writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "invalid target configuration"))
+		return
+	}
+	config := map[string]string{}
+	for _, kv := range body.KeyValues {
+		config[kv.Key] = kv.Value
+	}
+	s.eventTargets[targetType+":"+name] = config
+	if arn := notificationTargetARN(targetType, name); arn != "" {
+		s.notifyTargets[arn] = true
+	}
+}
+
+// handleListNotificationTargets replies with the configured targets. Like
+// the real server it lists notification targets by service and does not
+// return their configuration.
+func (s *Server) handleListNotificationTargets(w http.ResponseWriter) {
+	endpoints := []map[string]string{}
+	for _, key := range slices.Sorted(maps.Keys(s.eventTargets)) {
+		targetType, name, _ := strings.Cut(key, ":")
+		status := "online"
+		if s.eventTargets[key]["enable"] == "off" {
+			status = "offline"
+		}
+		endpoints = append(endpoints, map[string]string{
+			"account_id": name,
+			"service":    strings.TrimPrefix(targetType, "notify_"),
+			"status":     status,
+		})
+	}
+	writeJSON(w, map[string]any{"notification_endpoints": endpoints})
+}
+
+// targetDefaults are the configuration keys of each target service with
+// their default values. Like the real server the fake returns all of them,
+// not only the keys a target was set with.
+var targetDefaults = map[string]map[string]string{
+	"webhook":  {"endpoint": "", "auth_token": "", "client_cert": "", "client_key": ""},
+	"kafka":    {"brokers": "", "topic": "", "sasl": "off", "sasl_username": "", "sasl_password": "", "sasl_mechanism": "plain", "tls": "off", "tls_skip_verify": "off", "version": ""},
+	"nats":     {"address": "", "subject": "", "username": "", "password": "", "token": "", "tls": "off", "tls_skip_verify": "off"},
+	"mqtt":     {"broker": "", "topic": "", "qos": "0", "username": "", "password": "", "keep_alive_interval": "0s", "reconnect_interval": "0s"},
+	"redis":    {"address": "", "key": "", "format": "namespace", "user": "", "password": ""},
+	"postgres": {"connection_string": "", "table": "", "format": "namespace"},
+}
+
+// handleGetConfigKV replies with the config line of a target including the
+// defaults of unset keys, e.g.
+// notify_webhook:primary enable=on endpoint="https://hooks.example.com" ...
+func (s *Server) handleGetConfigKV(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("key")
+	stored, ok := s.eventTargets[key]
+	if !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XRustFSAdminTargetNotFound", "The notification target does not exist"))
+		return
+	}
+	targetType, _, _ := strings.Cut(key, ":")
+	_, service, _ := strings.Cut(targetType, "_")
+	config := map[string]string{"enable": "off", "queue_dir": "", "queue_limit": "0"}
+	maps.Copy(config, targetDefaults[service])
+	maps.Copy(config, stored)
+	line := []string{key}
+	for _, k := range slices.Sorted(maps.Keys(config)) {
+		value := config[k]
+		if value == "" || strings.ContainsAny(value, " \"") {
+			value = strconv.Quote(value)
+		}
+		line = append(line, k+"="+value)
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(strings.Join(line, " ") + "\n"))
+}
+
+func (s *Server) handleRemoveNotificationTarget(w http.ResponseWriter, route string) {
+	targetType, name, _ := strings.Cut(route, "/")
+	if _, ok := s.eventTargets[targetType+":"+name]; !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XRustFSAdminTargetNotFound", "The notification target does not exist"))
+		return
+	}
+	delete(s.eventTargets, targetType+":"+name)
+	delete(s.notifyTargets, notificationTargetARN(targetType, name))
+}
@@ -0,0 +1,495 @@
+package rustfstest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+var builtinPolicies = map[string]string{
+	"readwrite":    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:*"],"Resource":["arn:aws:s3:::*"]}]}`,
+	"readonly":     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetBucketLocation","s3:GetObject"],"Resource":["arn:aws:s3:::*"]}]}`,
+	"writeonly":    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:PutObject"],"Resource":["arn:aws:s3:::*"]}]}`,
+	"diagnostics":  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["admin:ServerInfo"],"Resource":["arn:aws:s3:::*"]}]}`,
+	"consoleAdmin": `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["admin:*","s3:*"],"Resource":["arn:aws:s3:::*"]}]}`,
+}
+
+type user struct {
+	SecretKey string
+	Status    string
+	Policy    string
+}
+
+type group struct {
+	Status  string
+	Members []string
+	Policy  string
+}
+
+type serviceAccount struct {
+	ParentUser    string `json:"parentUser"`
+	SecretKey     string `json:"-"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Expiration    string `json:"expiration,omitempty"`
+	Status        string `json:"accountStatus"`
+	ImpliedPolicy bool   `json:"impliedPolicy"`
+	Policy        string `json:"policy,omitempty"`
+}
+
+type session struct {
+	secretKey  string
+	token      string
+	expiration time.Time
+}
+
+func (s *Server) handleAddUser(w http.ResponseWriter, r *http.Request) {
+	accessKey := r.URL.Query().Get("accessKey")
+	var body struct {
+		SecretKey string `json:"secretKey"`
+		Status    string `json:"status"`
+	}
+	if accessKey == "" || json.NewDecoder(r.Body).Decode(&body) != nil {
+		writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "invalid user"))
+		return
+	}
+	if body.Status == "" {
+		body.Status = "enabled"
+	}
+	u, ok := s.users[accessKey]
+	if !ok {
+		u = &user{}
+		s.users[accessKey] = u
+	}
+	u.SecretKey = body.SecretKey
+	u.Status = body.Status
+}
+
+func (s *Server) handleUserInfo(w http.ResponseWriter, r *http.Request) {
+	accessKey := r.URL.Query().Get("accessKey")
+	u, ok := s.users[accessKey]
+	if !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchUser", "The specified user does not exist"))
+		return
+	}
+	if r.Method == http.MethodPut {
+		u.Status = r.URL.Query().Get("status")
+		return
+	}
+	writeJSON(w, map[string]any{
+		"status":     u.Status,
+		"policyName": u.Policy,
+		"memberOf":   s.groupsOf(accessKey),
+	})
+}
+
+func (s *Server) handleRemoveUser(w http.ResponseWriter, r *http.Request) {
+	accessKey := r.URL.Query().Get("accessKey")
+	if _, ok := s.users[accessKey]; !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchUser", "The specified user does not exist"))
+		return
+	}
+	delete(s.users, accessKey)
+	for _, g := range s.groups {
+		g.Members = slices.DeleteFunc(g.Members, func(m string) bool { return m == accessKey })
+	}
+	for key, sa := range s.serviceAccounts {
+		if sa.ParentUser == accessKey {
+			delete(s.serviceAccounts, key)
+		}
+	}
+}
+
+func (s *Server) handleListUsers(w http.ResponseWriter, r *http.Request) {
+	bucket := r.URL.Query().Get("bucket")
+	type userInfo struct {
+		AccessKey string `json:"accessKey"`
+		Status    string `json:"status"`
+		Policy    string `json:"policyName"`
+	}
+	users := []userInfo{}
+	for _, accessKey := range sortedKeys(s.users) {
+		u := s.users[accessKey]
+		if bucket != "" && !s.policyGrantsBucket(u.Policy, bucket) {
+			continue
+		}
+		users = append(users, userInfo{AccessKey: accessKey, Status: u.Status, Policy: u.Policy})
+	}
+	writeJSON(w, users)
+}
+
+// policyGrantsBucket reports whether one of the comma separated policies
+// names the bucket or all buckets in a resource.
+func (s *Server) policyGrantsBucket(policies, bucket string) bool {
+	for _, name := range strings.Split(policies, ",") {
+		doc := string(s.policies[strings.TrimSpace(name)])
+		if strings.Contains(doc, "arn:aws:s3:::"+bucket) || strings.Contains(doc, "arn:aws:s3:::*") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) handleSetPolicy(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	entity, policy := query.Get("userOrGroup"), query.Get("policyName")
+	for _, name := range strings.Split(policy, ",") {
+		if _, ok := s.policies[strings.TrimSpace(name)]; !ok && name != "" {
+			writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchPolicy", "The canned policy does not exist"))
+			return
+		}
+	}
+	if query.Get("isGroup") == "true" {
+		g, ok := s.groups[entity]
+		if !ok {
+			writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchGroup", "The specified group does not exist"))
+			return
+		}
+		g.Policy = policy
+		return
+	}
+	u, ok := s.users[entity]
+	if !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchUser", "The specified user does not exist"))
+		return
+	}
+	u.Policy = policy
+}
+
+func (s *Server) handlePolicyEntities(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	type userMapping struct {
+		User     string   `json:"user"`
+		Policies []string `json:"policies"`
+	}
+	type groupMapping struct {
+		Group    string   `json:"group"`
+		Policies []string `json:"policies"`
+	}
+	type policyMapping struct {
+		Policy string   `json:"policy"`
+		Users  []string `json:"users"`
+		Groups []string `json:"groups"`
+	}
+	users, groups, policies := []userMapping{}, []groupMapping{}, []policyMapping{}
+	for _, name := range query["user"] {
+		u, ok := s.users[name]
+		if !ok {
+			writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchUser", "The specified user does not exist"))
+			return
+		}
+		users = append(users, userMapping{User: name, Policies: splitPolicies(u.Policy)})
+	}
+	for _, name := range query["group"] {
+		g, ok := s.groups[name]
+		if !ok {
+			writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchGroup", "The specified group does not exist"))
+			return
+		}
+		groups = append(groups, groupMapping{Group: name, Policies: splitPolicies(g.Policy)})
+	}
+	for _, name := range query["policy"] {
+		mapping := policyMapping{Policy: name, Users: []string{}, Groups: []string{}}
+		for _, accessKey := range sortedKeys(s.users) {
+			if slices.Contains(splitPolicies(s.users[accessKey].Policy), name) {
+				mapping.Users = append(mapping.Users, accessKey)
+			}
+		}
+		for _, group := range sortedKeys(s.groups) {
+			if slices.Contains(splitPolicies(s.groups[group].Policy), name) {
+				mapping.Groups = append(mapping.Groups, group)
+			}
+		}
+		policies = append(policies, mapping)
+	}
+	writeJSON(w, map[string]any{
+		"userMappings":   users,
+		"groupMappings":  groups,
+		"policyMappings": policies,
+	})
+}
+
+// splitPolicies splits a comma separated policy list.
+func splitPolicies(policies string) []string {
+	names := []string{}
+	for _, name := range strings.Split(policies, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (s *Server) groupsOf(accessKey string) []string {
+	groups := []string{}
+	for _, name := range sortedKeys(s.groups) {
+		if slices.Contains(s.groups[name].Members, accessKey) {
+			groups = append(groups, name)
+		}
+	}
+	return groups
+}
+
+func (s *Server) handleGetGroup(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("group")
+	g, ok := s.groups[name]
+	if !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchGroup", "The specified group does not exist"))
+		return
+	}
+	writeJSON(w, map[string]any{
+		"name":    name,
+		"status":  g.Status,
+		"members": g.Members,
+		"policy":  g.Policy,
+	})
+}
+
+func (s *Server) handleUpdateGroupMembers(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Group    string   `json:"group"`
+		Members  []string `json:"members"`
+		IsRemove bool     `json:"is_remove"`
+		Status   string   `json:"status"`
+	}
+	if json.NewDecoder(r.Body).Decode(&body) != nil || body.Group == "" {
+		writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "invalid group update"))
+		return
+	}
+	for _, member := range body.Members {
+		if _, ok := s.users[member]; !ok {
+			writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchUser", "The specified user does not exist"))
+			return
+		}
+	}
+
+	g, ok := s.groups[body.Group]
+	if body.IsRemove {
+		if !ok {
+			writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchGroup", "The specified group does not exist"))
+			return
+		}
+		g.Members = slices.DeleteFunc(g.Members, func(m string) bool { return slices.Contains(body.Members, m) })
+		return
+	}
+	if !ok {
+		g = &group{Status: "enabled", Members: []string{}}
+		s.groups[body.Group] = g
+	}
+	if body.Status != "" {
+		g.Status = body.Status
+	}
+	for _, member := range body.Members {
+		if !slices.Contains(g.Members, member) {
+			g.Members = append(g.Members, member)
+		}
+	}
+}
+
+func (s *Server) handleDeleteGroup(w http.ResponseWriter, name string) {
+	if _, ok := s.groups[name]; !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchGroup", "The specified group does not exist"))
+		return
+	}
+	delete(s.groups, name)
+}
+
+func (s *Server) handleSetGroupStatus(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	g, ok := s.groups[query.Get("group")]
+	if !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchGroup", "The specified group does not exist"))
+		return
+	}
+	g.Status = query.Get("status")
+}
+
+func (s *Server) handleAddPolicy(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	var doc json.RawMessage
+	if name == "" || json.NewDecoder(r.Body).Decode(&doc) != nil {
+		writeError(w, true, newError(http.StatusBadRequest, "XMinioMalformedIAMPolicy", "policy is invalid"))
+		return
+	}
+	s.policies[name] = doc
+}
+
+func (s *Server) handlePolicyInfo(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	doc, ok := s.policies[name]
+	if !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchPolicy", "The canned policy does not exist"))
+		return
+	}
+	writeJSON(w, map[string]any{"policy_name": name, "policy": doc})
+}
+
+func (s *Server) handleRemovePolicy(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if _, ok := s.policies[name]; !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchPolicy", "The canned policy does not exist"))
+		return
+	}
+	delete(s.policies, name)
+}
+
+func (s *Server) handleAddServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		AccessKey     string `json:"accessKey"`
+		SecretKey     string `json:"secretKey"`
+		Name          string `json:"name"`
+		Description   string `json:"description"`
+		Expiration    string `json:"expiration"`
+		ImpliedPolicy bool   `json:"impliedPolicy"`
+		Policy        string `json:"policy"`
+		TargetUser    string `json:"targetUser"`
+	}
+	if json.NewDecoder(r.Body).Decode(&body) != nil {
+		writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "invalid service account"))
+		return
+	}
+	if _, ok := s.serviceAccounts[body.AccessKey]; ok {
+		writeError(w, true, newError(http.StatusConflict, "XMinioAdminServiceAccountExists", "The service account already exists"))
+		return
+	}
+	parent := body.TargetUser
+	if parent == "" {
+		parent = AccessKey
+	} else if _, ok := s.users[parent]; !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminNoSuchUser", "The specified user does not exist"))
+		return
+	}
+	if body.AccessKey == "" {
+		body.AccessKey = randomString(10)
+	}
+	if body.SecretKey == "" {
+		body.SecretKey = randomString(20)
+	}
+	s.serviceAccounts[body.AccessKey] = &serviceAccount{
+		ParentUser:    parent,
+		SecretKey:     body.SecretKey,
+		Name:          body.Name,
+		Description:   body.Description,
+		Expiration:    body.Expiration,
+		Status:        "on",
+		ImpliedPolicy: body.ImpliedPolicy,
+		Policy:        body.Policy,
+	}
+	writeJSON(w, map[string]any{"credentials": map[string]string{
+		"accessKey":  body.AccessKey,
+		"secretKey":  body.SecretKey,
+		"expiration": body.Expiration,
+	}})
+}
+
+func (s *Server) handleServiceAccountInfo(w http.ResponseWriter, r *http.Request) {
+	sa, ok := s.serviceAccounts[r.URL.Query().Get("accessKey")]
+	if !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminServiceAccountNotFound", "The specified service account is not found"))
+		return
+	}
+	writeJSON(w, sa)
+}
+
+func (s *Server) handleUpdateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	sa, ok := s.serviceAccounts[r.URL.Query().Get("accessKey")]
+	if !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminServiceAccountNotFound", "The specified service account is not found"))
+		return
+	}
+	var body struct {
+		NewSecretKey   string  `json:"newSecretKey"`
+		NewName        *string `json:"newName"`
+		NewDescription *string `json:"newDescription"`
+		NewExpiration  string  `json:"newExpiration"`
+		NewPolicy      string  `json:"newPolicy"`
+		NewStatus      string  `json:"newStatus"`
+	}
+	if json.NewDecoder(r.Body).Decode(&body) != nil {
+		writeError(w, true, newError(http.StatusBadRequest, "XMinioAdminInvalidArgument", "invalid service account update"))
+		return
+	}
+	if body.NewSecretKey != "" {
+		sa.SecretKey = body.NewSecretKey
+	}
+	if body.NewName != nil {
+		sa.Name = *body.NewName
+	}
+	if body.NewDescription != nil {
+		sa.Description = *body.NewDescription
+	}
+	if body.NewExpiration != "" {
+		sa.Expiration = body.NewExpiration
+	}
+	if body.NewPolicy != "" {
+		sa.Policy = body.NewPolicy
+		sa.ImpliedPolicy = false
+	}
+	if body.NewStatus != "" {
+		sa.Status = body.NewStatus
+	}
+}
+
+func (s *Server) handleDeleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	accessKey := r.URL.Query().Get("accessKey")
+	if _, ok := s.serviceAccounts[accessKey]; !ok {
+		writeError(w, true, newError(http.StatusNotFound, "XMinioAdminServiceAccountNotFound", "The specified service account is not found"))
+		return
+	}
+	delete(s.serviceAccounts, accessKey)
+}
+
+// handleSTS implements the AssumeRole action. The temporary credentials are
+// valid for DurationSeconds, one hour by default.
+func (s *Server) handleSTS(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("Action") != "AssumeRole" {
+		writeError(w, false, newError(http.StatusBadRequest, "InvalidAction", "only AssumeRole is supported"))
+		return
+	}
+	duration := time.Hour
+	if d, err := time.ParseDuration(r.PostForm.Get("DurationSeconds") + "s"); err == nil && d > 0 {
+		duration = d
+	}
+
+	accessKey, secretKey, token := randomString(20), randomString(40), randomString(64)
+	expiration := time.Now().Add(duration).UTC()
+	s.sessions[accessKey] = session{secretKey: secretKey, token: token, expiration: expiration}
+
+	type credentials struct {
+		AccessKey    string    `xml:"AccessKeyId"`
+		SecretKey    string    `xml:"SecretAccessKey"`
+		SessionToken string    `xml:"SessionToken"`
+		Expiration   time.Time `xml:"Expiration"`
+	}
+	type assumeRoleResponse struct {
+		XMLName     xml.Name    `xml:"https://sts.amazonaws.com/doc/2011-06-15/ AssumeRoleResponse"`
+		Credentials credentials `xml:"AssumeRoleResult>Credentials"`
+	}
+	writeXML(w, assumeRoleResponse{Credentials: credentials{
+		AccessKey:    accessKey,
+		SecretKey:    secretKey,
+		SessionToken: token,
+		Expiration:   expiration,
+	}})
+}
+
+func randomString(n int) string {
+	b := make([]byte, (n+1)/2)
+	_, _ = rand.Read(b)
+	return strings.ToUpper(hex.EncodeToString(b))[:n]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
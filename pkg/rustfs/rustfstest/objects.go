@@ -0,0 +1,387 @@
+package rustfstest
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const nullVersion = "null"
+
+// objectVersion is one version of an object, oldest first in bucket.objects.
+type objectVersion struct {
+	versionID    string
+	deleteMarker bool
+	data         []byte
+	etag         string
+	modified     time.Time
+	// Object lock state set through ?retention and ?legal-hold.
+	retentionMode string
+	retainUntil   time.Time
+	legalHold     bool
+}
+
+// locked reports whether v may not be deleted or have its retention
+// shortened. Governance retention can be bypassed.
+func (v *objectVersion) locked(bypassGovernance bool) bool {
+	if v.legalHold {
+		return true
+	}
+	if v.retentionMode == "" || !v.retainUntil.After(time.Now()) {
+		return false
+	}
+	return v.retentionMode == "COMPLIANCE" || !bypassGovernance
+}
+
+func (b *bucket) versioningEnabled() bool {
+	return bytes.Contains(b.config["versioning"], []byte("<Status>Enabled</Status>"))
+}
+
+// latest returns the current version of key, which may be a delete marker.
+func (b *bucket) latest(key string) *objectVersion {
+	versions := b.objects[key]
+	if len(versions) == 0 {
+		return nil
+	}
+	return versions[len(versions)-1]
+}
+
+func (b *bucket) version(key, versionID string) *objectVersion {
+	if versionID == "" {
+		return b.latest(key)
+	}
+	for _, v := range b.objects[key] {
+		if v.versionID == versionID {
+			return v
+		}
+	}
+	return nil
+}
+
+// put stores a new version of key. Unversioned buckets keep a single null
+// version per key.
+func (b *bucket) put(key string, v *objectVersion) {
+	if !b.versioningEnabled() {
+		v.versionID = nullVersion
+		b.removeVersion(key, nullVersion)
+	}
+	b.objects[key] = append(b.objects[key], v)
+}
+
+// remove deletes key like S3 DeleteObject. Without a version id versioned
+// buckets get a delete marker instead.
+func (b *bucket) remove(key, versionID string) *objectVersion {
+	if versionID != "" {
+		b.removeVersion(key, versionID)
+		return nil
+	}
+	if !b.versioningEnabled() {
+		delete(b.objects, key)
+		return nil
+	}
+	marker := &objectVersion{versionID: randomString(32), deleteMarker: true, modified: time.Now().UTC()}
+	b.objects[key] = append(b.objects[key], marker)
+	return marker
+}
+
+func (b *bucket) removeVersion(key, versionID string) {
+	versions := b.objects[key][:0]
+	for _, v := range b.objects[key] {
+		if v.versionID != versionID {
+			versions = append(versions, v)
+		}
+	}
+	if len(versions) == 0 {
+		delete(b.objects, key)
+		return
+	}
+	b.objects[key] = versions
+}
+
+func noSuchKey() *apiError {
+	return newError(http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+}
+
+func (s *Server) handleObject(w http.ResponseWriter, r *http.Request, name, key string) {
+	b, ok := s.buckets[name]
+	if !ok {
+		writeError(w, false, noSuchBucket())
+		return
+	}
+	versionID := r.URL.Query().Get("versionId")
+	if r.URL.Query().Has("retention") || r.URL.Query().Has("legal-hold") {
+		s.handleObjectLock(w, r, b, key, versionID)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		sum := md5.Sum(data)
+		v := &objectVersion{
+			versionID: randomString(32),
+			data:      data,
+			etag:      hex.EncodeToString(sum[:]),
+			modified:  time.Now().UTC(),
+		}
+		b.put(key, v)
+		w.Header().Set("ETag", `"`+v.etag+`"`)
+		if v.versionID != nullVersion {
+			w.Header().Set("X-Amz-Version-Id", v.versionID)
+		}
+	case http.MethodGet, http.MethodHead:
+		v := b.version(key, versionID)
+		if v == nil || v.deleteMarker {
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writeError(w, false, noSuchKey())
+			return
+		}
+		w.Header().Set("ETag", `"`+v.etag+`"`)
+		w.Header().Set("Last-Modified", v.modified.Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(v.data)))
+		if v.versionID != nullVersion {
+			w.Header().Set("X-Amz-Version-Id", v.versionID)
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(v.data)
+		}
+	case http.MethodDelete:
+		bypass := strings.EqualFold(r.Header.Get("X-Amz-Bypass-Governance-Retention"), "true")
+		if v := b.version(key, versionID); versionID != "" && v != nil && v.locked(bypass) {
+			writeError(w, false, newError(http.StatusForbidden, "AccessDenied", "Object is WORM protected and cannot be overwritten"))
+			return
+		}
+		if marker := b.remove(key, versionID); marker != nil {
+			w.Header().Set("X-Amz-Delete-Marker", "true")
+			w.Header().Set("X-Amz-Version-Id", marker.versionID)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, false, newError(http.StatusNotImplemented, "NotImplemented", r.Method+" on an object is not implemented by rustfstest"))
+	}
+}
+
+// handleObjectLock serves the retention and legal hold of an object
+// version. Like S3 it refuses to shorten or remove an active retention
+// unless governance retention is bypassed.
+func (s *Server) handleObjectLock(w http.ResponseWriter, r *http.Request, b *bucket, key, versionID string) {
+	if !strings.Contains(string(b.config["object-lock"]), "Enabled") {
+		writeError(w, false, newError(http.StatusBadRequest, "InvalidRequest", "Bucket is missing ObjectLockConfiguration"))
+		return
+	}
+	v := b.version(key, versionID)
+	if v == nil || v.deleteMarker {
+		if versionID != "" {
+			writeError(w, false, newError(http.StatusNotFound, "NoSuchVersion", "The specified version does not exist."))
+			return
+		}
+		writeError(w, false, noSuchKey())
+		return
+	}
+	type retention struct {
+		XMLName         xml.Name   `xml:"Retention"`
+		Mode            string     `xml:"Mode,omitempty"`
+		RetainUntilDate *time.Time `xml:"RetainUntilDate,omitempty"`
+	}
+	type legalHold struct {
+		XMLName xml.Name `xml:"LegalHold"`
+		Status  string   `xml:"Status"`
+	}
+	noConfig := newError(http.StatusNotFound, "NoSuchObjectLockConfiguration", "The specified object does not have a ObjectLock configuration")
+
+	switch {
+	case r.URL.Query().Has("retention") && r.Method == http.MethodGet:
+		if v.retentionMode == "" {
+			writeError(w, false, noConfig)
+			return
+		}
+		writeXML(w, retention{Mode: v.retentionMode, RetainUntilDate: &v.retainUntil})
+	case r.URL.Query().Has("retention") && r.Method == http.MethodPut:
+		var config retention
+		if err := xml.NewDecoder(r.Body).Decode(&config); err != nil {
+			writeError(w, false, newError(http.StatusBadRequest, "MalformedXML", err.Error()))
+			return
+		}
+		var until time.Time
+		if config.RetainUntilDate != nil {
+			until = *config.RetainUntilDate
+		}
+		shortened := config.Mode != v.retentionMode || until.Before(v.retainUntil)
+		bypass := strings.EqualFold(r.Header.Get("X-Amz-Bypass-Governance-Retention"), "true")
+		if shortened && v.retentionMode != "" && v.retainUntil.After(time.Now()) && (v.retentionMode == "COMPLIANCE" || !bypass) {
+			writeError(w, false, newError(http.StatusForbidden, "AccessDenied", "Access Denied"))
+			return
+		}
+		v.retentionMode, v.retainUntil = config.Mode, until
+	case r.URL.Query().Has("legal-hold") && r.Method == http.MethodGet:
+		status := "OFF"
+		if v.legalHold {
+			status = "ON"
+		}
+		writeXML(w, legalHold{Status: status})
+	case r.URL.Query().Has("legal-hold") && r.Method == http.MethodPut:
+		var config legalHold
+		if err := xml.NewDecoder(r.Body).Decode(&config); err != nil {
+			writeError(w, false, newError(http.StatusBadRequest, "MalformedXML", err.Error()))
+			return
+		}
+		v.legalHold = config.Status == "ON"
+	default:
+		writeError(w, false, newError(http.StatusMethodNotAllowed, "MethodNotAllowed", "method not allowed"))
+	}
+}
+
+func (s *Server) handleListObjects(w http.ResponseWriter, r *http.Request, b *bucket, name string) {
+	type content struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+		ETag         string    `xml:"ETag"`
+		Size         int       `xml:"Size"`
+		StorageClass string    `xml:"StorageClass"`
+	}
+	type listBucketResult struct {
+		XMLName     xml.Name  `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+		Name        string    `xml:"Name"`
+		Prefix      string    `xml:"Prefix"`
+		KeyCount    int       `xml:"KeyCount"`
+		MaxKeys     int       `xml:"MaxKeys"`
+		IsTruncated bool      `xml:"IsTruncated"`
+		Contents    []content `xml:"Contents"`
+	}
+	prefix := r.URL.Query().Get("prefix")
+	result := listBucketResult{Name: name, Prefix: prefix, MaxKeys: 1000}
+	for _, key := range sortedKeys(b.objects) {
+		v := b.latest(key)
+		if !strings.HasPrefix(key, prefix) || v.deleteMarker {
+			continue
+		}
+		result.Contents = append(result.Contents, content{
+			Key:          key,
+			LastModified: v.modified,
+			ETag:         `"` + v.etag + `"`,
+			Size:         len(v.data),
+			StorageClass: "STANDARD",
+		})
+	}
+	result.KeyCount = len(result.Contents)
+	writeXML(w, result)
+}
+
+func (s *Server) handleListObjectVersions(w http.ResponseWriter, r *http.Request, b *bucket, name string) {
+	// XMLName is either Version or DeleteMarker.
+	type entry struct {
+		XMLName      xml.Name
+		Key          string    `xml:"Key"`
+		VersionID    string    `xml:"VersionId"`
+		IsLatest     bool      `xml:"IsLatest"`
+		LastModified time.Time `xml:"LastModified"`
+		ETag         string    `xml:"ETag,omitempty"`
+		Size         *int      `xml:"Size,omitempty"`
+		StorageClass string    `xml:"StorageClass,omitempty"`
+	}
+	type listVersionsResult struct {
+		XMLName     xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListVersionsResult"`
+		Name        string   `xml:"Name"`
+		Prefix      string   `xml:"Prefix"`
+		MaxKeys     int      `xml:"MaxKeys"`
+		IsTruncated bool     `xml:"IsTruncated"`
+		Entries     []entry
+	}
+	prefix := r.URL.Query().Get("prefix")
+	result := listVersionsResult{Name: name, Prefix: prefix, MaxKeys: 1000}
+	for _, key := range sortedKeys(b.objects) {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		versions := b.objects[key]
+		// S3 lists the versions of a key newest first.
+		for i := len(versions) - 1; i >= 0; i-- {
+			v := versions[i]
+			e := entry{
+				XMLName:      xml.Name{Local: "Version"},
+				Key:          key,
+				VersionID:    v.versionID,
+				IsLatest:     i == len(versions)-1,
+				LastModified: v.modified,
+			}
+			if v.deleteMarker {
+				e.XMLName.Local = "DeleteMarker"
+			} else {
+				size := len(v.data)
+				e.ETag, e.Size, e.StorageClass = `"`+v.etag+`"`, &size, "STANDARD"
+			}
+			result.Entries = append(result.Entries, e)
+		}
+	}
+	writeXML(w, result)
+}
+
+func (s *Server) handleDeleteObjects(w http.ResponseWriter, r *http.Request, b *bucket) {
+	var request struct {
+		Quiet   bool `xml:"Quiet"`
+		Objects []struct {
+			Key       string `xml:"Key"`
+			VersionID string `xml:"VersionId"`
+		} `xml:"Object"`
+	}
+	body, _ := io.ReadAll(r.Body)
+	if err := xml.Unmarshal(body, &request); err != nil {
+		writeError(w, false, newError(http.StatusBadRequest, "MalformedXML", err.Error()))
+		return
+	}
+
+	type deleted struct {
+		Key                   string `xml:"Key"`
+		VersionID             string `xml:"VersionId,omitempty"`
+		DeleteMarker          bool   `xml:"DeleteMarker,omitempty"`
+		DeleteMarkerVersionID string `xml:"DeleteMarkerVersionId,omitempty"`
+	}
+	type deleteResult struct {
+		XMLName xml.Name  `xml:"http://s3.amazonaws.com/doc/2006-03-01/ DeleteResult"`
+		Deleted []deleted `xml:"Deleted"`
+	}
+	var result deleteResult
+	for _, object := range request.Objects {
+		marker := b.remove(object.Key, object.VersionID)
+		if request.Quiet {
+			continue
+		}
+		d := deleted{Key: object.Key, VersionID: object.VersionID}
+		if marker != nil {
+			d.DeleteMarker, d.DeleteMarkerVersionID = true, marker.versionID
+		}
+		result.Deleted = append(result.Deleted, d)
+	}
+	writeXML(w, result)
+}
+
+// decodeChunked strips the aws-chunked framing of streaming uploads. Chunk
+// signatures are not verified.
+func decodeChunked(body []byte) ([]byte, bool) {
+	var data []byte
+	for {
+		header, rest, ok := bytes.Cut(body, []byte("\r\n"))
+		if !ok {
+			return nil, false
+		}
+		sizeHex, _, _ := bytes.Cut(header, []byte(";"))
+		size, err := strconv.ParseInt(string(sizeHex), 16, 64)
+		if err != nil || int64(len(rest)) < size+2 {
+			return nil, false
+		}
+		if size == 0 {
+			return data, true
+		}
+		data = append(data, rest[:size]...)
+		body = rest[size+2:]
+	}
+}
@@ -0,0 +1,216 @@
+package rustfstest
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+type bucket struct {
+	region  string
+	created time.Time
+	quota   int64
+	// config holds the raw XML document of every bucket subresource.
+	config  map[string][]byte
+	objects map[string][]*objectVersion
+	// targets are the remote targets registered through the admin API.
+	targets []map[string]any
+}
+
+// remoteTarget returns the index of the remote target with arn or -1.
+func (b *bucket) remoteTarget(arn string) int {
+	return slices.IndexFunc(b.targets, func(target map[string]any) bool {
+		return target["arn"] == arn
+	})
+}
+
+var mfaDeletePattern = regexp.MustCompile(`<MfaDelete>[^<]*</MfaDelete>`)
+
+// Bucket subresources the fake stores verbatim. Subresources mapped to an
+// error code reply with that code when unset, the others with an empty
+// document.
+var bucketSubresources = map[string]struct {
+	emptyDocument string
+	notFoundCode  string
+}{
+	"versioning":   {emptyDocument: `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></VersioningConfiguration>`},
+	"notification": {emptyDocument: `<NotificationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></NotificationConfiguration>`},
+	"encryption":   {notFoundCode: "ServerSideEncryptionConfigurationNotFoundError"},
+	"object-lock":  {notFoundCode: "ObjectLockConfigurationNotFoundError"},
+	"replication":  {notFoundCode: "ReplicationConfigurationNotFoundError"},
+	"lifecycle":    {notFoundCode: "NoSuchLifecycleConfiguration"},
+	"policy":       {notFoundCode: "NoSuchBucketPolicy"},
+	"tagging":      {notFoundCode: "NoSuchTagSet"},
+	"cors":         {notFoundCode: "NoSuchCORSConfiguration"},
+}
+
+func (s *Server) handleS3(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	if path == "" {
+		s.handleListBuckets(w)
+		return
+	}
+	name, object, _ := strings.Cut(path, "/")
+	if object != "" {
+		s.handleObject(w, r, name, object)
+		return
+	}
+
+	query := r.URL.Query()
+	if query.Has("location") && r.Method == http.MethodGet {
+		s.handleBucketLocation(w, name)
+		return
+	}
+	b, exists := s.buckets[name]
+	switch {
+	case !exists && r.Method != http.MethodPut:
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeError(w, false, noSuchBucket())
+		return
+	case query.Has("versions") && r.Method == http.MethodGet:
+		s.handleListObjectVersions(w, r, b, name)
+		return
+	case query.Has("delete") && r.Method == http.MethodPost:
+		s.handleDeleteObjects(w, r, b)
+		return
+	}
+	for subresource := range bucketSubresources {
+		if query.Has(subresource) {
+			s.handleBucketSubresource(w, r, name, subresource)
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		s.handleMakeBucket(w, r, name)
+	case http.MethodGet:
+		s.handleListObjects(w, r, b, name)
+	case http.MethodHead:
+	case http.MethodDelete:
+		if len(b.objects) > 0 {
+			writeError(w, false, newError(http.StatusConflict, "BucketNotEmpty", "The bucket you tried to delete is not empty"))
+			return
+		}
+		delete(s.buckets, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, false, newError(http.StatusNotImplemented, "NotImplemented", r.Method+" on a bucket is not implemented by rustfstest"))
+	}
+}
+
+func noSuchBucket() *apiError {
+	return newError(http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+}
+
+func (s *Server) handleMakeBucket(w http.ResponseWriter, r *http.Request, name string) {
+	if _, ok := s.buckets[name]; ok {
+		writeError(w, false, newError(http.StatusConflict, "BucketAlreadyOwnedByYou", "Your previous request to create the named bucket succeeded and you already own it."))
+		return
+	}
+	var location struct {
+		LocationConstraint string `xml:"LocationConstraint"`
+	}
+	if body, _ := io.ReadAll(r.Body); len(body) > 0 {
+		if err := xml.Unmarshal(body, &location); err != nil {
+			writeError(w, false, newError(http.StatusBadRequest, "MalformedXML", err.Error()))
+			return
+		}
+	}
+	b := &bucket{
+		region:  location.LocationConstraint,
+		created: time.Now().UTC(),
+		config:  map[string][]byte{},
+		objects: map[string][]*objectVersion{},
+	}
+	if strings.EqualFold(r.Header.Get("X-Amz-Bucket-Object-Lock-Enabled"), "true") {
+		b.config["object-lock"] = []byte(`<ObjectLockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`)
+		b.config["versioning"] = []byte(`<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status></VersioningConfiguration>`)
+	}
+	s.buckets[name] = b
+}
+
+func (s *Server) handleListBuckets(w http.ResponseWriter) {
+	type bucketEntry struct {
+		Name         string    `xml:"Name"`
+		CreationDate time.Time `xml:"CreationDate"`
+	}
+	type listAllMyBucketsResult struct {
+		XMLName xml.Name      `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListAllMyBucketsResult"`
+		Owner   string        `xml:"Owner>ID"`
+		Buckets []bucketEntry `xml:"Buckets>Bucket"`
+	}
+	result := listAllMyBucketsResult{Owner: AccessKey}
+	for _, name := range sortedKeys(s.buckets) {
+		result.Buckets = append(result.Buckets, bucketEntry{Name: name, CreationDate: s.buckets[name].created})
+	}
+	writeXML(w, result)
+}
+
+func (s *Server) handleBucketLocation(w http.ResponseWriter, name string) {
+	b, ok := s.buckets[name]
+	if !ok {
+		writeError(w, false, noSuchBucket())
+		return
+	}
+	type locationConstraint struct {
+		XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LocationConstraint"`
+		Location string   `xml:",chardata"`
+	}
+	writeXML(w, locationConstraint{Location: b.region})
+}
+
+func (s *Server) handleBucketSubresource(w http.ResponseWriter, r *http.Request, name, subresource string) {
+	b, ok := s.buckets[name]
+	if !ok {
+		writeError(w, false, noSuchBucket())
+		return
+	}
+	spec := bucketSubresources[subresource]
+	if code, ok := s.notFoundCodes[subresource]; ok {
+		spec.notFoundCode = code
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		doc, ok := b.config[subresource]
+		if !ok {
+			if spec.notFoundCode != "" {
+				writeError(w, false, newError(http.StatusNotFound, spec.notFoundCode, "The "+subresource+" configuration does not exist"))
+				return
+			}
+			doc = []byte(spec.emptyDocument)
+		}
+		if subresource == "policy" {
+			w.Header().Set("Content-Type", "application/json")
+		} else {
+			w.Header().Set("Content-Type", "application/xml")
+		}
+		_, _ = w.Write(doc)
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		if subresource == "object-lock" {
+			if _, enabled := b.config["object-lock"]; !enabled {
+				writeError(w, false, newError(http.StatusConflict, "InvalidBucketState", "Object Lock configuration cannot be enabled on existing buckets"))
+				return
+			}
+		}
+		if subresource == "versioning" {
+			// Like RustFS the fake does not support MFA delete and drops it.
+			body = mfaDeletePattern.ReplaceAll(body, nil)
+		}
+		b.config[subresource] = body
+	case http.MethodDelete:
+		delete(b.config, subresource)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, false, newError(http.StatusMethodNotAllowed, "MethodNotAllowed", "method not allowed"))
+	}
+}
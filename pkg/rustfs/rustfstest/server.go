@@ -0,0 +1,208 @@
+// Package rustfstest provides an in-process fake of the RustFS admin and S3
+// API for hermetic tests.
+//
+// The fake verifies SigV4 signatures and keeps users, groups, canned
+// policies, service accounts, quotas, tiers, buckets and their remote targets
+// in memory. Only the routes used by pkg/rustfs, the bucket configuration
+// calls of minio-go and basic versioned object storage are implemented.
+package rustfstest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	// AccessKey and SecretKey are the root credentials of every server.
+	AccessKey = "rustfsadmin"
+	SecretKey = "rustfsadmin"
+
+	adminPrefix = "/rustfs/admin/v3/"
+)
+
+// Server is a fake RustFS server. All methods are safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu              sync.Mutex
+	users           map[string]*user
+	groups          map[string]*group
+	policies        map[string]json.RawMessage
+	serviceAccounts map[string]*serviceAccount
+	sessions        map[string]session
+	tiers           map[string]*tier
+	buckets         map[string]*bucket
+	notifyTargets   map[string]bool
+	eventTargets    map[string]map[string]string
+	notFoundCodes   map[string]string
+}
+
+// NewServer starts a fake server which is closed when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	s := newServer()
+	s.Server = httptest.NewServer(s)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// NewTLSServer is like NewServer but serves HTTPS with the self-signed
+// certificate of httptest.
+func NewTLSServer(t testing.TB) *Server {
+	t.Helper()
+	s := newServer()
+	s.Server = httptest.NewTLSServer(s)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Start starts a fake server outside of a single test, e.g. from TestMain.
+// The caller must Close it.
+func Start() *Server {
+	s := newServer()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+func newServer() *Server {
+	s := &Server{
+		users:           map[string]*user{},
+		groups:          map[string]*group{},
+		policies:        map[string]json.RawMessage{},
+		serviceAccounts: map[string]*serviceAccount{},
+		sessions:        map[string]session{},
+		tiers:           map[string]*tier{},
+		buckets:         map[string]*bucket{},
+		notifyTargets:   map[string]bool{},
+		eventTargets:    map[string]map[string]string{},
+		notFoundCodes:   map[string]string{},
+	}
+	for name, doc := range builtinPolicies {
+		s.policies[name] = json.RawMessage(doc)
+	}
+	return s
+}
+
+// AddNotificationTarget registers a notification target ARN, e.g.
+// arn:rustfs:sqs::primary:webhook, as if it was configured on the server.
+func (s *Server) AddNotificationTarget(arn string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifyTargets[arn] = true
+}
+
+// NotificationTarget returns the configuration of the target name of
+// targetType, e.g. notify_webhook, and whether it exists.
+func (s *Server) NotificationTarget(targetType, name string) (map[string]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	config, ok := s.eventTargets[targetType+":"+name]
+	return maps.Clone(config), ok
+}
+
+// Tier returns the configuration the tier name was added or last edited
+// with and whether it exists.
+func (s *Server) Tier(name string) (json.RawMessage, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tiers[name]
+	if !ok {
+		return nil, false
+	}
+	return slices.Clone(t.Config), true
+}
+
+// SetTierUsage sets the usage reported for the tier name, as if objects had
+// been transitioned to it.
+func (s *Server) SetTierUsage(name string, usage TierUsage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.tiers[name]; ok {
+		t.Usage = usage
+	}
+}
+
+// SetNotFoundCode sets the error code the server replies with for an unset
+// bucket subresource, e.g. NoSuchObjectLockConfiguration for object-lock,
+// which other server releases send.
+func (s *Server) SetNotFoundCode(subresource, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notFoundCodes[subresource] = code
+}
+
+// Endpoint returns the address of the server in host:port format as
+// expected by the provider and rustfs.RustfsAdminConfig.
+func (s *Server) Endpoint() string {
+	return s.Listener.Addr().String()
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	admin := strings.HasPrefix(r.URL.Path, adminPrefix)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.verifySignature(r); err != nil {
+		writeError(w, admin, err)
+		return
+	}
+	// STS requests are form encoded POSTs to the root path.
+	if r.Method == http.MethodPost && r.URL.Path == "/" {
+		s.handleSTS(w, r)
+		return
+	}
+	if admin {
+		s.handleAdmin(w, r, strings.TrimPrefix(r.URL.Path, adminPrefix))
+		return
+	}
+	s.handleS3(w, r)
+}
+
+// apiError is the error reply of the fake. Admin routes encode it as JSON,
+// S3 routes as XML.
+type apiError struct {
+	XMLName xml.Name `xml:"Error" json:"-"`
+	Status  int      `xml:"-" json:"-"`
+	Code    string   `xml:"Code" json:"Code"`
+	Message string   `xml:"Message" json:"Message"`
+}
+
+func (e *apiError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+func newError(status int, code, message string) *apiError {
+	return &apiError{Status: status, Code: code, Message: message}
+}
+
+func writeError(w http.ResponseWriter, admin bool, err *apiError) {
+	if admin {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(err.Status)
+		_ = json.NewEncoder(w).Encode(err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(err.Status)
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(err)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeXML(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,505 @@
+package rustfstest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs/rustfstest"
+)
+
+func newClients(t *testing.T) (*rustfstest.Server, rustfs.RustfsAdmin, *minio.Client) {
+	server := rustfstest.NewServer(t)
+	admin := rustfs.New(&rustfs.RustfsAdminConfig{
+		Endpoint:     server.Endpoint(),
+		AccessKey:    rustfstest.AccessKey,
+		AccessSecret: rustfstest.SecretKey,
+	})
+	s3, err := minio.New(server.Endpoint(), &minio.Options{
+		Creds:  credentials.NewStaticV4(rustfstest.AccessKey, rustfstest.SecretKey, ""),
+		Region: rustfs.DefaultRegion,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, admin, s3
+}
+
+func TestSignatureVerification(t *testing.T) {
+	server := rustfstest.NewServer(t)
+	ctx := context.Background()
+
+	wrong := rustfs.New(&rustfs.RustfsAdminConfig{
+		Endpoint:     server.Endpoint(),
+		AccessKey:    rustfstest.AccessKey,
+		AccessSecret: "wrong",
+	})
+	if _, err := wrong.IsAdmin(ctx); !rustfs.IsAccessDenied(err) {
+		t.Errorf("expected access denied for a wrong secret, got %v", err)
+	}
+
+	unknown := rustfs.New(&rustfs.RustfsAdminConfig{
+		Endpoint:     server.Endpoint(),
+		AccessKey:    "nobody",
+		AccessSecret: "secret",
+	})
+	if _, err := unknown.IsAdmin(ctx); !rustfs.IsAccessDenied(err) {
+		t.Errorf("expected access denied for an unknown key, got %v", err)
+	}
+
+	res, err := http.Get(server.URL + "/rustfs/admin/v3/is-admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusForbidden {
+		t.Errorf("expected unsigned request to be rejected, got %d", res.StatusCode)
+	}
+}
+
+func TestUserCredentials(t *testing.T) {
+	server, admin, _ := newClients(t)
+	ctx := context.Background()
+
+	if err := admin.CreateUserAccount(ctx, rustfs.UserAccount{AccessKey: "alice", SecretKey: "alice-secret"}); err != nil {
+		t.Fatal(err)
+	}
+	alice := rustfs.New(&rustfs.RustfsAdminConfig{
+		Endpoint:     server.Endpoint(),
+		AccessKey:    "alice",
+		AccessSecret: "alice-secret",
+	})
+	if _, err := alice.ListPools(ctx); err != nil {
+		t.Errorf("expected user credentials to be accepted: %v", err)
+	}
+
+	if err := admin.UpdateUserAccount(ctx, rustfs.UserAccount{AccessKey: "alice", Status: "disabled"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := alice.ListPools(ctx); !rustfs.IsAccessDenied(err) {
+		t.Errorf("expected disabled user to be rejected, got %v", err)
+	}
+}
+
+func TestIAM(t *testing.T) {
+	_, admin, _ := newClients(t)
+	ctx := context.Background()
+
+	if err := admin.CreateUserAccount(ctx, rustfs.UserAccount{AccessKey: "bob", SecretKey: "bob-secret", Policy: "readwrite"}); err != nil {
+		t.Fatal(err)
+	}
+	user, err := admin.ReadUserAccount(ctx, "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Status != "enabled" || user.Policy != "readwrite" {
+		t.Errorf("unexpected user %+v", user)
+	}
+
+	if err := admin.UpdateGroupMembers(ctx, rustfs.GroupAddRemove{Group: "devs", Members: []string{"bob"}}); err != nil {
+		t.Fatal(err)
+	}
+	group, err := admin.GetGroup(ctx, "devs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.Status != "enabled" || len(group.Members) != 1 {
+		t.Errorf("unexpected group %+v", group)
+	}
+	user, _ = admin.ReadUserAccount(ctx, "bob")
+	if len(user.Groups) != 1 || user.Groups[0] != "devs" {
+		t.Errorf("expected bob to be member of devs, got %v", user.Groups)
+	}
+	if err := admin.SetGroupPolicies(ctx, "devs", []string{"readonly", "diagnostics"}); err != nil {
+		t.Fatal(err)
+	}
+	entities, err := admin.GetPolicyEntities(ctx, rustfs.PolicyEntitiesQuery{Users: []string{"bob"}, Groups: []string{"devs"}, Policies: []string{"readonly"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entities.UserMappings) != 1 || len(entities.UserMappings[0].Policies) != 1 || len(entities.GroupMappings[0].Policies) != 2 {
+		t.Errorf("unexpected policy entities %+v", entities)
+	}
+	if len(entities.PolicyMappings) != 1 || len(entities.PolicyMappings[0].Groups) != 1 || len(entities.PolicyMappings[0].Users) != 0 {
+		t.Errorf("unexpected policy mappings %+v", entities.PolicyMappings)
+	}
+	if err := admin.SetGroupPolicies(ctx, "devs", []string{"unknown"}); !rustfs.IsNotFound(err) {
+		t.Errorf("expected an unknown policy to be not found, got %v", err)
+	}
+	if err := admin.DeleteGroup(ctx, "devs"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := admin.GetGroup(ctx, "devs"); !rustfs.IsNotFound(err) {
+		t.Errorf("expected deleted group to be not found, got %v", err)
+	}
+
+	policy := rustfs.Policy{
+		Name: "getter",
+		Statement: []rustfs.PolicyStatement{
+			{Effect: "Allow", Action: []string{"s3:GetObject"}, Resource: []string{"arn:aws:s3:::bucket/*"}},
+		},
+	}
+	if err := admin.CreatePolicy(ctx, policy); err != nil {
+		t.Fatal(err)
+	}
+	read, err := admin.ReadPolicy(ctx, "getter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Name != "getter" || len(read.Statement) != 1 || read.Statement[0].Action[0] != "s3:GetObject" {
+		t.Errorf("unexpected policy %+v", read)
+	}
+	if err := admin.DeletePolicy(ctx, "getter"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := admin.ReadPolicy(ctx, "getter"); !rustfs.IsNotFound(err) {
+		t.Errorf("expected deleted policy to be not found, got %v", err)
+	}
+
+	account := rustfs.ServiceAccount{AccessKey: "svc", SecretKey: "svc-secret", Name: "svc", TargetUser: "bob"}
+	if err := admin.CreateServiceAccount(ctx, account); err != nil {
+		t.Fatal(err)
+	}
+	account.Description = "changed"
+	if err := admin.UpdateServiceAccount(ctx, account); err != nil {
+		t.Fatal(err)
+	}
+	sa, err := admin.ReadServiceAccount(ctx, "svc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sa.Name != "svc" || sa.Description != "changed" {
+		t.Errorf("unexpected service account %+v", sa)
+	}
+
+	if err := admin.DeleteUserAccount(ctx, rustfs.UserAccount{AccessKey: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := admin.ReadServiceAccount(ctx, "svc"); !rustfs.IsNotFound(err) {
+		t.Errorf("expected service account to be removed with its parent, got %v", err)
+	}
+	if _, err := admin.ReadUserAccount(ctx, "bob"); !rustfs.IsNotFound(err) {
+		t.Errorf("expected deleted user to be not found, got %v", err)
+	}
+}
+
+func TestBucketsAndQuota(t *testing.T) {
+	_, admin, s3 := newClients(t)
+	ctx := context.Background()
+
+	if err := s3.MakeBucket(ctx, "data", minio.MakeBucketOptions{Region: rustfs.DefaultRegion}); err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := s3.BucketExists(ctx, "data"); err != nil || !exists {
+		t.Fatalf("expected bucket to exist: %v", err)
+	}
+	if _, err := admin.SetQuota(ctx, rustfs.Quota{Bucket: "data", Quota: 1024}); err != nil {
+		t.Fatal(err)
+	}
+	quota, err := admin.ReadQuota(ctx, "data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quota.Bucket != "data" || quota.Quota != 1024 {
+		t.Errorf("unexpected quota %+v", quota)
+	}
+	if _, err := admin.ReadQuota(ctx, "missing"); !rustfs.IsNotFound(err) {
+		t.Errorf("expected quota of missing bucket to be not found, got %v", err)
+	}
+
+	if err := admin.CreateBucket(ctx, "other"); err != nil {
+		t.Fatal(err)
+	}
+	if err := admin.CreateBucket(ctx, "other"); !rustfs.IsConflict(err) {
+		t.Errorf("expected conflict for an existing bucket, got %v", err)
+	}
+	buckets, err := s3.ListBuckets(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 2 {
+		t.Errorf("expected 2 buckets, got %d", len(buckets))
+	}
+
+	if err := s3.RemoveBucket(ctx, "data"); err != nil {
+		t.Fatal(err)
+	}
+	if exists, _ := s3.BucketExists(ctx, "data"); exists {
+		t.Error("expected bucket to be removed")
+	}
+}
+
+func TestBucketSubresources(t *testing.T) {
+	_, admin, s3 := newClients(t)
+	ctx := context.Background()
+
+	if err := s3.MakeBucket(ctx, "locked", minio.MakeBucketOptions{ObjectLocking: true}); err != nil {
+		t.Fatal(err)
+	}
+	versioning, err := s3.GetBucketVersioning(ctx, "locked")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !versioning.Enabled() {
+		t.Error("expected object lock buckets to be versioned")
+	}
+	enabled, _, _, _, err := s3.GetObjectLockConfig(ctx, "locked")
+	if err != nil || enabled != "Enabled" {
+		t.Errorf("expected object lock to be enabled, got %q: %v", enabled, err)
+	}
+
+	if err := s3.MakeBucket(ctx, "plain", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s3.GetBucketTagging(ctx, "plain"); minio.ToErrorResponse(err).Code != "NoSuchTagSet" {
+		t.Errorf("expected missing tags to fail with NoSuchTagSet, got %v", err)
+	}
+	if err := s3.SuspendVersioning(ctx, "plain"); err != nil {
+		t.Fatal(err)
+	}
+	versioning, err = s3.GetBucketVersioning(ctx, "plain")
+	if err != nil || !versioning.Suspended() {
+		t.Errorf("expected suspended versioning, got %+v: %v", versioning, err)
+	}
+
+	days := 7
+	lifecycle := &rustfs.LifecycleConfiguration{Rules: []rustfs.LifecycleRule{
+		{ID: "expire", Status: "Enabled", Expiration: &rustfs.LifecycleExpiration{Days: &days}},
+	}}
+	if err := admin.SetBucketLifecycleConfiguration(ctx, "plain", lifecycle); err != nil {
+		t.Fatal(err)
+	}
+	read, err := admin.GetBucketLifecycleConfiguration(ctx, "plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read.Rules) != 1 || *read.Rules[0].Expiration.Days != 7 {
+		t.Errorf("unexpected lifecycle %+v", read)
+	}
+	if err := admin.DeleteBucketLifecycleConfiguration(ctx, "plain"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := admin.GetBucketLifecycleConfiguration(ctx, "plain"); !rustfs.IsNotFound(err) {
+		t.Errorf("expected lifecycle to be removed, got %v", err)
+	}
+}
+
+func TestObjects(t *testing.T) {
+	_, _, s3 := newClients(t)
+	ctx := context.Background()
+
+	if err := s3.MakeBucket(ctx, "objects", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s3.EnableVersioning(ctx, "objects"); err != nil {
+		t.Fatal(err)
+	}
+	for _, content := range []string{"one", "two"} {
+		if _, err := s3.PutObject(ctx, "objects", "dir/key", strings.NewReader(content), int64(len(content)), minio.PutObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	object, err := s3.GetObject(ctx, "objects", "dir/key", minio.GetObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(object)
+	if string(data) != "two" {
+		t.Errorf("expected latest version, got %q", data)
+	}
+	if err := s3.RemoveObject(ctx, "objects", "dir/key", minio.RemoveObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var versions, markers int
+	for info := range s3.ListObjects(ctx, "objects", minio.ListObjectsOptions{Recursive: true, WithVersions: true}) {
+		if info.Err != nil {
+			t.Fatal(info.Err)
+		}
+		if info.IsDeleteMarker {
+			markers++
+		} else {
+			versions++
+		}
+	}
+	if versions != 2 || markers != 1 {
+		t.Errorf("expected 2 versions and 1 delete marker, got %d and %d", versions, markers)
+	}
+	for info := range s3.ListObjects(ctx, "objects", minio.ListObjectsOptions{Recursive: true}) {
+		t.Errorf("expected deleted object to be hidden, got %q", info.Key)
+	}
+
+	if err := s3.RemoveBucket(ctx, "objects"); minio.ToErrorResponse(err).Code != "BucketNotEmpty" {
+		t.Errorf("expected BucketNotEmpty, got %v", err)
+	}
+	objects := s3.ListObjects(ctx, "objects", minio.ListObjectsOptions{Recursive: true, WithVersions: true})
+	for removeErr := range s3.RemoveObjects(ctx, "objects", objects, minio.RemoveObjectsOptions{}) {
+		t.Error(removeErr.Err)
+	}
+	if err := s3.RemoveBucket(ctx, "objects"); err != nil {
+		t.Errorf("expected emptied bucket to be removed: %v", err)
+	}
+}
+
+func TestObjectLock(t *testing.T) {
+	_, _, s3 := newClients(t)
+	ctx := context.Background()
+
+	if err := s3.MakeBucket(ctx, "locked", minio.MakeBucketOptions{ObjectLocking: true}); err != nil {
+		t.Fatal(err)
+	}
+	info, err := s3.PutObject(ctx, "locked", "report.pdf", strings.NewReader("report"), 6, minio.PutObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mode := minio.Governance
+	until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	err = s3.PutObjectRetention(ctx, "locked", "report.pdf", minio.PutObjectRetentionOptions{Mode: &mode, RetainUntilDate: &until, VersionID: info.VersionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	readMode, readUntil, err := s3.GetObjectRetention(ctx, "locked", "report.pdf", info.VersionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *readMode != minio.Governance || !readUntil.Equal(until) {
+		t.Errorf("unexpected retention %v until %v", *readMode, readUntil)
+	}
+
+	// Shortening governance retention needs the bypass header.
+	earlier := until.Add(-time.Minute)
+	err = s3.PutObjectRetention(ctx, "locked", "report.pdf", minio.PutObjectRetentionOptions{Mode: &mode, RetainUntilDate: &earlier, VersionID: info.VersionID})
+	if !rustfs.IsAccessDenied(err) {
+		t.Errorf("expected access denied without bypass, got %v", err)
+	}
+	if err := s3.RemoveObject(ctx, "locked", "report.pdf", minio.RemoveObjectOptions{VersionID: info.VersionID}); !rustfs.IsAccessDenied(err) {
+		t.Errorf("expected access denied deleting a retained version, got %v", err)
+	}
+	err = s3.PutObjectRetention(ctx, "locked", "report.pdf", minio.PutObjectRetentionOptions{GovernanceBypass: true, VersionID: info.VersionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	on := minio.LegalHoldEnabled
+	if err := s3.PutObjectLegalHold(ctx, "locked", "report.pdf", minio.PutObjectLegalHoldOptions{Status: &on}); err != nil {
+		t.Fatal(err)
+	}
+	status, err := s3.GetObjectLegalHold(ctx, "locked", "report.pdf", minio.GetObjectLegalHoldOptions{})
+	if err != nil || *status != minio.LegalHoldEnabled {
+		t.Errorf("expected legal hold, got %v: %v", status, err)
+	}
+	if err := s3.RemoveObject(ctx, "locked", "report.pdf", minio.RemoveObjectOptions{VersionID: info.VersionID, GovernanceBypass: true}); !rustfs.IsAccessDenied(err) {
+		t.Errorf("expected access denied deleting a version on legal hold, got %v", err)
+	}
+}
+
+func TestTiers(t *testing.T) {
+	server, admin, _ := newClients(t)
+	ctx := context.Background()
+
+	config := []byte(`{"tier_type":"s3","s3":{"name":"COLD","bucket":"archive"}}`)
+	if err := admin.AddTier(ctx, config); err != nil {
+		t.Fatal(err)
+	}
+	if err := admin.AddTier(ctx, config); !rustfs.IsConflict(err) {
+		t.Errorf("expected conflict for an existing tier, got %v", err)
+	}
+	if err := admin.EditTier(ctx, "COLD", []byte(`{"access_key":"a","secret_key":"b"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := admin.EditTier(ctx, "COLD", config); err == nil {
+		t.Error("expected an error for editing other settings than credentials")
+	}
+	tiers, err := admin.ListTiers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tiers) != 1 || tiers[0].Name != "COLD" || tiers[0].Type != "s3" || tiers[0].Bucket != "archive" || tiers[0].AccessKey != "a" {
+		t.Errorf("unexpected tiers %+v", tiers)
+	}
+	if config, _ := server.Tier("COLD"); !strings.Contains(string(config), `"secret_key":"b"`) {
+		t.Errorf("expected the edited credentials in %s", config)
+	}
+	server.SetTierUsage("COLD", rustfstest.TierUsage{TotalSize: 1024, NumVersions: 2, NumObjects: 1})
+	stats, err := admin.GetTierStats(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 || stats[0].Name != "COLD" || stats[0].Stats.TotalSize != 1024 || stats[0].Stats.NumObjects != 1 {
+		t.Errorf("unexpected tier stats %+v", stats)
+	}
+	if err := admin.RemoveTier(ctx, "COLD"); err != nil {
+		t.Fatal(err)
+	}
+	if err := admin.RemoveTier(ctx, "COLD"); !rustfs.IsNotFound(err) {
+		t.Errorf("expected removed tier to be not found, got %v", err)
+	}
+	if _, err := admin.GetTier(ctx, "COLD"); !rustfs.IsNotFound(err) {
+		t.Errorf("expected removed tier to be not found, got %v", err)
+	}
+}
+
+func TestAssumeRole(t *testing.T) {
+	server := rustfstest.NewServer(t)
+	creds := credentials.New(&credentials.STSAssumeRole{
+		Client:      server.Client(),
+		STSEndpoint: server.URL,
+		Options: credentials.STSAssumeRoleOptions{
+			AccessKey: rustfstest.AccessKey,
+			SecretKey: rustfstest.SecretKey,
+		},
+	})
+	value, err := creds.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.SessionToken == "" || value.AccessKeyID == rustfstest.AccessKey {
+		t.Fatalf("expected temporary credentials, got %+v", value)
+	}
+
+	client := rustfs.New(&rustfs.RustfsAdminConfig{Endpoint: server.Endpoint(), Credentials: creds})
+	if _, err := client.ListPools(context.Background()); err != nil {
+		t.Errorf("expected temporary credentials to be accepted: %v", err)
+	}
+
+	withoutToken := rustfs.New(&rustfs.RustfsAdminConfig{
+		Endpoint:     server.Endpoint(),
+		AccessKey:    value.AccessKeyID,
+		AccessSecret: value.SecretAccessKey,
+	})
+	if _, err := withoutToken.ListPools(context.Background()); !rustfs.IsAccessDenied(err) {
+		t.Errorf("expected temporary credentials without token to be rejected, got %v", err)
+	}
+}
+
+func TestTLSServer(t *testing.T) {
+	server := rustfstest.NewTLSServer(t)
+	client := rustfs.New(&rustfs.RustfsAdminConfig{
+		Endpoint:     server.Endpoint(),
+		AccessKey:    rustfstest.AccessKey,
+		AccessSecret: rustfstest.SecretKey,
+		Ssl:          true,
+		Insecure:     true,
+	})
+	if _, err := client.IsAdmin(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnknownRoute(t *testing.T) {
+	_, admin, _ := newClients(t)
+	if err := admin.CreateBucket(context.Background(), "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := admin.DoDirectRequest(context.Background(), rustfs.RequestData{Method: "POST", RelPath: "bucket/object"})
+	if err == nil || !strings.Contains(err.Error(), "NotImplemented") {
+		t.Errorf("expected NotImplemented, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package rustfstest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+)
+
+const (
+	signV4Algorithm  = "AWS4-HMAC-SHA256"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	streamingPayload = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	amzDateFormat    = "20060102T150405Z"
+)
+
+// verifySignature checks the SigV4 Authorization header of r against the
+// known credentials. The request body is buffered so handlers can read it.
+func (s *Server) verifySignature(r *http.Request) *apiError {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return newError(http.StatusBadRequest, "IncompleteBody", err.Error())
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, signV4Algorithm+" ") {
+		return newError(http.StatusForbidden, "AccessDenied", "Signature V4 authorization required")
+	}
+	fields := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(auth, signV4Algorithm+" "), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		fields[key] = value
+	}
+	scope := strings.Split(fields["Credential"], "/")
+	if len(scope) != 5 || scope[4] != "aws4_request" {
+		return newError(http.StatusBadRequest, "AuthorizationHeaderMalformed", "Malformed credential scope")
+	}
+	accessKey, day, region, service := scope[0], scope[1], scope[2], scope[3]
+
+	secret, ok := s.secretFor(accessKey, r.Header.Get("X-Amz-Security-Token"))
+	if !ok {
+		return newError(http.StatusForbidden, "InvalidAccessKeyId", "The access key ID you provided does not exist in our records.")
+	}
+
+	date := r.Header.Get("X-Amz-Date")
+	if _, err := time.Parse(amzDateFormat, date); err != nil || !strings.HasPrefix(date, day) {
+		return newError(http.StatusForbidden, "AccessDenied", "Invalid X-Amz-Date")
+	}
+
+	// STS clients sign the payload hash without sending the header.
+	sum := sha256.Sum256(body)
+	payload := r.Header.Get("X-Amz-Content-Sha256")
+	switch payload {
+	case "":
+		payload = hex.EncodeToString(sum[:])
+	case unsignedPayload:
+	case streamingPayload:
+		decoded, ok := decodeChunked(body)
+		if !ok {
+			return newError(http.StatusBadRequest, "IncompleteBody", "Malformed aws-chunked body")
+		}
+		r.Body = io.NopCloser(bytes.NewReader(decoded))
+	default:
+		if payload != hex.EncodeToString(sum[:]) {
+			return newError(http.StatusBadRequest, "XAmzContentSHA256Mismatch", "The provided 'x-amz-content-sha256' header does not match what was computed.")
+		}
+	}
+
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		s3utils.EncodePath(r.URL.Path),
+		strings.ReplaceAll(r.URL.Query().Encode(), "+", "%20"),
+		canonicalHeaders(r, signedHeaders),
+		strings.Join(signedHeaders, ";"),
+		payload,
+	}, "\n")
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		signV4Algorithm,
+		date,
+		strings.Join(scope[1:], "/"),
+		hex.EncodeToString(hashed[:]),
+	}, "\n")
+
+	key := hmacSum([]byte("AWS4"+secret), day)
+	key = hmacSum(key, region)
+	key = hmacSum(key, service)
+	key = hmacSum(key, "aws4_request")
+	expected := hex.EncodeToString(hmacSum(key, stringToSign))
+	if !hmac.Equal([]byte(expected), []byte(fields["Signature"])) {
+		return newError(http.StatusForbidden, "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided.")
+	}
+	return nil
+}
+
+func canonicalHeaders(r *http.Request, signed []string) string {
+	sorted := append([]string(nil), signed...)
+	sort.Strings(sorted)
+	var buf strings.Builder
+	for _, name := range sorted {
+		buf.WriteString(name)
+		buf.WriteByte(':')
+		if name == "host" {
+			buf.WriteString(r.Host)
+		} else {
+			values := r.Header.Values(name)
+			for i, v := range values {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				buf.WriteString(strings.Join(strings.Fields(v), " "))
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+func hmacSum(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// secretFor returns the secret of accessKey. Temporary credentials are only
+// accepted together with their session token.
+func (s *Server) secretFor(accessKey, token string) (string, bool) {
+	if accessKey == AccessKey {
+		return SecretKey, true
+	}
+	if u, ok := s.users[accessKey]; ok && u.Status == "enabled" {
+		return u.SecretKey, true
+	}
+	if sa, ok := s.serviceAccounts[accessKey]; ok {
+		return sa.SecretKey, true
+	}
+	if session, ok := s.sessions[accessKey]; ok && session.token == token && time.Now().Before(session.expiration) {
+		return session.secretKey, true
+	}
+	return "", false
+}
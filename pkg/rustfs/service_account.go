@@ -36,7 +36,7 @@ type ServiceAccountReply struct {
 	Credentials serviceAccountCredentails `json:"credentials"`
 }
 
-func (c *RustfsAdmin) CreateServiceAccount(account ServiceAccount) error {
+func (c *RustfsAdmin) CreateServiceAccount(ctx context.Context, account ServiceAccount) error {
 	normalizeServiceAccount(&account)
 	//#nosec G117 — AccessKey is a public identifier, not a secret
 	bytes, err := json.Marshal(account)
@@ -48,8 +48,6 @@ func (c *RustfsAdmin) CreateServiceAccount(account ServiceAccount) error {
 		RelPath: "add-service-accounts",
 		Content: bytes,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, req_data)
 	if err != nil {
 		return err
@@ -59,7 +57,7 @@ func (c *RustfsAdmin) CreateServiceAccount(account ServiceAccount) error {
 	return err
 }
 
-func (c *RustfsAdmin) ReadServiceAccount(name string) (ServiceAccount, error) {
+func (c *RustfsAdmin) ReadServiceAccount(ctx context.Context, name string) (ServiceAccount, error) {
 	var instance ServiceAccount
 	urlValues := make(url.Values)
 	urlValues.Set("accessKey", name)
@@ -68,8 +66,6 @@ func (c *RustfsAdmin) ReadServiceAccount(name string) (ServiceAccount, error) {
 		RelPath:     "info-service-account",
 		QueryValues: urlValues,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, req_data)
 	if err != nil {
 		return instance, err
@@ -78,7 +74,7 @@ func (c *RustfsAdmin) ReadServiceAccount(name string) (ServiceAccount, error) {
 	return instance, err
 }
 
-func (c *RustfsAdmin) UpdateServiceAccount(account ServiceAccount) error {
+func (c *RustfsAdmin) UpdateServiceAccount(ctx context.Context, account ServiceAccount) error {
 	normalizeServiceAccount(&account)
 	updateRequest := createUpdate(account)
 	urlValues := make(url.Values)
@@ -93,8 +89,6 @@ func (c *RustfsAdmin) UpdateServiceAccount(account ServiceAccount) error {
 		QueryValues: urlValues,
 		Content:     bytes,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	_, err = c.doRequest(ctx, req_data)
 	if err != nil {
 		return err
@@ -102,7 +96,7 @@ func (c *RustfsAdmin) UpdateServiceAccount(account ServiceAccount) error {
 	return err
 }
 
-func (c *RustfsAdmin) DeleteServiceAccount(account ServiceAccount) error {
+func (c *RustfsAdmin) DeleteServiceAccount(ctx context.Context, account ServiceAccount) error {
 	normalizeServiceAccount(&account)
 	urlValues := make(url.Values)
 	urlValues.Set("accessKey", account.AccessKey)
@@ -111,8 +105,6 @@ func (c *RustfsAdmin) DeleteServiceAccount(account ServiceAccount) error {
 		RelPath:     "delete-service-accounts",
 		QueryValues: urlValues,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	_, err := c.doRequest(ctx, req_data)
 	if err != nil {
 		return err
@@ -1,6 +1,7 @@
 package rustfs_test
 
 import (
+	"context"
 	"math/rand"
 	"os"
 	"testing"
@@ -47,7 +48,7 @@ func TestCreateServiceAccount(t *testing.T) {
 		Name:      randomString(8),
 	}
 	dut := getClient()
-	err := dut.CreateServiceAccount(account)
+	err := dut.CreateServiceAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
@@ -61,11 +62,11 @@ func TestCreateAndDeleteServiceAccount(t *testing.T) {
 		Name:      randomString(8),
 	}
 	dut := getClient()
-	err := dut.CreateServiceAccount(account)
+	err := dut.CreateServiceAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
-	err = dut.DeleteServiceAccount(account)
+	err = dut.DeleteServiceAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
@@ -78,16 +79,16 @@ func TestCreateUpdateAndDeleteServiceAccount(t *testing.T) {
 		Name:      randomString(8),
 	}
 	dut := getClient()
-	err := dut.CreateServiceAccount(account)
+	err := dut.CreateServiceAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
 	account.SecretKey = "insecureOne"
-	err = dut.UpdateServiceAccount(account)
+	err = dut.UpdateServiceAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
-	err = dut.DeleteServiceAccount(account)
+	err = dut.DeleteServiceAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
@@ -100,18 +101,18 @@ func TestCreateReadAndDeleteServiceAccount(t *testing.T) {
 		Name:      randomString(8),
 	}
 	dut := getClient()
-	err := dut.CreateServiceAccount(account)
+	err := dut.CreateServiceAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
-	reply, err := dut.ReadServiceAccount(account.AccessKey)
+	reply, err := dut.ReadServiceAccount(context.Background(), account.AccessKey)
 	if err != nil {
 		t.Error(err)
 	}
 	if reply.Name != account.Name {
 		t.Error("Read value not matching")
 	}
-	err = dut.DeleteServiceAccount(account)
+	err = dut.DeleteServiceAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
@@ -3,16 +3,93 @@ package rustfs
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 )
 
-func (c *RustfsAdmin) AddTier(config json.RawMessage) error {
+// TierConfig is a tier as listed by the server. Secrets such as secret_key
+// are redacted by the server and not part of it.
+type TierConfig struct {
+	Name         string
+	Type         string
+	Endpoint     string
+	Bucket       string
+	Prefix       string
+	Region       string
+	StorageClass string
+	// AccessKey is the access key of S3 like backends and the account name
+	// of azure.
+	AccessKey string
+}
+
+// UnmarshalJSON decodes a tier configuration such as
+// {"tier_type":"s3","s3":{"name":"COLD","endpoint":...}}.
+func (t *TierConfig) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var header struct {
+		Name     string `json:"name"`
+		TierType string `json:"tier_type"`
+		Type     string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+	t.Type = header.TierType
+	if t.Type == "" {
+		t.Type = header.Type
+	}
+	var backend struct {
+		Name         string `json:"name"`
+		Endpoint     string `json:"endpoint"`
+		Bucket       string `json:"bucket"`
+		Prefix       string `json:"prefix"`
+		Region       string `json:"region"`
+		StorageClass string `json:"storage_class"`
+		AccessKey    string `json:"access_key"`
+		AccountName  string `json:"account_name"`
+	}
+	if raw, ok := fields[t.Type]; ok {
+		if err := json.Unmarshal(raw, &backend); err != nil {
+			return err
+		}
+	}
+	t.Name = backend.Name
+	if t.Name == "" {
+		t.Name = header.Name
+	}
+	t.Endpoint = backend.Endpoint
+	t.Bucket = backend.Bucket
+	t.Prefix = backend.Prefix
+	t.Region = backend.Region
+	t.StorageClass = backend.StorageClass
+	t.AccessKey = backend.AccessKey
+	if t.AccessKey == "" {
+		t.AccessKey = backend.AccountName
+	}
+	return nil
+}
+
+// TierStats is the usage of a tier.
+type TierStats struct {
+	Name  string    `json:"name"`
+	Type  string    `json:"type"`
+	Stats TierUsage `json:"stats"`
+}
+
+type TierUsage struct {
+	TotalSize   int64 `json:"total_size"`
+	NumVersions int64 `json:"num_versions"`
+	NumObjects  int64 `json:"num_objects"`
+}
+
+func (c *RustfsAdmin) AddTier(ctx context.Context, config json.RawMessage) error {
 	reqData := RequestData{
 		Method:  "PUT",
 		RelPath: "tier",
 		Content: []byte(config),
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, reqData)
 	if err != nil {
 		return err
@@ -21,14 +98,12 @@ func (c *RustfsAdmin) AddTier(config json.RawMessage) error {
 	return nil
 }
 
-func (c *RustfsAdmin) EditTier(name string, config json.RawMessage) error {
+func (c *RustfsAdmin) EditTier(ctx context.Context, name string, config json.RawMessage) error {
 	reqData := RequestData{
 		Method:  "POST",
 		RelPath: "tier/" + name,
 		Content: []byte(config),
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, reqData)
 	if err != nil {
 		return err
@@ -37,13 +112,11 @@ func (c *RustfsAdmin) EditTier(name string, config json.RawMessage) error {
 	return nil
 }
 
-func (c *RustfsAdmin) RemoveTier(name string) error {
+func (c *RustfsAdmin) RemoveTier(ctx context.Context, name string) error {
 	reqData := RequestData{
 		Method:  "DELETE",
 		RelPath: "tier/" + name,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, reqData)
 	if err != nil {
 		return err
@@ -51,3 +124,54 @@ func (c *RustfsAdmin) RemoveTier(name string) error {
 	defer resp.Body.Close()
 	return nil
 }
+
+// ListTiers returns the tiers configured on the server.
+func (c *RustfsAdmin) ListTiers(ctx context.Context) ([]TierConfig, error) {
+	reqData := RequestData{
+		Method:  "GET",
+		RelPath: "tier",
+	}
+	resp, err := c.doRequest(ctx, reqData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var tiers []TierConfig
+	err = json.NewDecoder(resp.Body).Decode(&tiers)
+	return tiers, err
+}
+
+// GetTier returns the tier name.
+func (c *RustfsAdmin) GetTier(ctx context.Context, name string) (TierConfig, error) {
+	tiers, err := c.ListTiers(ctx)
+	if err != nil {
+		return TierConfig{}, err
+	}
+	for _, tier := range tiers {
+		if tier.Name == name {
+			return tier, nil
+		}
+	}
+	return TierConfig{}, &APIError{
+		StatusCode: http.StatusNotFound,
+		Code:       "XMinioAdminTierNotFound",
+		Message:    "The tier does not exist",
+		Resource:   name,
+	}
+}
+
+// GetTierStats returns the usage of every tier.
+func (c *RustfsAdmin) GetTierStats(ctx context.Context) ([]TierStats, error) {
+	reqData := RequestData{
+		Method:  "GET",
+		RelPath: "tier-stats",
+	}
+	resp, err := c.doRequest(ctx, reqData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var stats []TierStats
+	err = json.NewDecoder(resp.Body).Decode(&stats)
+	return stats, err
+}
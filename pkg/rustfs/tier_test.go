@@ -1,6 +1,7 @@
 package rustfs
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -28,7 +29,7 @@ func TestAddTier(t *testing.T) {
 	client.accessSecret = "secret"
 
 	config := json.RawMessage(`{"tier_type":"s3","s3":{"name":"MYTIER"}}`)
-	err := client.AddTier(config)
+	err := client.AddTier(context.Background(), config)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -49,8 +50,70 @@ func TestRemoveTier(t *testing.T) {
 	})
 	client.accessSecret = "secret"
 
-	err := client.RemoveTier("MYTIER")
+	err := client.RemoveTier(context.Background(), "MYTIER")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestListTiers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/rustfs/admin/v3/tier" {
+			t.Errorf("expected GET tier, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`[{"version":"v1","tier_type":"s3","s3":{"name":"COLD","endpoint":"https://s3.amazonaws.com","access_key":"AKIA","secret_key":"REDACTED","bucket":"archive","prefix":"tier/","region":"us-east-1"}},` +
+			`{"type":"azure","azure":{"name":"AZURE","account_name":"account","account_key":"REDACTED","bucket":"container"}}]`))
+	}))
+	defer server.Close()
+
+	client := New(&RustfsAdminConfig{
+		Endpoint:  server.Listener.Addr().String(),
+		AccessKey: "admin",
+	})
+	client.accessSecret = "secret"
+
+	tiers, err := client.ListTiers(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []TierConfig{
+		{Name: "COLD", Type: "s3", Endpoint: "https://s3.amazonaws.com", Bucket: "archive", Prefix: "tier/", Region: "us-east-1", AccessKey: "AKIA"},
+		{Name: "AZURE", Type: "azure", Bucket: "container", AccessKey: "account"},
+	}
+	if len(tiers) != len(expected) {
+		t.Fatalf("expected %d tiers, got %+v", len(expected), tiers)
+	}
+	for i := range expected {
+		if tiers[i] != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], tiers[i])
+		}
+	}
+
+	if _, err := client.GetTier(context.Background(), "MISSING"); !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestGetTierStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/rustfs/admin/v3/tier-stats" {
+			t.Errorf("expected GET tier-stats, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`[{"name":"COLD","type":"s3","stats":{"total_size":2048,"num_versions":3,"num_objects":2}}]`))
+	}))
+	defer server.Close()
+
+	client := New(&RustfsAdminConfig{
+		Endpoint:  server.Listener.Addr().String(),
+		AccessKey: "admin",
+	})
+	client.accessSecret = "secret"
+
+	stats, err := client.GetTierStats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stats) != 1 || stats[0].Name != "COLD" || stats[0].Stats != (TierUsage{TotalSize: 2048, NumVersions: 3, NumObjects: 2}) {
+		t.Errorf("unexpected tier stats %+v", stats)
+	}
+}
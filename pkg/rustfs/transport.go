@@ -0,0 +1,90 @@
+package rustfs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// NewTransport builds the HTTP transport for the given configuration. The
+// provider hands the same transport to the admin client and to minio-go so
+// both APIs share one TLS setup.
+func NewTransport(config *RustfsAdminConfig) (*http.Transport, error) {
+	tr, err := minio.DefaultTransport(config.Ssl)
+	if err != nil {
+		return nil, err
+	}
+	if !config.Ssl {
+		return tr, nil
+	}
+
+	if err := applyTLSConfig(tr.TLSClientConfig, config); err != nil {
+		return nil, err
+	}
+	return tr, nil
+}
+
+// applyTLSConfig adds the user supplied TLS settings on top of the minio-go
+// defaults, which already honor SSL_CERT_FILE.
+func applyTLSConfig(tlsConfig *tls.Config, config *RustfsAdminConfig) error {
+	tlsConfig.InsecureSkipVerify = config.Insecure
+	tlsConfig.ServerName = config.TLSServerName
+
+	caPEM := []byte(config.CACertPEM)
+	if config.CACertFile != "" {
+		data, err := os.ReadFile(config.CACertFile)
+		if err != nil {
+			return fmt.Errorf("reading CA certificate: %w", err)
+		}
+		caPEM = append(caPEM, '\n')
+		caPEM = append(caPEM, data...)
+	}
+	if len(strings.TrimSpace(string(caPEM))) > 0 {
+		rootCAs := tlsConfig.RootCAs
+		if rootCAs == nil {
+			var err error
+			if rootCAs, err = x509.SystemCertPool(); err != nil {
+				rootCAs = x509.NewCertPool()
+			}
+		}
+		if !rootCAs.AppendCertsFromPEM(caPEM) {
+			return errors.New("no valid certificate found in CA bundle")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if config.ClientCert != "" || config.ClientKey != "" {
+		if config.ClientCert == "" || config.ClientKey == "" {
+			return errors.New("client certificate and client key must be set together")
+		}
+		certPEM, err := pemOrFile(config.ClientCert)
+		if err != nil {
+			return fmt.Errorf("reading client certificate: %w", err)
+		}
+		keyPEM, err := pemOrFile(config.ClientKey)
+		if err != nil {
+			return fmt.Errorf("reading client key: %w", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return nil
+}
+
+// pemOrFile returns value itself when it already holds PEM data, otherwise
+// it reads the file value points to.
+func pemOrFile(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
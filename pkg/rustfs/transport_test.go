@@ -0,0 +1,176 @@
+package rustfs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTLSTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func serverCAPEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func listPoolsWith(config RustfsAdminConfig) error {
+	config.AccessKey = "admin"
+	config.AccessSecret = "secret"
+	config.Ssl = true
+	client := New(&config)
+	_, err := client.ListPools(context.Background())
+	return err
+}
+
+func TestTransport_UnknownAuthority(t *testing.T) {
+	server := newTLSTestServer(t)
+	err := listPoolsWith(RustfsAdminConfig{Endpoint: server.Listener.Addr().String()})
+	if err == nil {
+		t.Fatal("expected certificate verification to fail")
+	}
+}
+
+func TestTransport_Insecure(t *testing.T) {
+	server := newTLSTestServer(t)
+	err := listPoolsWith(RustfsAdminConfig{Endpoint: server.Listener.Addr().String(), Insecure: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTransport_CACertPEM(t *testing.T) {
+	server := newTLSTestServer(t)
+	err := listPoolsWith(RustfsAdminConfig{
+		Endpoint:  server.Listener.Addr().String(),
+		CACertPEM: serverCAPEM(server),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTransport_CACertFile(t *testing.T) {
+	server := newTLSTestServer(t)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(serverCAPEM(server)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	err := listPoolsWith(RustfsAdminConfig{
+		Endpoint:   server.Listener.Addr().String(),
+		CACertFile: caFile,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = listPoolsWith(RustfsAdminConfig{
+		Endpoint:   server.Listener.Addr().String(),
+		CACertFile: filepath.Join(t.TempDir(), "missing.pem"),
+	})
+	if err == nil {
+		t.Fatal("expected error for missing CA file")
+	}
+}
+
+func TestTransport_ServerName(t *testing.T) {
+	server := newTLSTestServer(t)
+	// The httptest certificate is issued for example.com.
+	err := listPoolsWith(RustfsAdminConfig{
+		Endpoint:      server.Listener.Addr().String(),
+		CACertPEM:     serverCAPEM(server),
+		TLSServerName: "example.com",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = listPoolsWith(RustfsAdminConfig{
+		Endpoint:      server.Listener.Addr().String(),
+		CACertPEM:     serverCAPEM(server),
+		TLSServerName: "rustfs.invalid",
+	})
+	if err == nil {
+		t.Fatal("expected hostname verification to fail")
+	}
+}
+
+func TestTransport_ClientCertificate(t *testing.T) {
+	var presented int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented = len(r.TLS.PeerCertificates)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	// Reuse the server key pair as client certificate.
+	cert := server.TLS.Certificates[0]
+	key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}))
+	keyFile := filepath.Join(t.TempDir(), "client.key")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	err = listPoolsWith(RustfsAdminConfig{
+		Endpoint:   server.Listener.Addr().String(),
+		CACertPEM:  serverCAPEM(server),
+		ClientCert: certPEM,
+		ClientKey:  keyFile,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if presented != 1 {
+		t.Errorf("expected the client certificate to be presented, got %d certificates", presented)
+	}
+
+	err = listPoolsWith(RustfsAdminConfig{
+		Endpoint:   server.Listener.Addr().String(),
+		CACertPEM:  serverCAPEM(server),
+		ClientCert: certPEM,
+	})
+	if err == nil {
+		t.Fatal("expected error when the client key is missing")
+	}
+}
+
+func TestTransport_Shared(t *testing.T) {
+	server := newTLSTestServer(t)
+	config := &RustfsAdminConfig{
+		Endpoint:  server.Listener.Addr().String(),
+		Ssl:       true,
+		CACertPEM: serverCAPEM(server),
+	}
+	tr, err := NewTransport(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config.Transport = tr
+	config.CACertPEM = "not a certificate"
+
+	client := New(config)
+	if client.httpClient.Transport != tr {
+		t.Error("expected the client to use the supplied transport")
+	}
+	if _, err := client.ListPools(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -14,7 +14,7 @@ type UserAccount struct {
 	Groups    []string `json:"memberOf"`
 }
 
-func (c *RustfsAdmin) CreateUserAccount(user UserAccount) error {
+func (c *RustfsAdmin) CreateUserAccount(ctx context.Context, user UserAccount) error {
 
 	user.Status = "enabled"
 	urlValues := make(url.Values)
@@ -33,20 +33,18 @@ func (c *RustfsAdmin) CreateUserAccount(user UserAccount) error {
 		QueryValues: urlValues,
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	_, err = c.doRequest(ctx, req_data)
 	if err != nil {
 		return err
 	}
 
 	if user.Policy != "" {
-		return c.addUserToGroup(user.AccessKey, user.Policy)
+		return c.setUserOrGroupPolicy(ctx, user.AccessKey, false, user.Policy)
 	}
 	return err
 }
 
-func (c *RustfsAdmin) ReadUserAccount(name string) (UserAccount, error) {
+func (c *RustfsAdmin) ReadUserAccount(ctx context.Context, name string) (UserAccount, error) {
 	var instance UserAccount
 	urlValues := make(url.Values)
 	urlValues.Set("accessKey", name)
@@ -55,8 +53,6 @@ func (c *RustfsAdmin) ReadUserAccount(name string) (UserAccount, error) {
 		RelPath:     "user-info",
 		QueryValues: urlValues,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, req_data)
 	if err != nil {
 		return instance, err
@@ -67,7 +63,7 @@ func (c *RustfsAdmin) ReadUserAccount(name string) (UserAccount, error) {
 
 }
 
-func (c *RustfsAdmin) UpdateUserAccount(account UserAccount) error {
+func (c *RustfsAdmin) UpdateUserAccount(ctx context.Context, account UserAccount) error {
 	urlValues := make(url.Values)
 	urlValues.Set("accessKey", account.AccessKey)
 	urlValues.Set("status", account.Status)
@@ -76,19 +72,17 @@ func (c *RustfsAdmin) UpdateUserAccount(account UserAccount) error {
 		RelPath:     "user-info",
 		QueryValues: urlValues,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	_, err := c.doRequest(ctx, req_data)
 	if err != nil {
 		return err
 	}
 	if account.Policy != "" {
-		return c.addUserToGroup(account.AccessKey, account.Policy)
+		return c.setUserOrGroupPolicy(ctx, account.AccessKey, false, account.Policy)
 	}
 	return nil
 }
 
-func (c *RustfsAdmin) DeleteUserAccount(account UserAccount) error {
+func (c *RustfsAdmin) DeleteUserAccount(ctx context.Context, account UserAccount) error {
 	urlValues := make(url.Values)
 	urlValues.Set("accessKey", account.AccessKey)
 	req_data := RequestData{
@@ -96,27 +90,9 @@ func (c *RustfsAdmin) DeleteUserAccount(account UserAccount) error {
 		RelPath:     "remove-user",
 		QueryValues: urlValues,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	_, err := c.doRequest(ctx, req_data)
 	if err != nil {
 		return err
 	}
 	return err
 }
-
-func (c *RustfsAdmin) addUserToGroup(user string, group string) error {
-	urlValues := make(url.Values)
-	urlValues.Set("userOrGroup", user)
-	urlValues.Set("policyName", group)
-	urlValues.Set("isGroup", "false")
-	req_data := RequestData{
-		Method:      "PUT",
-		RelPath:     "set-user-or-group-policy",
-		QueryValues: urlValues,
-	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	_, err := c.doRequest(ctx, req_data)
-	return err
-}
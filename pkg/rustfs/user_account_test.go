@@ -1,6 +1,7 @@
 package rustfs_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
@@ -13,11 +14,11 @@ func TestCreateUserAccount(t *testing.T) {
 		SecretKey: randomString(8),
 	}
 	dut := getClient()
-	err := dut.CreateUserAccount(account)
+	err := dut.CreateUserAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
-	read, err := dut.ReadUserAccount(account.AccessKey)
+	read, err := dut.ReadUserAccount(context.Background(), account.AccessKey)
 	if err != nil {
 		t.Error(err)
 	}
@@ -25,7 +26,7 @@ func TestCreateUserAccount(t *testing.T) {
 		t.Error("wtf")
 	}
 
-	err = dut.DeleteUserAccount(account)
+	err = dut.DeleteUserAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
@@ -39,11 +40,11 @@ func TestCreateUserAccountWithGrp(t *testing.T) {
 		Policy:    "readwrite",
 	}
 	dut := getClient()
-	err := dut.CreateUserAccount(account)
+	err := dut.CreateUserAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
-	err = dut.DeleteUserAccount(account)
+	err = dut.DeleteUserAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
@@ -55,7 +56,7 @@ func TestAddUserWithAccesKey(t *testing.T) {
 		SecretKey: randomString(8),
 	}
 	dut := getClient()
-	err := dut.CreateUserAccount(account)
+	err := dut.CreateUserAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
@@ -66,16 +67,16 @@ func TestAddUserWithAccesKey(t *testing.T) {
 		Name:       randomString(8),
 		TargetUser: account.AccessKey,
 	}
-	err = dut.CreateServiceAccount(service)
+	err = dut.CreateServiceAccount(context.Background(), service)
 	if err != nil {
 		t.Error(err)
 	}
 
-	err = dut.DeleteServiceAccount(service)
+	err = dut.DeleteServiceAccount(context.Background(), service)
 	if err != nil {
 		t.Error(err)
 	}
-	err = dut.DeleteUserAccount(account)
+	err = dut.DeleteUserAccount(context.Background(), account)
 	if err != nil {
 		t.Error(err)
 	}
@@ -12,7 +12,7 @@ type UserInfo struct {
 	Policy    string `json:"policyName"`
 }
 
-func (c *RustfsAdmin) ListUsers(bucket string) ([]UserInfo, error) {
+func (c *RustfsAdmin) ListUsers(ctx context.Context, bucket string) ([]UserInfo, error) {
 	query := url.Values{}
 	if bucket != "" {
 		query.Set("bucket", bucket)
@@ -22,8 +22,6 @@ func (c *RustfsAdmin) ListUsers(bucket string) ([]UserInfo, error) {
 		RelPath:     "list-users",
 		QueryValues: query,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 	resp, err := c.doRequest(ctx, reqData)
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package rustfs
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -27,7 +28,7 @@ func TestListUsers(t *testing.T) {
 	})
 	client.accessSecret = "secret"
 
-	users, err := client.ListUsers("")
+	users, err := client.ListUsers(context.Background(), "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -59,7 +60,7 @@ func TestListUsersWithBucket(t *testing.T) {
 	})
 	client.accessSecret = "secret"
 
-	users, err := client.ListUsers("my-bucket")
+	users, err := client.ListUsers(context.Background(), "my-bucket")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -8,4 +8,8 @@ import (
 type AllClient struct {
 	Minio      *minio.Client
 	RustClient rustfs.RustfsAdmin
+	// Region is the provider wide default region for new buckets.
+	Region string
+	// DefaultTags are merged into the tags of every taggable resource.
+	DefaultTags map[string]string
 }
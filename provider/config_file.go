@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// aliasConfig is the subset of an mc style config.json the provider reads.
+type aliasConfig struct {
+	Aliases map[string]aliasEntry `json:"aliases"`
+	// Hosts is used by older mc releases instead of aliases.
+	Hosts map[string]aliasEntry `json:"hosts"`
+}
+
+type aliasEntry struct {
+	URL          string `json:"url"`
+	AccessKey    string `json:"accessKey"`
+	SecretKey    string `json:"secretKey"`
+	SessionToken string `json:"sessionToken"`
+}
+
+// resolvedAlias holds the connection settings of a single alias.
+type resolvedAlias struct {
+	Endpoint     string
+	Ssl          bool
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+}
+
+// defaultAliasConfigFile returns the config file of mc in the home directory.
+func defaultAliasConfigFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".mc", "config.json")
+}
+
+// loadAlias reads the alias with the given name from an mc style config file.
+// An empty file name selects ~/.mc/config.json.
+func loadAlias(file, alias string) (*resolvedAlias, error) {
+	if file == "" {
+		file = defaultAliasConfigFile()
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	var config aliasConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", file, err)
+	}
+	entry, ok := config.Aliases[alias]
+	if !ok {
+		entry, ok = config.Hosts[alias]
+	}
+	if !ok {
+		return nil, fmt.Errorf("alias %q not found in %s", alias, file)
+	}
+
+	u, err := url.Parse(entry.URL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("alias %q has an invalid url %q", alias, entry.URL)
+	}
+	return &resolvedAlias{
+		Endpoint:     u.Host,
+		Ssl:          u.Scheme == "https",
+		AccessKey:    entry.AccessKey,
+		SecretKey:    entry.SecretKey,
+		SessionToken: entry.SessionToken,
+	}, nil
+}
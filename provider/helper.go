@@ -1,11 +1,19 @@
 package provider
 
 import (
+	"fmt"
+	"net/http"
 	"os"
+	"time"
 
+	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
 )
 
+// defaultOperationTimeout bounds a single CRUD operation against the RustFS
+// admin API unless the resource configures its own timeouts block.
+const defaultOperationTimeout = 5 * time.Minute
+
 func envOrDefault(envKey, defaultValue string) string {
 	if v := os.Getenv(envKey); v != "" {
 		return v
@@ -13,13 +21,120 @@ func envOrDefault(envKey, defaultValue string) string {
 	return defaultValue
 }
 
-func generateRustClientConfig(model RustfsProviderModel) *rustfs.RustfsAdminConfig {
+// generateRustClientConfig resolves the client configuration. Endpoint and
+// credentials are taken from the first source that provides them:
+//
+//  1. RUSTFS_ENDPOINT, RUSTFS_USER, RUSTFS_SECRET and RUSTFS_SESSION_TOKEN
+//  2. the endpoint, access_key, access_secret and session_token attributes
+//  3. the alias selected by alias from config_file (mc config.json format)
+//  4. the profile from shared_credentials_file (credentials only), also read
+//     if only AWS_PROFILE or AWS_SHARED_CREDENTIALS_FILE is set
+//
+// ssl falls back to the scheme of the alias url when it is not set.
+func generateRustClientConfig(model RustfsProviderModel) (*rustfs.RustfsAdminConfig, error) {
 	config := &rustfs.RustfsAdminConfig{
-		Endpoint:     envOrDefault("RUSTFS_ENDPOINT", model.Endpoint.ValueString()),
-		AccessKey:    envOrDefault("RUSTFS_USER", model.AccessKey.ValueString()),
-		AccessSecret: envOrDefault("RUSTFS_SECRET", model.AccessSecret.ValueString()),
-		Ssl:          model.Ssl.ValueBool(),
-		Insecure:     model.Insecure.ValueBool(),
+		Endpoint:        envOrDefault("RUSTFS_ENDPOINT", model.Endpoint.ValueString()),
+		AccessKey:       envOrDefault("RUSTFS_USER", model.AccessKey.ValueString()),
+		AccessSecret:    envOrDefault("RUSTFS_SECRET", model.AccessSecret.ValueString()),
+		SessionToken:    envOrDefault("RUSTFS_SESSION_TOKEN", model.SessionToken.ValueString()),
+		Ssl:             model.Ssl.ValueBool(),
+		Insecure:        model.Insecure.ValueBool(),
+		Region:          envOrDefault("RUSTFS_REGION", model.Region.ValueString()),
+		CACertFile:      model.CACertFile.ValueString(),
+		CACertPEM:       model.CACertPEM.ValueString(),
+		ClientCert:      model.ClientCert.ValueString(),
+		ClientKey:       model.ClientKey.ValueString(),
+		TLSServerName:   model.TLSServerName.ValueString(),
+		MaxRetries:      rustfs.DefaultMaxRetries,
+		RetryMaxBackoff: rustfs.DefaultRetryMaxBackoff,
+	}
+
+	if alias := model.Alias.ValueString(); alias != "" {
+		resolved, err := loadAlias(model.ConfigFile.ValueString(), alias)
+		if err != nil {
+			return nil, err
+		}
+		if config.Endpoint == "" {
+			config.Endpoint = resolved.Endpoint
+			if model.Ssl.IsNull() {
+				config.Ssl = resolved.Ssl
+			}
+		}
+		if config.AccessKey == "" && config.AccessSecret == "" {
+			config.AccessKey = resolved.AccessKey
+			config.AccessSecret = resolved.SecretKey
+			if config.SessionToken == "" {
+				config.SessionToken = resolved.SessionToken
+			}
+		}
+	}
+
+	if config.AccessKey == "" && config.AccessSecret == "" && usesSharedCredentials(model) {
+		shared := &credentials.FileAWSCredentials{
+			Filename: model.SharedCredentialsFile.ValueString(),
+			Profile:  model.Profile.ValueString(),
+		}
+		value, err := shared.Retrieve()
+		if err != nil {
+			return nil, fmt.Errorf("reading shared credentials: %w", err)
+		}
+		config.AccessKey = value.AccessKeyID
+		config.AccessSecret = value.SecretAccessKey
+		if config.SessionToken == "" {
+			config.SessionToken = value.SessionToken
+		}
+	}
+
+	if config.Region == "" {
+		config.Region = rustfs.DefaultRegion
+	}
+	if !model.MaxRetries.IsNull() && !model.MaxRetries.IsUnknown() {
+		config.MaxRetries = int(model.MaxRetries.ValueInt64())
+	}
+	if v := model.RetryMaxBackoff.ValueString(); v != "" {
+		backoff, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry_max_backoff %q: %w", v, err)
+		}
+		if backoff <= 0 {
+			return nil, fmt.Errorf("invalid retry_max_backoff %q: must be positive", v)
+		}
+		config.RetryMaxBackoff = backoff
+	}
+	return config, nil
+}
+
+// usesSharedCredentials reports whether a profile or shared credentials
+// file is configured, either as attribute or in the AWS environment.
+func usesSharedCredentials(model RustfsProviderModel) bool {
+	return model.Profile.ValueString() != "" || model.SharedCredentialsFile.ValueString() != "" ||
+		os.Getenv("AWS_PROFILE") != "" || os.Getenv("AWS_SHARED_CREDENTIALS_FILE") != ""
+}
+
+// generateCredentials returns the credentials shared by the admin client and
+// minio-go. With an assume_role block the configured keys are only used to
+// request temporary credentials from the STS endpoint of the server.
+func generateCredentials(model RustfsProviderModel, config *rustfs.RustfsAdminConfig) *credentials.Credentials {
+	if model.AssumeRole == nil {
+		return credentials.NewStaticV4(config.AccessKey, config.AccessSecret, config.SessionToken)
+	}
+
+	scheme := "http"
+	if config.Ssl {
+		scheme = "https"
 	}
-	return config
+	return credentials.New(&credentials.STSAssumeRole{
+		Client:      &http.Client{Transport: config.Transport},
+		STSEndpoint: scheme + "://" + config.Endpoint,
+		Options: credentials.STSAssumeRoleOptions{
+			AccessKey:       config.AccessKey,
+			SecretKey:       config.AccessSecret,
+			SessionToken:    config.SessionToken,
+			Policy:          model.AssumeRole.Policy.ValueString(),
+			Location:        config.Region,
+			DurationSeconds: int(model.AssumeRole.DurationSeconds.ValueInt64()),
+			RoleARN:         model.AssumeRole.RoleArn.ValueString(),
+			RoleSessionName: model.AssumeRole.SessionName.ValueString(),
+		},
+	})
 }
@@ -0,0 +1,400 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs/rustfstest"
+)
+
+func TestGenerateRustClientConfig_RetryDefaults(t *testing.T) {
+	config, err := generateRustClientConfig(RustfsProviderModel{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.MaxRetries != rustfs.DefaultMaxRetries {
+		t.Errorf("expected %d retries, got %d", rustfs.DefaultMaxRetries, config.MaxRetries)
+	}
+	if config.RetryMaxBackoff != rustfs.DefaultRetryMaxBackoff {
+		t.Errorf("expected backoff %s, got %s", rustfs.DefaultRetryMaxBackoff, config.RetryMaxBackoff)
+	}
+}
+
+func TestGenerateRustClientConfig_Retry(t *testing.T) {
+	config, err := generateRustClientConfig(RustfsProviderModel{
+		MaxRetries:      types.Int64Value(0),
+		RetryMaxBackoff: types.StringValue("30s"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.MaxRetries != 0 {
+		t.Errorf("expected retries to be disabled, got %d", config.MaxRetries)
+	}
+	if config.RetryMaxBackoff != 30*time.Second {
+		t.Errorf("expected backoff 30s, got %s", config.RetryMaxBackoff)
+	}
+
+	for _, invalid := range []string{"ten seconds", "-1s", "0"} {
+		_, err := generateRustClientConfig(RustfsProviderModel{RetryMaxBackoff: types.StringValue(invalid)})
+		if err == nil {
+			t.Errorf("expected error for retry_max_backoff %q", invalid)
+		}
+	}
+}
+
+func TestGenerateRustClientConfig_TLS(t *testing.T) {
+	config, err := generateRustClientConfig(RustfsProviderModel{
+		Ssl:           types.BoolValue(true),
+		Insecure:      types.BoolValue(true),
+		CACertFile:    types.StringValue("/etc/rustfs/ca.pem"),
+		ClientCert:    types.StringValue("/etc/rustfs/client.pem"),
+		ClientKey:     types.StringValue("/etc/rustfs/client.key"),
+		TLSServerName: types.StringValue("rustfs.internal"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !config.Ssl || !config.Insecure {
+		t.Error("expected ssl and insecure to be set")
+	}
+	if config.CACertFile != "/etc/rustfs/ca.pem" || config.TLSServerName != "rustfs.internal" {
+		t.Errorf("unexpected TLS settings: %+v", config)
+	}
+	if config.ClientCert != "/etc/rustfs/client.pem" || config.ClientKey != "/etc/rustfs/client.key" {
+		t.Errorf("unexpected client certificate settings: %+v", config)
+	}
+}
+
+func TestGenerateRustClientConfig_Region(t *testing.T) {
+	t.Setenv("RUSTFS_REGION", "")
+	config, err := generateRustClientConfig(RustfsProviderModel{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Region != rustfs.DefaultRegion {
+		t.Errorf("expected default region, got %s", config.Region)
+	}
+
+	config, _ = generateRustClientConfig(RustfsProviderModel{Region: types.StringValue("eu-central-1")})
+	if config.Region != "eu-central-1" {
+		t.Errorf("expected configured region, got %s", config.Region)
+	}
+
+	t.Setenv("RUSTFS_REGION", "eu-west-1")
+	config, _ = generateRustClientConfig(RustfsProviderModel{})
+	if config.Region != "eu-west-1" {
+		t.Errorf("expected region from environment, got %s", config.Region)
+	}
+}
+
+func TestGenerateCredentials_SessionToken(t *testing.T) {
+	t.Setenv("RUSTFS_SESSION_TOKEN", "from-env")
+	model := RustfsProviderModel{
+		AccessKey:    types.StringValue("key"),
+		AccessSecret: types.StringValue("secret"),
+	}
+	config, err := generateRustClientConfig(model)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.SessionToken != "from-env" {
+		t.Errorf("expected session token from environment, got %q", config.SessionToken)
+	}
+
+	value, err := generateCredentials(model, config).Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value.SessionToken != "from-env" {
+		t.Errorf("expected static credentials to carry the session token, got %q", value.SessionToken)
+	}
+}
+
+func TestGenerateCredentials_AssumeRole(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.Form
+		w.Write([]byte(`<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><AssumeRoleResult><Credentials>
+<AccessKeyId>TEMPKEY</AccessKeyId><SecretAccessKey>TEMPSECRET</SecretAccessKey><SessionToken>TEMPTOKEN</SessionToken>
+<Expiration>` + time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `</Expiration>
+</Credentials></AssumeRoleResult></AssumeRoleResponse>`))
+	}))
+	defer server.Close()
+
+	model := RustfsProviderModel{
+		Endpoint:     types.StringValue(server.Listener.Addr().String()),
+		AccessKey:    types.StringValue("key"),
+		AccessSecret: types.StringValue("secret"),
+		AssumeRole: &assumeRoleModel{
+			SessionName:     types.StringValue("ci"),
+			DurationSeconds: types.Int64Value(7200),
+		},
+	}
+	t.Setenv("RUSTFS_ENDPOINT", "")
+	t.Setenv("RUSTFS_USER", "")
+	t.Setenv("RUSTFS_SECRET", "")
+	t.Setenv("RUSTFS_SESSION_TOKEN", "")
+	config, err := generateRustClientConfig(model)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	value, err := generateCredentials(model, config).Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value.AccessKeyID != "TEMPKEY" || value.SessionToken != "TEMPTOKEN" {
+		t.Errorf("expected temporary credentials, got %+v", value)
+	}
+	if form.Get("RoleSessionName") != "ci" || form.Get("DurationSeconds") != "7200" {
+		t.Errorf("unexpected AssumeRole request: %v", form)
+	}
+}
+
+// Temporary credentials of a CI job can assume a role, the session token
+// is sent with the AssumeRole request.
+func TestGenerateCredentials_AssumeRoleWithSessionToken(t *testing.T) {
+	server := rustfstest.NewServer(t)
+	temporary, err := credentials.New(&credentials.STSAssumeRole{
+		Client:      server.Client(),
+		STSEndpoint: server.URL,
+		Options: credentials.STSAssumeRoleOptions{
+			AccessKey: rustfstest.AccessKey,
+			SecretKey: rustfstest.SecretKey,
+		},
+	}).Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	model := RustfsProviderModel{
+		Endpoint:     types.StringValue(server.Endpoint()),
+		AccessKey:    types.StringValue(temporary.AccessKeyID),
+		AccessSecret: types.StringValue(temporary.SecretAccessKey),
+		SessionToken: types.StringValue(temporary.SessionToken),
+		AssumeRole:   &assumeRoleModel{SessionName: types.StringValue("ci")},
+	}
+	t.Setenv("RUSTFS_ENDPOINT", "")
+	t.Setenv("RUSTFS_USER", "")
+	t.Setenv("RUSTFS_SECRET", "")
+	t.Setenv("RUSTFS_SESSION_TOKEN", "")
+	config, err := generateRustClientConfig(model)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	value, err := generateCredentials(model, config).Get()
+	if err != nil {
+		t.Fatalf("expected the session token to authenticate the AssumeRole request, got %v", err)
+	}
+	if value.AccessKeyID == temporary.AccessKeyID || value.SessionToken == "" {
+		t.Errorf("expected new temporary credentials, got %+v", value)
+	}
+}
+
+func TestGenerateRustClientConfig_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(configFile, []byte(`{
+  "version": "10",
+  "aliases": {
+    "prod": {"url": "https://rustfs.example.com:9000", "accessKey": "alias-key", "secretKey": "alias-secret", "api": "s3v4"}
+  }
+}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	credentialsFile := filepath.Join(dir, "credentials")
+	if err := os.WriteFile(credentialsFile, []byte(`[default]
+aws_access_key_id = default-key
+aws_secret_access_key = default-secret
+
+[ci]
+aws_access_key_id = profile-key
+aws_secret_access_key = profile-secret
+aws_session_token = profile-token
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		model    RustfsProviderModel
+		endpoint string
+		ssl      bool
+		key      string
+		secret   string
+		token    string
+	}{
+		{
+			name: "environment wins over attributes",
+			env:  map[string]string{"RUSTFS_ENDPOINT": "env:9000", "RUSTFS_USER": "env-key", "RUSTFS_SECRET": "env-secret"},
+			model: RustfsProviderModel{
+				Endpoint:     types.StringValue("attr:9000"),
+				AccessKey:    types.StringValue("attr-key"),
+				AccessSecret: types.StringValue("attr-secret"),
+				Alias:        types.StringValue("prod"),
+				ConfigFile:   types.StringValue(configFile),
+			},
+			endpoint: "env:9000", key: "env-key", secret: "env-secret",
+		},
+		{
+			name: "attributes win over alias",
+			model: RustfsProviderModel{
+				Endpoint:     types.StringValue("attr:9000"),
+				AccessKey:    types.StringValue("attr-key"),
+				AccessSecret: types.StringValue("attr-secret"),
+				Alias:        types.StringValue("prod"),
+				ConfigFile:   types.StringValue(configFile),
+			},
+			endpoint: "attr:9000", key: "attr-key", secret: "attr-secret",
+		},
+		{
+			name: "alias",
+			model: RustfsProviderModel{
+				Alias:      types.StringValue("prod"),
+				ConfigFile: types.StringValue(configFile),
+			},
+			endpoint: "rustfs.example.com:9000", ssl: true, key: "alias-key", secret: "alias-secret",
+		},
+		{
+			name: "explicit ssl wins over alias scheme",
+			model: RustfsProviderModel{
+				Ssl:        types.BoolValue(false),
+				Alias:      types.StringValue("prod"),
+				ConfigFile: types.StringValue(configFile),
+			},
+			endpoint: "rustfs.example.com:9000", key: "alias-key", secret: "alias-secret",
+		},
+		{
+			name: "alias wins over profile",
+			model: RustfsProviderModel{
+				Alias:                 types.StringValue("prod"),
+				ConfigFile:            types.StringValue(configFile),
+				Profile:               types.StringValue("ci"),
+				SharedCredentialsFile: types.StringValue(credentialsFile),
+			},
+			endpoint: "rustfs.example.com:9000", ssl: true, key: "alias-key", secret: "alias-secret",
+		},
+		{
+			name: "profile",
+			model: RustfsProviderModel{
+				Endpoint:              types.StringValue("attr:9000"),
+				Profile:               types.StringValue("ci"),
+				SharedCredentialsFile: types.StringValue(credentialsFile),
+			},
+			endpoint: "attr:9000", key: "profile-key", secret: "profile-secret", token: "profile-token",
+		},
+		{
+			name: "default profile",
+			model: RustfsProviderModel{
+				Endpoint:              types.StringValue("attr:9000"),
+				SharedCredentialsFile: types.StringValue(credentialsFile),
+			},
+			endpoint: "attr:9000", key: "default-key", secret: "default-secret",
+		},
+		{
+			name: "profile and credentials file from environment",
+			env:  map[string]string{"AWS_PROFILE": "ci", "AWS_SHARED_CREDENTIALS_FILE": credentialsFile},
+			model: RustfsProviderModel{
+				Endpoint: types.StringValue("attr:9000"),
+			},
+			endpoint: "attr:9000", key: "profile-key", secret: "profile-secret", token: "profile-token",
+		},
+		{
+			name: "credentials file from environment",
+			env:  map[string]string{"AWS_SHARED_CREDENTIALS_FILE": credentialsFile},
+			model: RustfsProviderModel{
+				Endpoint: types.StringValue("attr:9000"),
+			},
+			endpoint: "attr:9000", key: "default-key", secret: "default-secret",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"RUSTFS_ENDPOINT", "RUSTFS_USER", "RUSTFS_SECRET", "RUSTFS_SESSION_TOKEN", "AWS_PROFILE", "AWS_SHARED_CREDENTIALS_FILE"} {
+				t.Setenv(key, tt.env[key])
+			}
+			config, err := generateRustClientConfig(tt.model)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if config.Endpoint != tt.endpoint || config.Ssl != tt.ssl {
+				t.Errorf("endpoint = %s (ssl %v), want %s (ssl %v)", config.Endpoint, config.Ssl, tt.endpoint, tt.ssl)
+			}
+			if config.AccessKey != tt.key || config.AccessSecret != tt.secret || config.SessionToken != tt.token {
+				t.Errorf("credentials = %s/%s/%s, want %s/%s/%s",
+					config.AccessKey, config.AccessSecret, config.SessionToken, tt.key, tt.secret, tt.token)
+			}
+		})
+	}
+}
+
+// AWS_PROFILE alone selects a profile of ~/.aws/credentials.
+func TestGenerateRustClientConfig_AwsProfileEnv(t *testing.T) {
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, ".aws"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".aws", "credentials"), []byte(`[ci]
+aws_access_key_id = profile-key
+aws_secret_access_key = profile-secret
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"RUSTFS_ENDPOINT", "RUSTFS_USER", "RUSTFS_SECRET", "RUSTFS_SESSION_TOKEN", "AWS_SHARED_CREDENTIALS_FILE"} {
+		t.Setenv(key, "")
+	}
+	t.Setenv("HOME", home)
+	t.Setenv("AWS_PROFILE", "ci")
+
+	config, err := generateRustClientConfig(RustfsProviderModel{Endpoint: types.StringValue("attr:9000")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.AccessKey != "profile-key" || config.AccessSecret != "profile-secret" {
+		t.Errorf("expected the credentials of AWS_PROFILE, got %s/%s", config.AccessKey, config.AccessSecret)
+	}
+}
+
+func TestGenerateRustClientConfig_AliasErrors(t *testing.T) {
+	t.Setenv("RUSTFS_ENDPOINT", "")
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configFile, []byte(`{"hosts": {"legacy": {"url": "http://localhost:9000"}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := generateRustClientConfig(RustfsProviderModel{
+		Alias:      types.StringValue("legacy"),
+		ConfigFile: types.StringValue(configFile),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error for legacy hosts section: %v", err)
+	}
+	if config.Endpoint != "localhost:9000" || config.Ssl {
+		t.Errorf("unexpected endpoint %s (ssl %v)", config.Endpoint, config.Ssl)
+	}
+
+	if _, err := generateRustClientConfig(RustfsProviderModel{
+		Alias:      types.StringValue("missing"),
+		ConfigFile: types.StringValue(configFile),
+	}); err == nil {
+		t.Error("expected error for unknown alias")
+	}
+	if _, err := generateRustClientConfig(RustfsProviderModel{
+		Alias:      types.StringValue("legacy"),
+		ConfigFile: types.StringValue(filepath.Join(t.TempDir(), "missing.json")),
+	}); err == nil {
+		t.Error("expected error for missing config file")
+	}
+}
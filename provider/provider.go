@@ -6,13 +6,16 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
 )
 
@@ -27,13 +30,43 @@ type RustfsProvider struct {
 	version string
 }
 
+// assumeRoleModel describes the assume_role block of the provider.
+type assumeRoleModel struct {
+	RoleArn         types.String `tfsdk:"role_arn"`
+	SessionName     types.String `tfsdk:"session_name"`
+	DurationSeconds types.Int64  `tfsdk:"duration_seconds"`
+	Policy          types.String `tfsdk:"policy"`
+}
+
+// defaultTagsModel describes the default_tags block of the provider.
+type defaultTagsModel struct {
+	Tags types.Map `tfsdk:"tags"`
+}
+
 // RustfsProviderModel describes the provider data model.
 type RustfsProviderModel struct {
-	Endpoint     types.String `tfsdk:"endpoint"`
-	AccessKey    types.String `tfsdk:"access_key"`
-	AccessSecret types.String `tfsdk:"access_secret"`
-	Ssl          types.Bool   `tfsdk:"ssl"`
-	Insecure     types.Bool   `tfsdk:"insecure"`
+	Endpoint              types.String     `tfsdk:"endpoint"`
+	AccessKey             types.String     `tfsdk:"access_key"`
+	AccessSecret          types.String     `tfsdk:"access_secret"`
+	Ssl                   types.Bool       `tfsdk:"ssl"`
+	Insecure              types.Bool       `tfsdk:"insecure"`
+	Region                types.String     `tfsdk:"region"`
+	SessionToken          types.String     `tfsdk:"session_token"`
+	Alias                 types.String     `tfsdk:"alias"`
+	ConfigFile            types.String     `tfsdk:"config_file"`
+	Profile               types.String     `tfsdk:"profile"`
+	SharedCredentialsFile types.String     `tfsdk:"shared_credentials_file"`
+	AssumeRole            *assumeRoleModel `tfsdk:"assume_role"`
+	CACertFile            types.String     `tfsdk:"ca_cert_file"`
+	CACertPEM             types.String     `tfsdk:"ca_cert_pem"`
+	ClientCert            types.String     `tfsdk:"client_cert"`
+	ClientKey             types.String     `tfsdk:"client_key"`
+	TLSServerName         types.String     `tfsdk:"tls_server_name"`
+	MaxRetries            types.Int64      `tfsdk:"max_retries"`
+	RetryMaxBackoff       types.String     `tfsdk:"retry_max_backoff"`
+
+	// Defaults for resources
+	DefaultTags *defaultTagsModel `tfsdk:"default_tags"`
 }
 
 func (p *RustfsProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -67,6 +100,105 @@ func (p *RustfsProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Description: "Use SSL transport",
 			},
+			"session_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Session token of temporary credentials. Defaults to RUSTFS_SESSION_TOKEN environment variable.",
+			},
+			"alias": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of an alias in config_file to read the endpoint, ssl mode and credentials from.",
+			},
+			"config_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to an mc style config.json holding aliases. Defaults to ~/.mc/config.json. The config.toml of the RustFS rc client is not supported.",
+			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "Profile in shared_credentials_file to read credentials from. Defaults to AWS_PROFILE environment variable or default.",
+			},
+			"shared_credentials_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to an AWS shared credentials file. Defaults to AWS_SHARED_CREDENTIALS_FILE environment variable or ~/.aws/credentials.",
+			},
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Description: "Region used to sign requests and to create buckets. Defaults to RUSTFS_REGION environment variable or us-east-1.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM encoded CA bundle used to verify the server certificate in addition to the system roots.",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded CA bundle used to verify the server certificate in addition to the system roots.",
+			},
+			"client_cert": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded client certificate, or a path to it, for mutual TLS. Requires client_key.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key of the client certificate, or a path to it. Requires client_cert.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"tls_server_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Host name used to verify the server certificate when it differs from the endpoint.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of times a failed request is retried. Set to 0 to disable retries. Defaults to 5.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Optional:    true,
+				Description: "Upper bound of the wait between two retries as Go duration, e.g. `30s`. Defaults to `10s`.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"assume_role": schema.SingleNestedBlock{
+				Description: "Exchange the configured credentials for temporary ones using STS AssumeRole. The temporary credentials are refreshed automatically before they expire.",
+				Attributes: map[string]schema.Attribute{
+					"role_arn": schema.StringAttribute{
+						Optional:    true,
+						Description: "ARN of the role to assume",
+					},
+					"session_name": schema.StringAttribute{
+						Optional:    true,
+						Description: "Name of the role session",
+					},
+					"duration_seconds": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Validity of the temporary credentials in seconds. Defaults to 3600.",
+						Validators: []validator.Int64{
+							int64validator.Between(900, 43200),
+						},
+					},
+					"policy": schema.StringAttribute{
+						Optional:    true,
+						Description: "JSON policy further restricting the permissions of the temporary credentials",
+					},
+				},
+			},
+			"default_tags": schema.SingleNestedBlock{
+				Description: "Tags applied to every taggable resource. Tags set on a resource override default tags with the same key.",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Default tags",
+					},
+				},
+			},
 		},
 	}
 }
@@ -80,32 +212,41 @@ func (p *RustfsProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	generatedConfig := generateRustClientConfig(config)
+	generatedConfig, err := generateRustClientConfig(config)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider configuration", err.Error())
+		return
+	}
 
-	endpoint := envOrDefault("RUSTFS_ENDPOINT", config.Endpoint.ValueString())
+	endpoint := generatedConfig.Endpoint
 	if endpoint == "" {
 		resp.Diagnostics.AddError(
 			"Missing RUSTFS endpoint",
-			"Set the endpoint in the provider block or via the RUSTFS_ENDPOINT environment variable.",
+			"Set the endpoint in the provider block, via the RUSTFS_ENDPOINT environment variable or through an alias.",
 		)
 		return
 	}
 
-	accessKey := envOrDefault("RUSTFS_USER", config.AccessKey.ValueString())
-	secretKey := envOrDefault("RUSTFS_SECRET", config.AccessSecret.ValueString())
-
-	// Example client configuration for data sources and resources
-	tr, err := minio.DefaultTransport(config.Ssl.ValueBool())
+	// The admin client and minio-go share one transport and thus the TLS setup.
+	tr, err := rustfs.NewTransport(generatedConfig)
 	if err != nil {
-		resp.Diagnostics.AddError(err.Error(), err.Error())
+		resp.Diagnostics.AddError("Invalid TLS configuration", err.Error())
 		return
 	}
-	usEast01 := "us-east-1"
+	generatedConfig.Transport = tr
+	generatedConfig.Credentials = generateCredentials(config, generatedConfig)
+
+	// minio-go only exposes its retry settings as package globals, so they
+	// apply to every client in this process. MaxRetry counts attempts, not
+	// retries.
+	minio.MaxRetry = generatedConfig.MaxRetries + 1
+	minio.DefaultRetryCap = generatedConfig.RetryMaxBackoff
+
 	minio_client, err := minio.New(endpoint, &minio.Options{
-		Secure:    config.Ssl.ValueBool(),
-		Creds:     credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure:    generatedConfig.Ssl,
+		Creds:     generatedConfig.Credentials,
 		Transport: tr,
-		Region:    usEast01,
+		Region:    generatedConfig.Region,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(err.Error(), err.Error())
@@ -114,6 +255,13 @@ func (p *RustfsProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	client := &AllClient{
 		Minio:      minio_client,
 		RustClient: rustfs.New(generatedConfig),
+		Region:     generatedConfig.Region,
+	}
+	if config.DefaultTags != nil {
+		resp.Diagnostics.Append(config.DefaultTags.Tags.ElementsAs(ctx, &client.DefaultTags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 	resp.DataSourceData = client
 	resp.ResourceData = client
@@ -137,6 +285,26 @@ func (p *RustfsProvider) Resources(ctx context.Context) []func() resource.Resour
 		NewBucketReplicationResource,
 		NewBucketEncryptionResource,
 		NewBucketVersioningResource,
+		NewBucketPolicyResource,
+		NewBucketTaggingResource,
+		NewBucketCorsResource,
+		NewBucketRemoteTargetResource,
+		NewNotifyWebhookResource,
+		NewNotifyKafkaResource,
+		NewNotifyNatsResource,
+		NewNotifyMqttResource,
+		NewNotifyRedisResource,
+		NewNotifyPostgresResource,
+		NewAuditWebhookResource,
+		NewAuditKafkaResource,
+		NewAuditNatsResource,
+		NewAuditMqttResource,
+		NewAuditRedisResource,
+		NewAuditPostgresResource,
+		NewObjectLegalHoldResource,
+		NewObjectRetentionResource,
+		NewIamUserPolicyAttachmentResource,
+		NewIamGroupPolicyAttachmentResource,
 	}
 }
 
@@ -146,6 +314,8 @@ func (p *RustfsProvider) DataSources(ctx context.Context) []func() datasource.Da
 		NewIamBackupDataSource,
 		NewBucketMetadataBackupDataSource,
 		NewUsersDataSource,
+		NewTiersDataSource,
+		NewIamPolicyDocumentDataSource,
 	}
 }
 
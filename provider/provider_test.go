@@ -4,11 +4,19 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs/rustfstest"
 )
 
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
@@ -42,3 +50,89 @@ func testAccProviderConfig() string {
 }
 `
 }
+
+// testProviderClient configures the provider against an in-process fake
+// server and returns the client handed to resources and data sources.
+// modify may fill in further provider attributes.
+func testProviderClient(t *testing.T, modify ...func(*RustfsProviderModel)) (*AllClient, *rustfstest.Server) {
+	t.Helper()
+	server := rustfstest.NewServer(t)
+	t.Setenv("RUSTFS_ENDPOINT", server.Endpoint())
+	t.Setenv("RUSTFS_USER", rustfstest.AccessKey)
+	t.Setenv("RUSTFS_SECRET", rustfstest.SecretKey)
+	t.Setenv("RUSTFS_SESSION_TOKEN", "")
+	t.Setenv("RUSTFS_REGION", "")
+
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(context.Background(), provider.SchemaRequest{}, schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: testNullObject(schemaResp.Schema.Type())}
+	var model RustfsProviderModel
+	state.Get(context.Background(), &model)
+	for _, m := range modify {
+		m(&model)
+	}
+	if diags := state.Set(context.Background(), &model); diags.HasError() {
+		t.Fatalf("set diagnostics: %v", diags)
+	}
+	config := tfsdk.Config(state)
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("configure diagnostics: %v", resp.Diagnostics)
+	}
+	return resp.ResourceData.(*AllClient), server
+}
+
+// testResourceState configures r with client and returns a state of r with
+// every attribute null. Tests read it into the resource model, fill in the
+// plan and set it again.
+func testResourceState(t *testing.T, r resource.Resource, client *AllClient) tfsdk.State {
+	t.Helper()
+	if rc, ok := r.(resource.ResourceWithConfigure); ok {
+		resp := &resource.ConfigureResponse{}
+		rc.Configure(context.Background(), resource.ConfigureRequest{ProviderData: client}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("configure diagnostics: %v", resp.Diagnostics)
+		}
+	}
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+	return tfsdk.State{Schema: resp.Schema, Raw: testNullObject(resp.Schema.Type())}
+}
+
+func testNullObject(typ attr.Type) tftypes.Value {
+	objType := typ.TerraformType(context.Background()).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+	for name, attrType := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	return tftypes.NewValue(objType, values)
+}
+
+func TestProviderConfigure_FakeServer(t *testing.T) {
+	client, _ := testProviderClient(t)
+
+	if client.Region != "us-east-1" {
+		t.Errorf("expected default region, got %q", client.Region)
+	}
+	admin, err := client.RustClient.IsAdmin(context.Background())
+	if err != nil || !admin {
+		t.Errorf("expected admin client to authenticate: %v", err)
+	}
+	if _, err := client.Minio.ListBuckets(context.Background()); err != nil {
+		t.Errorf("expected minio client to authenticate: %v", err)
+	}
+}
+
+func TestProviderConfigure_DefaultTags(t *testing.T) {
+	client, _ := testProviderClient(t, func(m *RustfsProviderModel) {
+		tags, _ := types.MapValueFrom(context.Background(), types.StringType, map[string]string{"owner": "platform"})
+		m.DefaultTags = &defaultTagsModel{Tags: tags}
+	})
+
+	if client.DefaultTags["owner"] != "platform" {
+		t.Errorf("expected default tags to be passed to resources, got %v", client.DefaultTags)
+	}
+}
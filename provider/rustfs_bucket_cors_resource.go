@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7/pkg/cors"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+var (
+	_ resource.Resource                = &BucketCorsResource{}
+	_ resource.ResourceWithImportState = &BucketCorsResource{}
+)
+
+type BucketCorsResource struct {
+	client *AllClient
+}
+
+type bucketCorsRuleModel struct {
+	Id             types.String `tfsdk:"id"`
+	AllowedMethods types.List   `tfsdk:"allowed_methods"`
+	AllowedOrigins types.List   `tfsdk:"allowed_origins"`
+	AllowedHeaders types.List   `tfsdk:"allowed_headers"`
+	ExposeHeaders  types.List   `tfsdk:"expose_headers"`
+	MaxAgeSeconds  types.Int64  `tfsdk:"max_age_seconds"`
+}
+
+type BucketCorsResourceModel struct {
+	Bucket   types.String          `tfsdk:"bucket"`
+	CorsRule []bucketCorsRuleModel `tfsdk:"cors_rule"`
+}
+
+func NewBucketCorsResource() resource.Resource {
+	return &BucketCorsResource{}
+}
+
+func (r *BucketCorsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_cors"
+}
+
+func (r *BucketCorsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Manage RustFS bucket CORS",
+		MarkdownDescription: "Manage the cross-origin resource sharing (CORS) configuration of a RustFS bucket",
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the bucket.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"cors_rule": schema.ListNestedBlock{
+				Description: "CORS rules of the bucket.",
+				Validators: []validator.List{
+					listvalidator.IsRequired(),
+					listvalidator.SizeAtMost(100),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Optional:    true,
+							Description: "Unique identifier for the rule.",
+						},
+						"allowed_methods": schema.ListAttribute{
+							ElementType: types.StringType,
+							Required:    true,
+							Description: "HTTP methods the origins may use: GET, PUT, HEAD, POST or DELETE.",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.ValueStringsAre(stringvalidator.OneOf("GET", "PUT", "HEAD", "POST", "DELETE")),
+							},
+						},
+						"allowed_origins": schema.ListAttribute{
+							ElementType: types.StringType,
+							Required:    true,
+							Description: "Origins allowed to access the bucket, e.g. https://example.com or *.",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+						"allowed_headers": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Headers allowed in a preflight request.",
+						},
+						"expose_headers": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Response headers the browser may access.",
+						},
+						"max_age_seconds": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Time in seconds the browser caches the preflight response.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *BucketCorsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*AllClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AllClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *BucketCorsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan BucketCorsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, diags := buildCorsConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.Minio.SetBucketCors(ctx, plan.Bucket.ValueString(), config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error setting bucket CORS",
+			"Could not set bucket CORS: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketCorsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state BucketCorsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// minio-go reports a missing CORS configuration as nil.
+	config, err := r.client.Minio.GetBucketCors(ctx, state.Bucket.ValueString())
+	if err != nil && !rustfs.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error reading bucket CORS",
+			"Could not read bucket CORS: "+err.Error(),
+		)
+		return
+	}
+	if err != nil || config == nil || len(config.CORSRules) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	rules, diags := flattenCorsRules(ctx, config.CORSRules)
+	resp.Diagnostics.Append(diags...)
+	state.CorsRule = rules
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BucketCorsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan BucketCorsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, diags := buildCorsConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.Minio.SetBucketCors(ctx, plan.Bucket.ValueString(), config)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating bucket CORS",
+			"Could not update bucket CORS: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketCorsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BucketCorsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A nil configuration removes the CORS configuration.
+	err := r.client.Minio.SetBucketCors(ctx, data.Bucket.ValueString(), nil)
+	if err != nil && !rustfs.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error removing bucket CORS",
+			"Could not remove bucket CORS: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *BucketCorsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("bucket"), req, resp)
+}
+
+func buildCorsConfig(ctx context.Context, plan BucketCorsResourceModel) (*cors.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	rules := make([]cors.Rule, 0, len(plan.CorsRule))
+	for _, r := range plan.CorsRule {
+		rule := cors.Rule{
+			ID:            r.Id.ValueString(),
+			MaxAgeSeconds: int(r.MaxAgeSeconds.ValueInt64()),
+		}
+		diags.Append(r.AllowedMethods.ElementsAs(ctx, &rule.AllowedMethod, false)...)
+		diags.Append(r.AllowedOrigins.ElementsAs(ctx, &rule.AllowedOrigin, false)...)
+		diags.Append(r.AllowedHeaders.ElementsAs(ctx, &rule.AllowedHeader, false)...)
+		diags.Append(r.ExposeHeaders.ElementsAs(ctx, &rule.ExposeHeader, false)...)
+		rules = append(rules, rule)
+	}
+	return cors.NewConfig(rules), diags
+}
+
+func flattenCorsRules(ctx context.Context, rules []cors.Rule) ([]bucketCorsRuleModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	// Optional values the server leaves out stay null to match the config.
+	list := func(values []string) types.List {
+		if len(values) == 0 {
+			return types.ListNull(types.StringType)
+		}
+		value, d := types.ListValueFrom(ctx, types.StringType, values)
+		diags.Append(d...)
+		return value
+	}
+
+	models := make([]bucketCorsRuleModel, 0, len(rules))
+	for _, rule := range rules {
+		m := bucketCorsRuleModel{
+			Id:             types.StringNull(),
+			AllowedMethods: list(rule.AllowedMethod),
+			AllowedOrigins: list(rule.AllowedOrigin),
+			AllowedHeaders: list(rule.AllowedHeader),
+			ExposeHeaders:  list(rule.ExposeHeader),
+			MaxAgeSeconds:  types.Int64Null(),
+		}
+		if rule.ID != "" {
+			m.Id = types.StringValue(rule.ID)
+		}
+		if rule.MaxAgeSeconds != 0 {
+			m.MaxAgeSeconds = types.Int64Value(int64(rule.MaxAgeSeconds))
+		}
+		models = append(models, m)
+	}
+	return models, diags
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
+)
+
+func testCorsRules(t *testing.T) []bucketCorsRuleModel {
+	t.Helper()
+	list := func(values ...string) types.List {
+		l, diags := types.ListValueFrom(context.Background(), types.StringType, values)
+		if diags.HasError() {
+			t.Fatalf("list diagnostics: %v", diags)
+		}
+		return l
+	}
+	return []bucketCorsRuleModel{
+		{
+			Id:             types.StringValue("web"),
+			AllowedMethods: list("GET", "HEAD"),
+			AllowedOrigins: list("https://example.com"),
+			AllowedHeaders: list("*"),
+			ExposeHeaders:  list("ETag"),
+			MaxAgeSeconds:  types.Int64Value(3000),
+		},
+		{
+			Id:             types.StringNull(),
+			AllowedMethods: list("PUT"),
+			AllowedOrigins: list("*"),
+			AllowedHeaders: types.ListNull(types.StringType),
+			ExposeHeaders:  types.ListNull(types.StringType),
+			MaxAgeSeconds:  types.Int64Null(),
+		},
+	}
+}
+
+func TestBucketCorsResourceSchema(t *testing.T) {
+	r := NewBucketCorsResource()
+	resp := &resource.SchemaResponse{}
+	r.Schema(nil, resource.SchemaRequest{}, resp)
+
+	if diags := resp.Diagnostics; diags.HasError() {
+		t.Fatalf("schema diagnostics: %v", diags)
+	}
+
+	if _, ok := resp.Schema.GetAttributes()["bucket"]; !ok {
+		t.Error("expected bucket attribute")
+	}
+	if _, ok := resp.Schema.GetBlocks()["cors_rule"]; !ok {
+		t.Error("expected cors_rule block")
+	}
+}
+
+func TestBucketCorsResourceMetadata(t *testing.T) {
+	r := NewBucketCorsResource()
+	resp := &resource.MetadataResponse{}
+	r.Metadata(nil, resource.MetadataRequest{ProviderTypeName: "rustfs"}, resp)
+
+	if resp.TypeName != "rustfs_bucket_cors" {
+		t.Errorf("expected rustfs_bucket_cors, got %s", resp.TypeName)
+	}
+}
+
+func TestBuildCorsConfig_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	rules := testCorsRules(t)
+	config, diags := buildCorsConfig(ctx, BucketCorsResourceModel{CorsRule: rules})
+	if diags.HasError() {
+		t.Fatalf("build diagnostics: %v", diags)
+	}
+	if len(config.CORSRules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(config.CORSRules))
+	}
+	if config.CORSRules[0].MaxAgeSeconds != 3000 || config.CORSRules[0].ID != "web" {
+		t.Errorf("unexpected first rule %+v", config.CORSRules[0])
+	}
+
+	flattened, diags := flattenCorsRules(ctx, config.CORSRules)
+	if diags.HasError() {
+		t.Fatalf("flatten diagnostics: %v", diags)
+	}
+	if !reflect.DeepEqual(flattened, rules) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", flattened, rules)
+	}
+}
+
+func TestBucketCorsResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	if err := client.Minio.MakeBucket(ctx, "web", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewBucketCorsResource()
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &BucketCorsResourceModel{
+		Bucket:   types.StringValue("web"),
+		CorsRule: testCorsRules(t),
+	})
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	// Import only knows the bucket name, Read fills in the rules.
+	importResp := &resource.ImportStateResponse{State: testResourceState(t, r, client)}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "web"}, importResp)
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var imported BucketCorsResourceModel
+	readResp.State.Get(ctx, &imported)
+	if !reflect.DeepEqual(imported.CorsRule, testCorsRules(t)) {
+		t.Errorf("unexpected imported rules %+v", imported.CorsRule)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected removed CORS configuration to be removed from state")
+	}
+}
@@ -3,9 +3,17 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
+	"maps"
+	"regexp"
+	"slices"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -32,33 +40,81 @@ type bucketLifecycleConfigurationRessource struct {
 }
 
 type bucketLifecycleConfigurationModel struct {
-	Bucket types.String `tfsdk:"bucket"`
-	Id     types.String `tfsdk:"id"`
-	Rule   []ruleModel  `tfsdk:"rule"`
+	Bucket   types.String   `tfsdk:"bucket"`
+	Id       types.String   `tfsdk:"id"`
+	Rule     []ruleModel    `tfsdk:"rule"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type ruleModel struct {
-	Id         types.String     `tfsdk:"id"`
-	Status     types.String     `tfsdk:"status"`
-	Filter     *filterModel     `tfsdk:"filter"`
-	Expiration *expirationModel `tfsdk:"expiration"`
+	Id                             types.String                         `tfsdk:"id"`
+	Status                         types.String                         `tfsdk:"status"`
+	Filter                         *filterModel                         `tfsdk:"filter"`
+	Expiration                     *expirationModel                     `tfsdk:"expiration"`
+	Transition                     []transitionModel                    `tfsdk:"transition"`
+	NoncurrentVersionExpiration    *noncurrentVersionExpirationModel    `tfsdk:"noncurrent_version_expiration"`
+	NoncurrentVersionTransition    []noncurrentVersionTransitionModel   `tfsdk:"noncurrent_version_transition"`
+	AbortIncompleteMultipartUpload *abortIncompleteMultipartUploadModel `tfsdk:"abort_incomplete_multipart_upload"`
 }
 
 type filterModel struct {
-	Prefix types.String `tfsdk:"prefix"`
+	Prefix                types.String    `tfsdk:"prefix"`
+	ObjectSizeGreaterThan types.Int64     `tfsdk:"object_size_greater_than"`
+	ObjectSizeLessThan    types.Int64     `tfsdk:"object_size_less_than"`
+	Tag                   *filterTagModel `tfsdk:"tag"`
+	And                   *filterAndModel `tfsdk:"and"`
+}
+
+type filterTagModel struct {
+	Key   types.String `tfsdk:"key"`
+	Value types.String `tfsdk:"value"`
+}
+
+type filterAndModel struct {
+	Prefix                types.String `tfsdk:"prefix"`
+	Tags                  types.Map    `tfsdk:"tags"`
+	ObjectSizeGreaterThan types.Int64  `tfsdk:"object_size_greater_than"`
+	ObjectSizeLessThan    types.Int64  `tfsdk:"object_size_less_than"`
 }
 
 type expirationModel struct {
-	Days types.Int64 `tfsdk:"days"`
+	Days                      types.Int64  `tfsdk:"days"`
+	Date                      types.String `tfsdk:"date"`
+	ExpiredObjectDeleteMarker types.Bool   `tfsdk:"expired_object_delete_marker"`
+}
+
+type transitionModel struct {
+	Days         types.Int64  `tfsdk:"days"`
+	Date         types.String `tfsdk:"date"`
+	StorageClass types.String `tfsdk:"storage_class"`
+}
+
+type noncurrentVersionExpirationModel struct {
+	NoncurrentDays          types.Int64 `tfsdk:"noncurrent_days"`
+	NewerNoncurrentVersions types.Int64 `tfsdk:"newer_noncurrent_versions"`
+}
+
+type noncurrentVersionTransitionModel struct {
+	NoncurrentDays          types.Int64  `tfsdk:"noncurrent_days"`
+	NewerNoncurrentVersions types.Int64  `tfsdk:"newer_noncurrent_versions"`
+	StorageClass            types.String `tfsdk:"storage_class"`
+}
+
+type abortIncompleteMultipartUploadModel struct {
+	DaysAfterInitiation types.Int64 `tfsdk:"days_after_initiation"`
 }
 
+// lifecycleDatePattern matches the ISO 8601 dates at midnight UTC S3 accepts
+// for expiration and transition dates.
+var lifecycleDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T00:00:00(\.000)?Z$`)
+
 // Metadata returns the resource type name.
 func (r *bucketLifecycleConfigurationRessource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_bucket_lifecycle_configuration"
 }
 
 // Schema defines the schema for the resource.
-func (r *bucketLifecycleConfigurationRessource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *bucketLifecycleConfigurationRessource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description:         "Manage S3 bucket lifecycle configurations in rustfs",
 		MarkdownDescription: "Manage S3 bucket lifecycle configurations in rustfs",
@@ -79,6 +135,12 @@ func (r *bucketLifecycleConfigurationRessource) Schema(_ context.Context, _ reso
 			},
 		},
 		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 			"rule": schema.ListNestedBlock{
 				Description: "List of lifecycle rules",
 				NestedObject: schema.NestedBlockObject{
@@ -97,20 +159,195 @@ func (r *bucketLifecycleConfigurationRessource) Schema(_ context.Context, _ reso
 					},
 					Blocks: map[string]schema.Block{
 						"filter": schema.SingleNestedBlock{
-							Description: "Filter identifying one or more objects to which the rule applies",
+							Description: "Filter identifying one or more objects to which the rule applies. Only one of prefix, tag, object_size_greater_than, object_size_less_than and and may be set, an empty filter applies to all objects",
 							Attributes: map[string]schema.Attribute{
 								"prefix": schema.StringAttribute{
 									Optional:    true,
 									Description: "Object key prefix identifying one or more objects to which the rule applies",
+									Validators: []validator.String{
+										stringvalidator.ConflictsWith(filterPredicates("prefix")...),
+									},
+								},
+								"object_size_greater_than": schema.Int64Attribute{
+									Optional:    true,
+									Description: "Minimum object size in bytes to which the rule applies",
+									Validators: []validator.Int64{
+										int64validator.AtLeast(0),
+										int64validator.ConflictsWith(filterPredicates("object_size_greater_than")...),
+									},
+								},
+								"object_size_less_than": schema.Int64Attribute{
+									Optional:    true,
+									Description: "Maximum object size in bytes to which the rule applies",
+									Validators: []validator.Int64{
+										int64validator.AtLeast(1),
+										int64validator.ConflictsWith(filterPredicates("object_size_less_than")...),
+									},
+								},
+							},
+							Blocks: map[string]schema.Block{
+								"tag": schema.SingleNestedBlock{
+									Description: "Object tag identifying the objects to which the rule applies",
+									Validators: []validator.Object{
+										objectvalidator.ConflictsWith(filterPredicates("tag")...),
+									},
+									Attributes: map[string]schema.Attribute{
+										"key": schema.StringAttribute{
+											Required:    true,
+											Description: "Tag key",
+										},
+										"value": schema.StringAttribute{
+											Required:    true,
+											Description: "Tag value",
+										},
+									},
+								},
+								"and": schema.SingleNestedBlock{
+									Description: "Combination of predicates an object must all match",
+									Validators: []validator.Object{
+										objectvalidator.ConflictsWith(filterPredicates("and")...),
+									},
+									Attributes: map[string]schema.Attribute{
+										"prefix": schema.StringAttribute{
+											Optional:    true,
+											Description: "Object key prefix",
+										},
+										"tags": schema.MapAttribute{
+											ElementType: types.StringType,
+											Optional:    true,
+											Description: "Object tags which must all match",
+										},
+										"object_size_greater_than": schema.Int64Attribute{
+											Optional:    true,
+											Description: "Minimum object size in bytes",
+											Validators: []validator.Int64{
+												int64validator.AtLeast(0),
+											},
+										},
+										"object_size_less_than": schema.Int64Attribute{
+											Optional:    true,
+											Description: "Maximum object size in bytes",
+											Validators: []validator.Int64{
+												int64validator.AtLeast(1),
+											},
+										},
+									},
 								},
 							},
 						},
 						"expiration": schema.SingleNestedBlock{
-							Description: "Configuration block for object expiration",
+							Description: "Configuration block for object expiration. Only one of days, date and expired_object_delete_marker may be set",
 							Attributes: map[string]schema.Attribute{
 								"days": schema.Int64Attribute{
 									Optional:    true,
 									Description: "Lifetime of the objects in days",
+									Validators: []validator.Int64{
+										int64validator.AtLeast(1),
+										int64validator.ConflictsWith(
+											path.MatchRelative().AtParent().AtName("date"),
+											path.MatchRelative().AtParent().AtName("expired_object_delete_marker"),
+										),
+									},
+								},
+								"date": schema.StringAttribute{
+									Optional:    true,
+									Description: "Date the objects expire, at midnight UTC, e.g. 2030-01-01T00:00:00Z",
+									Validators: []validator.String{
+										stringvalidator.RegexMatches(lifecycleDatePattern, "must be a date at midnight UTC, e.g. 2030-01-01T00:00:00Z"),
+										stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("expired_object_delete_marker")),
+									},
+								},
+								"expired_object_delete_marker": schema.BoolAttribute{
+									Optional:    true,
+									Description: "Remove delete markers without noncurrent versions",
+								},
+							},
+						},
+						"transition": schema.ListNestedBlock{
+							Description: "Transitions of current object versions to a tier",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"days": schema.Int64Attribute{
+										Optional:    true,
+										Description: "Days after object creation of the transition",
+										Validators: []validator.Int64{
+											int64validator.AtLeast(0),
+											int64validator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("date")),
+										},
+									},
+									"date": schema.StringAttribute{
+										Optional:    true,
+										Description: "Date of the transition, at midnight UTC, e.g. 2030-01-01T00:00:00Z",
+										Validators: []validator.String{
+											stringvalidator.RegexMatches(lifecycleDatePattern, "must be a date at midnight UTC, e.g. 2030-01-01T00:00:00Z"),
+										},
+									},
+									"storage_class": schema.StringAttribute{
+										Required:    true,
+										Description: "Name of the tier the objects are moved to, see rustfs_tier",
+										Validators: []validator.String{
+											stringvalidator.LengthAtLeast(1),
+										},
+									},
+								},
+							},
+						},
+						"noncurrent_version_expiration": schema.SingleNestedBlock{
+							Description: "Configuration block for the expiration of noncurrent object versions",
+							Attributes: map[string]schema.Attribute{
+								"noncurrent_days": schema.Int64Attribute{
+									Required:    true,
+									Description: "Days after which noncurrent versions expire",
+									Validators: []validator.Int64{
+										int64validator.AtLeast(1),
+									},
+								},
+								"newer_noncurrent_versions": schema.Int64Attribute{
+									Optional:    true,
+									Description: "Number of newer noncurrent versions to retain",
+									Validators: []validator.Int64{
+										int64validator.AtLeast(1),
+									},
+								},
+							},
+						},
+						"noncurrent_version_transition": schema.ListNestedBlock{
+							Description: "Transitions of noncurrent object versions to a tier",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"noncurrent_days": schema.Int64Attribute{
+										Required:    true,
+										Description: "Days after which noncurrent versions are moved",
+										Validators: []validator.Int64{
+											int64validator.AtLeast(0),
+										},
+									},
+									"newer_noncurrent_versions": schema.Int64Attribute{
+										Optional:    true,
+										Description: "Number of newer noncurrent versions to keep in place",
+										Validators: []validator.Int64{
+											int64validator.AtLeast(1),
+										},
+									},
+									"storage_class": schema.StringAttribute{
+										Required:    true,
+										Description: "Name of the tier the objects are moved to, see rustfs_tier",
+										Validators: []validator.String{
+											stringvalidator.LengthAtLeast(1),
+										},
+									},
+								},
+							},
+						},
+						"abort_incomplete_multipart_upload": schema.SingleNestedBlock{
+							Description: "Configuration block for aborting incomplete multipart uploads",
+							Attributes: map[string]schema.Attribute{
+								"days_after_initiation": schema.Int64Attribute{
+									Required:    true,
+									Description: "Days after the upload started after which it is aborted",
+									Validators: []validator.Int64{
+										int64validator.AtLeast(1),
+									},
 								},
 							},
 						},
@@ -146,34 +383,24 @@ func (r *bucketLifecycleConfigurationRessource) Create(ctx context.Context, req
 		return
 	}
 
-	var rules []rustfs.LifecycleRule
-	for _, rulePlan := range plan.Rule {
-		rule := rustfs.LifecycleRule{
-			ID:     rulePlan.Id.ValueString(),
-			Status: rulePlan.Status.ValueString(),
-		}
-
-		if rulePlan.Filter != nil {
-			rule.Filter = rustfs.LifecycleFilter{
-				Prefix: rulePlan.Filter.Prefix.ValueString(),
-			}
-		}
-
-		if rulePlan.Expiration != nil {
-			daysVal := int(rulePlan.Expiration.Days.ValueInt64())
-			rule.Expiration = &rustfs.LifecycleExpiration{
-				Days: &daysVal,
-			}
-		}
-
-		rules = append(rules, rule)
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
+	rules, diags := expandLifecycleRules(ctx, plan.Rule)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	config := &rustfs.LifecycleConfiguration{
 		Rules: rules,
 	}
 
-	err := r.client.RustClient.SetBucketLifecycleConfiguration(plan.Bucket.ValueString(), config)
+	err := r.client.RustClient.SetBucketLifecycleConfiguration(ctx, plan.Bucket.ValueString(), config)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating bucket lifecycle configuration",
@@ -197,11 +424,17 @@ func (r *bucketLifecycleConfigurationRessource) Read(ctx context.Context, req re
 		return
 	}
 
-	config, err := r.client.RustClient.GetBucketLifecycleConfiguration(state.Bucket.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	config, err := r.client.RustClient.GetBucketLifecycleConfiguration(ctx, state.Bucket.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "NoSuchLifecycleConfiguration") ||
-			strings.Contains(err.Error(), "NoSuchBucket") ||
-			strings.Contains(err.Error(), "404") {
+		if rustfs.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -212,27 +445,7 @@ func (r *bucketLifecycleConfigurationRessource) Read(ctx context.Context, req re
 		return
 	}
 
-	state.Rule = []ruleModel{}
-	for _, ruleAPI := range config.Rules {
-		rm := ruleModel{
-			Id:     types.StringValue(ruleAPI.ID),
-			Status: types.StringValue(ruleAPI.Status),
-		}
-
-		if ruleAPI.Filter.Prefix != "" {
-			rm.Filter = &filterModel{
-				Prefix: types.StringValue(ruleAPI.Filter.Prefix),
-			}
-		}
-
-		if ruleAPI.Expiration != nil && *ruleAPI.Expiration.Days != 0 {
-			rm.Expiration = &expirationModel{
-				Days: types.Int64Value(int64(*ruleAPI.Expiration.Days)),
-			}
-		}
-
-		state.Rule = append(state.Rule, rm)
-	}
+	state.Rule = flattenLifecycleRules(config.Rules, state.Rule)
 
 	state.Id = types.StringValue(state.Bucket.ValueString())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -247,34 +460,24 @@ func (r *bucketLifecycleConfigurationRessource) Update(ctx context.Context, req
 		return
 	}
 
-	var rules []rustfs.LifecycleRule
-	for _, rulePlan := range plan.Rule {
-		rule := rustfs.LifecycleRule{
-			ID:     rulePlan.Id.ValueString(),
-			Status: rulePlan.Status.ValueString(),
-		}
-
-		if rulePlan.Filter != nil {
-			rule.Filter = rustfs.LifecycleFilter{
-				Prefix: rulePlan.Filter.Prefix.ValueString(),
-			}
-		}
-
-		if rulePlan.Expiration != nil {
-			daysVal := int(*rulePlan.Expiration.Days.ValueInt64Pointer())
-			rule.Expiration = &rustfs.LifecycleExpiration{
-				Days: &daysVal,
-			}
-		}
-
-		rules = append(rules, rule)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
+	rules, diags := expandLifecycleRules(ctx, plan.Rule)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	config := &rustfs.LifecycleConfiguration{
 		Rules: rules,
 	}
 
-	err := r.client.RustClient.SetBucketLifecycleConfiguration(plan.Bucket.ValueString(), config)
+	err := r.client.RustClient.SetBucketLifecycleConfiguration(ctx, plan.Bucket.ValueString(), config)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating bucket lifecycle configuration",
@@ -295,11 +498,17 @@ func (r *bucketLifecycleConfigurationRessource) Delete(ctx context.Context, req
 		return
 	}
 
-	err := r.client.RustClient.DeleteBucketLifecycleConfiguration(data.Bucket.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.RustClient.DeleteBucketLifecycleConfiguration(ctx, data.Bucket.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "NoSuchLifecycleConfiguration") ||
-			strings.Contains(err.Error(), "NoSuchBucket") ||
-			strings.Contains(err.Error(), "404") {
+		if rustfs.IsNotFound(err) {
 			// Already deleted
 			return
 		}
@@ -310,3 +519,212 @@ func (r *bucketLifecycleConfigurationRessource) Delete(ctx context.Context, req
 		return
 	}
 }
+
+// filterPredicates returns the paths of the filter predicates other than
+// name, relative to a predicate in the filter block.
+func filterPredicates(name string) []path.Expression {
+	var expressions []path.Expression
+	for _, predicate := range []string{"prefix", "tag", "object_size_greater_than", "object_size_less_than", "and"} {
+		if predicate != name {
+			expressions = append(expressions, path.MatchRelative().AtParent().AtName(predicate))
+		}
+	}
+	return expressions
+}
+
+func intPointer(value types.Int64) *int {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	i := int(value.ValueInt64())
+	return &i
+}
+
+func expandLifecycleRules(ctx context.Context, plan []ruleModel) ([]rustfs.LifecycleRule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var rules []rustfs.LifecycleRule
+	for _, rulePlan := range plan {
+		rule := rustfs.LifecycleRule{
+			ID:     rulePlan.Id.ValueString(),
+			Status: rulePlan.Status.ValueString(),
+		}
+
+		if f := rulePlan.Filter; f != nil {
+			rule.Filter = &rustfs.LifecycleFilter{
+				Prefix:                f.Prefix.ValueString(),
+				ObjectSizeGreaterThan: f.ObjectSizeGreaterThan.ValueInt64(),
+				ObjectSizeLessThan:    f.ObjectSizeLessThan.ValueInt64(),
+			}
+			if f.Tag != nil {
+				rule.Filter.Tag = &rustfs.LifecycleTag{
+					Key:   f.Tag.Key.ValueString(),
+					Value: f.Tag.Value.ValueString(),
+				}
+			}
+			if f.And != nil {
+				var tags map[string]string
+				diags.Append(f.And.Tags.ElementsAs(ctx, &tags, false)...)
+				and := &rustfs.LifecycleAnd{
+					Prefix:                f.And.Prefix.ValueString(),
+					ObjectSizeGreaterThan: f.And.ObjectSizeGreaterThan.ValueInt64(),
+					ObjectSizeLessThan:    f.And.ObjectSizeLessThan.ValueInt64(),
+				}
+				for _, key := range slices.Sorted(maps.Keys(tags)) {
+					and.Tags = append(and.Tags, rustfs.LifecycleTag{Key: key, Value: tags[key]})
+				}
+				rule.Filter.And = and
+			}
+		}
+
+		if e := rulePlan.Expiration; e != nil {
+			rule.Expiration = &rustfs.LifecycleExpiration{
+				Days:                      intPointer(e.Days),
+				Date:                      e.Date.ValueString(),
+				ExpiredObjectDeleteMarker: e.ExpiredObjectDeleteMarker.ValueBoolPointer(),
+			}
+		}
+
+		for _, t := range rulePlan.Transition {
+			rule.Transitions = append(rule.Transitions, rustfs.LifecycleTransition{
+				Days:         intPointer(t.Days),
+				Date:         t.Date.ValueString(),
+				StorageClass: t.StorageClass.ValueString(),
+			})
+		}
+
+		if e := rulePlan.NoncurrentVersionExpiration; e != nil {
+			rule.NoncurrentVersionExpiration = &rustfs.NoncurrentVersionExpiration{
+				NoncurrentDays:          int(e.NoncurrentDays.ValueInt64()),
+				NewerNoncurrentVersions: int(e.NewerNoncurrentVersions.ValueInt64()),
+			}
+		}
+
+		for _, t := range rulePlan.NoncurrentVersionTransition {
+			rule.NoncurrentVersionTransitions = append(rule.NoncurrentVersionTransitions, rustfs.NoncurrentVersionTransition{
+				NoncurrentDays:          int(t.NoncurrentDays.ValueInt64()),
+				NewerNoncurrentVersions: int(t.NewerNoncurrentVersions.ValueInt64()),
+				StorageClass:            t.StorageClass.ValueString(),
+			})
+		}
+
+		if a := rulePlan.AbortIncompleteMultipartUpload; a != nil {
+			rule.AbortIncompleteMultipartUpload = &rustfs.AbortIncompleteMultipartUpload{
+				DaysAfterInitiation: int(a.DaysAfterInitiation.ValueInt64()),
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+	return rules, diags
+}
+
+func stringOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+func int64OrNull[T int | int64](value T) types.Int64 {
+	if value == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(value))
+}
+
+func intPointerValue(value *int) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*value))
+}
+
+// flattenLifecycleRules converts the rules read from the server. prior is
+// the state before, an empty filter block stays in place if it was
+// configured.
+func flattenLifecycleRules(rules []rustfs.LifecycleRule, prior []ruleModel) []ruleModel {
+	models := []ruleModel{}
+	for i, ruleAPI := range rules {
+		rm := ruleModel{
+			Id:     types.StringValue(ruleAPI.ID),
+			Status: types.StringValue(ruleAPI.Status),
+		}
+
+		var f rustfs.LifecycleFilter
+		if ruleAPI.Filter != nil {
+			f = *ruleAPI.Filter
+		}
+		if f.IsEmpty() && ruleAPI.Prefix != "" {
+			f.Prefix = ruleAPI.Prefix
+		}
+		if !f.IsEmpty() || (i < len(prior) && prior[i].Filter != nil) {
+			rm.Filter = &filterModel{
+				Prefix:                stringOrNull(f.Prefix),
+				ObjectSizeGreaterThan: int64OrNull(f.ObjectSizeGreaterThan),
+				ObjectSizeLessThan:    int64OrNull(f.ObjectSizeLessThan),
+			}
+			if f.Tag != nil {
+				rm.Filter.Tag = &filterTagModel{
+					Key:   types.StringValue(f.Tag.Key),
+					Value: types.StringValue(f.Tag.Value),
+				}
+			}
+			if f.And != nil {
+				tags := types.MapNull(types.StringType)
+				if len(f.And.Tags) > 0 {
+					elements := map[string]attr.Value{}
+					for _, tag := range f.And.Tags {
+						elements[tag.Key] = types.StringValue(tag.Value)
+					}
+					tags, _ = types.MapValue(types.StringType, elements)
+				}
+				rm.Filter.And = &filterAndModel{
+					Prefix:                stringOrNull(f.And.Prefix),
+					Tags:                  tags,
+					ObjectSizeGreaterThan: int64OrNull(f.And.ObjectSizeGreaterThan),
+					ObjectSizeLessThan:    int64OrNull(f.And.ObjectSizeLessThan),
+				}
+			}
+		}
+
+		if e := ruleAPI.Expiration; e != nil {
+			rm.Expiration = &expirationModel{
+				Days:                      intPointerValue(e.Days),
+				Date:                      stringOrNull(e.Date),
+				ExpiredObjectDeleteMarker: types.BoolPointerValue(e.ExpiredObjectDeleteMarker),
+			}
+		}
+
+		for _, t := range ruleAPI.Transitions {
+			rm.Transition = append(rm.Transition, transitionModel{
+				Days:         intPointerValue(t.Days),
+				Date:         stringOrNull(t.Date),
+				StorageClass: types.StringValue(t.StorageClass),
+			})
+		}
+
+		if e := ruleAPI.NoncurrentVersionExpiration; e != nil {
+			rm.NoncurrentVersionExpiration = &noncurrentVersionExpirationModel{
+				NoncurrentDays:          types.Int64Value(int64(e.NoncurrentDays)),
+				NewerNoncurrentVersions: int64OrNull(e.NewerNoncurrentVersions),
+			}
+		}
+
+		for _, t := range ruleAPI.NoncurrentVersionTransitions {
+			rm.NoncurrentVersionTransition = append(rm.NoncurrentVersionTransition, noncurrentVersionTransitionModel{
+				NoncurrentDays:          types.Int64Value(int64(t.NoncurrentDays)),
+				NewerNoncurrentVersions: int64OrNull(t.NewerNoncurrentVersions),
+				StorageClass:            types.StringValue(t.StorageClass),
+			})
+		}
+
+		if a := ruleAPI.AbortIncompleteMultipartUpload; a != nil {
+			rm.AbortIncompleteMultipartUpload = &abortIncompleteMultipartUploadModel{
+				DaysAfterInitiation: types.Int64Value(int64(a.DaysAfterInitiation)),
+			}
+		}
+
+		models = append(models, rm)
+	}
+	return models
+}
@@ -1,9 +1,20 @@
 package provider
 
 import (
+	"context"
+	"encoding/xml"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/minio/minio-go/v7"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
 )
 
 func TestAccBucketLifecycleConfigurationResource(t *testing.T) {
@@ -78,3 +89,200 @@ resource "rustfs_bucket_lifecycle_configuration" "test" {
 		},
 	})
 }
+
+func testLifecycleRules() []ruleModel {
+	tags, _ := types.MapValue(types.StringType, map[string]attr.Value{
+		"class": types.StringValue("cold"),
+		"team":  types.StringValue("ops"),
+	})
+	return []ruleModel{
+		{
+			Id:     types.StringValue("archive"),
+			Status: types.StringValue("Enabled"),
+			Filter: &filterModel{
+				Prefix:                types.StringNull(),
+				ObjectSizeGreaterThan: types.Int64Null(),
+				ObjectSizeLessThan:    types.Int64Null(),
+				And: &filterAndModel{
+					Prefix:                types.StringValue("logs/"),
+					Tags:                  tags,
+					ObjectSizeGreaterThan: types.Int64Value(1024),
+					ObjectSizeLessThan:    types.Int64Null(),
+				},
+			},
+			Transition: []transitionModel{{
+				Days:         types.Int64Value(30),
+				Date:         types.StringNull(),
+				StorageClass: types.StringValue("WARM"),
+			}},
+			NoncurrentVersionExpiration: &noncurrentVersionExpirationModel{
+				NoncurrentDays:          types.Int64Value(90),
+				NewerNoncurrentVersions: types.Int64Value(3),
+			},
+			NoncurrentVersionTransition: []noncurrentVersionTransitionModel{{
+				NoncurrentDays:          types.Int64Value(7),
+				NewerNoncurrentVersions: types.Int64Null(),
+				StorageClass:            types.StringValue("WARM"),
+			}},
+			AbortIncompleteMultipartUpload: &abortIncompleteMultipartUploadModel{
+				DaysAfterInitiation: types.Int64Value(2),
+			},
+		},
+		{
+			Id:     types.StringValue("markers"),
+			Status: types.StringValue("Disabled"),
+			Filter: &filterModel{
+				Prefix:                types.StringNull(),
+				ObjectSizeGreaterThan: types.Int64Null(),
+				ObjectSizeLessThan:    types.Int64Null(),
+				Tag: &filterTagModel{
+					Key:   types.StringValue("class"),
+					Value: types.StringValue("tmp"),
+				},
+			},
+			Expiration: &expirationModel{
+				Days:                      types.Int64Null(),
+				Date:                      types.StringNull(),
+				ExpiredObjectDeleteMarker: types.BoolValue(true),
+			},
+		},
+		{
+			// An empty filter applies the rule to all objects.
+			Id:     types.StringValue("dated"),
+			Status: types.StringValue("Enabled"),
+			Filter: &filterModel{
+				Prefix:                types.StringNull(),
+				ObjectSizeGreaterThan: types.Int64Null(),
+				ObjectSizeLessThan:    types.Int64Null(),
+			},
+			Expiration: &expirationModel{
+				Days:                      types.Int64Null(),
+				Date:                      types.StringValue("2030-01-01T00:00:00Z"),
+				ExpiredObjectDeleteMarker: types.BoolNull(),
+			},
+		},
+	}
+}
+
+func TestBucketLifecycleConfigurationSchema(t *testing.T) {
+	r := NewBucketLifecycleConfigurationRessource()
+	resp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("schema diagnostics: %v", resp.Diagnostics)
+	}
+	if diags := resp.Schema.ValidateImplementation(context.Background()); diags.HasError() {
+		t.Fatalf("schema implementation diagnostics: %v", diags)
+	}
+	rule := resp.Schema.Blocks["rule"].GetNestedObject()
+	for _, block := range []string{"filter", "expiration", "transition", "noncurrent_version_expiration", "noncurrent_version_transition", "abort_incomplete_multipart_upload"} {
+		if _, ok := rule.GetBlocks()[block]; !ok {
+			t.Errorf("expected %s block", block)
+		}
+	}
+}
+
+func TestLifecycleDatePattern(t *testing.T) {
+	for date, valid := range map[string]bool{
+		"2030-01-01T00:00:00Z":     true,
+		"2030-01-01T00:00:00.000Z": true,
+		"2030-01-01T12:00:00Z":     false,
+		"2030-01-01":               false,
+	} {
+		if lifecycleDatePattern.MatchString(date) != valid {
+			t.Errorf("expected %s valid=%v", date, valid)
+		}
+	}
+}
+
+func TestLifecycleRulesRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	rules, diags := expandLifecycleRules(ctx, testLifecycleRules())
+	if diags.HasError() {
+		t.Fatalf("expand diagnostics: %v", diags)
+	}
+	and := rules[0].Filter.And
+	if and == nil || len(and.Tags) != 2 || and.Tags[0].Key != "class" {
+		t.Errorf("expected sorted And tags, got %+v", and)
+	}
+	if rules[0].Expiration != nil {
+		t.Error("expected no expiration for the archive rule")
+	}
+
+	// The XML document must survive the trip to the server.
+	encoded, err := xml.Marshal(rustfs.LifecycleConfiguration{Rules: rules})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded rustfs.LifecycleConfiguration
+	if err := xml.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	flattened := flattenLifecycleRules(decoded.Rules, testLifecycleRules())
+	if !reflect.DeepEqual(flattened, testLifecycleRules()) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", flattened, testLifecycleRules())
+	}
+}
+
+func TestLifecycleRulesFlatten_LegacyPrefix(t *testing.T) {
+	days := 7
+	flattened := flattenLifecycleRules([]rustfs.LifecycleRule{{
+		ID:         "legacy",
+		Status:     "Enabled",
+		Prefix:     "tmp/",
+		Expiration: &rustfs.LifecycleExpiration{Days: &days},
+	}}, nil)
+	if flattened[0].Filter == nil || flattened[0].Filter.Prefix.ValueString() != "tmp/" {
+		t.Errorf("expected rule level prefix in filter, got %+v", flattened[0].Filter)
+	}
+	if flattened[0].Expiration.Days.ValueInt64() != 7 {
+		t.Errorf("unexpected expiration %+v", flattened[0].Expiration)
+	}
+}
+
+func TestBucketLifecycleConfigurationResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	if err := client.Minio.MakeBucket(ctx, "lifecycle", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewBucketLifecycleConfigurationRessource()
+	plan := testResourceState(t, r, client)
+	var timeoutsValue timeouts.Value
+	plan.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)
+	plan.Set(ctx, &bucketLifecycleConfigurationModel{
+		Bucket:   types.StringValue("lifecycle"),
+		Id:       types.StringUnknown(),
+		Rule:     testLifecycleRules(),
+		Timeouts: timeoutsValue,
+	})
+
+	createResp := &fwresource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var read bucketLifecycleConfigurationModel
+	readResp.State.Get(ctx, &read)
+	if !reflect.DeepEqual(read.Rule, testLifecycleRules()) {
+		t.Errorf("unexpected rules after read %+v", read.Rule)
+	}
+
+	deleteResp := &fwresource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	readResp = &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected removed lifecycle configuration to be removed from state")
+	}
+}
@@ -35,9 +35,9 @@ func (r *BucketMetadataBackupImportResource) Schema(_ context.Context, _ resourc
 		Description: "Import bucket metadata from a ZIP archive",
 		Attributes: map[string]schema.Attribute{
 			"content_base64": schema.StringAttribute{
-				Required:    true,
-				Sensitive:   true,
-				Description: "Base64-encoded ZIP archive with bucket metadata.",
+				Required:      true,
+				Sensitive:     true,
+				Description:   "Base64-encoded ZIP archive with bucket metadata.",
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
 		},
@@ -69,7 +69,7 @@ func (r *BucketMetadataBackupImportResource) Create(ctx context.Context, req res
 		return
 	}
 
-	if err := r.client.RustClient.ImportBucketMetadata(data); err != nil {
+	if err := r.client.RustClient.ImportBucketMetadata(ctx, data); err != nil {
 		resp.Diagnostics.AddError("Error importing bucket metadata", "Could not import: "+err.Error())
 		return
 	}
@@ -96,7 +96,7 @@ func (r *BucketMetadataBackupImportResource) Update(ctx context.Context, req res
 		return
 	}
 
-	if err := r.client.RustClient.ImportBucketMetadata(data); err != nil {
+	if err := r.client.RustClient.ImportBucketMetadata(ctx, data); err != nil {
 		resp.Diagnostics.AddError("Error importing bucket metadata", "Could not import: "+err.Error())
 		return
 	}
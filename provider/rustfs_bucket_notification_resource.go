@@ -2,27 +2,40 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/minio/minio-go/v7/pkg/notification"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
 )
 
 var (
 	_ resource.Resource                = &BucketNotificationResource{}
 	_ resource.ResourceWithImportState = &BucketNotificationResource{}
+	_ resource.ResourceWithModifyPlan  = &BucketNotificationResource{}
 )
 
 type BucketNotificationResource struct {
 	client *AllClient
 }
 
-type bucketNotificationQueueModel struct {
+// bucketNotificationConfigModel is one queue, topic or lambda entry.
+type bucketNotificationConfigModel struct {
+	Id           types.String `tfsdk:"id"`
 	Arn          types.String `tfsdk:"arn"`
 	Events       types.Set    `tfsdk:"events"`
 	FilterPrefix types.String `tfsdk:"filter_prefix"`
@@ -30,10 +43,17 @@ type bucketNotificationQueueModel struct {
 }
 
 type bucketNotificationResourceModel struct {
-	Bucket types.String                   `tfsdk:"bucket"`
-	Queue  []bucketNotificationQueueModel `tfsdk:"queue"`
+	Bucket types.String                    `tfsdk:"bucket"`
+	Queue  []bucketNotificationConfigModel `tfsdk:"queue"`
+	Topic  []bucketNotificationConfigModel `tfsdk:"topic"`
+	Lambda []bucketNotificationConfigModel `tfsdk:"lambda"`
 }
 
+// notificationArnPattern matches target ARNs such as
+// arn:rustfs:sqs::primary:webhook. The service is sqs for queues, sns for
+// topics and lambda for lambdas.
+var notificationArnPattern = regexp.MustCompile(`^arn:(rustfs|minio|aws):(sqs|sns|lambda):[^:]*:[^:]+:[^:]+$`)
+
 func NewBucketNotificationResource() resource.Resource {
 	return &BucketNotificationResource{}
 }
@@ -42,6 +62,48 @@ func (r *BucketNotificationResource) Metadata(_ context.Context, req resource.Me
 	resp.TypeName = req.ProviderTypeName + "_bucket_notification"
 }
 
+func notificationConfigAttribute(description, service, example string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Optional:    true,
+		Description: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Optional:      true,
+					Computed:      true,
+					Description:   "Identifier of the entry. Derived from the other attributes if not set.",
+					PlanModifiers: []planmodifier.String{notificationIDModifier{}},
+				},
+				"arn": schema.StringAttribute{
+					Required:    true,
+					Description: fmt.Sprintf("ARN of the target (e.g., %s). The target must exist on the server, it is matched by name and type.", example),
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(notificationArnPattern, "must be a notification target ARN, e.g. "+example),
+						stringvalidator.RegexMatches(regexp.MustCompile(`^arn:[^:]+:`+service+`:`), "must be a "+service+" ARN"),
+					},
+				},
+				"events": schema.SetAttribute{
+					Required:    true,
+					ElementType: types.StringType,
+					Description: "S3 event types (e.g., s3:ObjectCreated:*, s3:ObjectRemoved:*).",
+					Validators: []validator.Set{
+						setvalidator.SizeAtLeast(1),
+						setvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^s3:`), "must be an S3 event type")),
+					},
+				},
+				"filter_prefix": schema.StringAttribute{
+					Optional:    true,
+					Description: "Filter events by object key prefix.",
+				},
+				"filter_suffix": schema.StringAttribute{
+					Optional:    true,
+					Description: "Filter events by object key suffix.",
+				},
+			},
+		},
+	}
+}
+
 func (r *BucketNotificationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description:         "Manage RustFS bucket event notifications",
@@ -54,31 +116,9 @@ func (r *BucketNotificationResource) Schema(_ context.Context, _ resource.Schema
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"queue": schema.ListNestedAttribute{
-				Optional:    true,
-				Description: "Queue notification configurations.",
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"arn": schema.StringAttribute{
-							Required:    true,
-							Description: "ARN of the queue target (e.g., arn:minio:sqs::PRIMARY:amqp).",
-						},
-						"events": schema.SetAttribute{
-							Required:    true,
-							ElementType: types.StringType,
-							Description: "S3 event types (e.g., s3:ObjectCreated:*, s3:ObjectRemoved:*).",
-						},
-						"filter_prefix": schema.StringAttribute{
-							Optional:    true,
-							Description: "Filter events by object key prefix.",
-						},
-						"filter_suffix": schema.StringAttribute{
-							Optional:    true,
-							Description: "Filter events by object key suffix.",
-						},
-					},
-				},
-			},
+			"queue":  notificationConfigAttribute("Queue notification configurations.", "sqs", "arn:rustfs:sqs::primary:webhook"),
+			"topic":  notificationConfigAttribute("Topic notification configurations.", "sns", "arn:rustfs:sns::primary:kafka"),
+			"lambda": notificationConfigAttribute("Lambda notification configurations.", "lambda", "arn:rustfs:lambda::primary:webhook"),
 		},
 	}
 }
@@ -105,7 +145,11 @@ func (r *BucketNotificationResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
-	config := buildNotificationConfig(plan)
+	config, diags := buildNotificationConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if err := r.client.Minio.SetBucketNotification(ctx, plan.Bucket.ValueString(), config); err != nil {
 		resp.Diagnostics.AddError(
 			"Error setting bucket notification",
@@ -126,6 +170,10 @@ func (r *BucketNotificationResource) Read(ctx context.Context, req resource.Read
 
 	config, err := r.client.Minio.GetBucketNotification(ctx, state.Bucket.ValueString())
 	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading bucket notification",
 			"Could not read bucket notification: "+err.Error(),
@@ -133,35 +181,24 @@ func (r *BucketNotificationResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
-	var queues []bucketNotificationQueueModel
+	var queues, topics, lambdas []bucketNotificationConfig
 	for _, q := range config.QueueConfigs {
-		var events []string
-		for _, e := range q.Events {
-			events = append(events, string(e))
-		}
-		eventsSet, diags := types.SetValueFrom(ctx, types.StringType, events)
-		resp.Diagnostics.Append(diags...)
-
-		var prefix, suffix string
-		if q.Filter != nil {
-			for _, rule := range q.Filter.S3Key.FilterRules {
-				switch rule.Name {
-				case "prefix":
-					prefix = rule.Value
-				case "suffix":
-					suffix = rule.Value
-				}
-			}
-		}
-
-		queues = append(queues, bucketNotificationQueueModel{
-			Arn:          types.StringValue(q.Queue),
-			Events:       eventsSet,
-			FilterPrefix: types.StringValue(prefix),
-			FilterSuffix: types.StringValue(suffix),
-		})
+		queues = append(queues, bucketNotificationConfig{q.Config, q.Queue})
+	}
+	for _, t := range config.TopicConfigs {
+		topics = append(topics, bucketNotificationConfig{t.Config, t.Topic})
 	}
-	state.Queue = queues
+	for _, l := range config.LambdaConfigs {
+		lambdas = append(lambdas, bucketNotificationConfig{l.Config, l.Lambda})
+	}
+
+	var diags diag.Diagnostics
+	state.Queue, diags = flattenNotificationConfigs(ctx, queues, state.Queue)
+	resp.Diagnostics.Append(diags...)
+	state.Topic, diags = flattenNotificationConfigs(ctx, topics, state.Topic)
+	resp.Diagnostics.Append(diags...)
+	state.Lambda, diags = flattenNotificationConfigs(ctx, lambdas, state.Lambda)
+	resp.Diagnostics.Append(diags...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -173,7 +210,11 @@ func (r *BucketNotificationResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
-	config := buildNotificationConfig(plan)
+	config, diags := buildNotificationConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if err := r.client.Minio.SetBucketNotification(ctx, plan.Bucket.ValueString(), config); err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating bucket notification",
@@ -205,32 +246,228 @@ func (r *BucketNotificationResource) ImportState(ctx context.Context, req resour
 	resource.ImportStatePassthroughID(ctx, path.Root("bucket"), req, resp)
 }
 
-func buildNotificationConfig(plan bucketNotificationResourceModel) notification.Configuration {
+// ModifyPlan checks that all target ARNs exist on the server, so a typo
+// fails the plan instead of the apply. The server lists every target with
+// an sqs ARN, targets are matched by name and type so topics and lambdas
+// find them with their sns and lambda ARNs.
+func (r *BucketNotificationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan bucketNotificationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var existing []string
+	for _, kind := range []struct {
+		name    string
+		entries []bucketNotificationConfigModel
+	}{{"queue", plan.Queue}, {"topic", plan.Topic}, {"lambda", plan.Lambda}} {
+		for i, entry := range kind.entries {
+			// ARNs of targets created in the same apply are not known yet.
+			if entry.Arn.IsUnknown() || entry.Arn.IsNull() {
+				continue
+			}
+			if existing == nil {
+				arns, err := r.client.RustClient.ListNotificationTargetARNs(ctx)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Error listing notification targets",
+						"Could not list notification targets: "+err.Error(),
+					)
+					return
+				}
+				existing = append([]string{}, arns...)
+			}
+			if !slices.ContainsFunc(existing, func(arn string) bool {
+				return notificationTargetKey(arn) == notificationTargetKey(entry.Arn.ValueString())
+			}) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(kind.name).AtListIndex(i).AtName("arn"),
+					"Unknown notification target",
+					fmt.Sprintf("The notification target %s does not exist on the server. Configured targets: %s.", entry.Arn.ValueString(), strings.Join(existing, ", ")),
+				)
+			}
+		}
+	}
+}
+
+// notificationTargetKey returns the name and type of the target of an ARN,
+// e.g. primary:webhook for arn:rustfs:sqs::primary:webhook.
+func notificationTargetKey(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) != 6 {
+		return arn
+	}
+	return parts[4] + ":" + parts[5]
+}
+
+// bucketNotificationConfig is a queue, topic or lambda configuration with
+// its target ARN.
+type bucketNotificationConfig struct {
+	notification.Config
+	arn string
+}
+
+func expandNotificationConfig(ctx context.Context, entry bucketNotificationConfigModel) (notification.Config, diag.Diagnostics) {
+	var events []notification.EventType
+	diags := entry.Events.ElementsAs(ctx, &events, false)
+
+	filter := &notification.Filter{}
+	if prefix := entry.FilterPrefix.ValueString(); prefix != "" {
+		filter.S3Key.FilterRules = append(filter.S3Key.FilterRules,
+			notification.FilterRule{Name: "prefix", Value: prefix})
+	}
+	if suffix := entry.FilterSuffix.ValueString(); suffix != "" {
+		filter.S3Key.FilterRules = append(filter.S3Key.FilterRules,
+			notification.FilterRule{Name: "suffix", Value: suffix})
+	}
+	if len(filter.S3Key.FilterRules) == 0 {
+		filter = nil
+	}
+
+	id := entry.Id.ValueString()
+	if id == "" {
+		var d diag.Diagnostics
+		id, d = notificationID(ctx, entry)
+		diags.Append(d...)
+	}
+	return notification.Config{
+		ID:     id,
+		Events: events,
+		Filter: filter,
+	}, diags
+}
+
+func buildNotificationConfig(ctx context.Context, plan bucketNotificationResourceModel) (notification.Configuration, diag.Diagnostics) {
+	var diags diag.Diagnostics
 	var config notification.Configuration
 	for _, q := range plan.Queue {
-		var events []notification.EventType
-		q.Events.ElementsAs(nil, &events, false)
+		c, d := expandNotificationConfig(ctx, q)
+		diags.Append(d...)
+		config.QueueConfigs = append(config.QueueConfigs, notification.QueueConfig{
+			Config: c,
+			Queue:  q.Arn.ValueString(),
+		})
+	}
+	for _, t := range plan.Topic {
+		c, d := expandNotificationConfig(ctx, t)
+		diags.Append(d...)
+		config.TopicConfigs = append(config.TopicConfigs, notification.TopicConfig{
+			Config: c,
+			Topic:  t.Arn.ValueString(),
+		})
+	}
+	for _, l := range plan.Lambda {
+		c, d := expandNotificationConfig(ctx, l)
+		diags.Append(d...)
+		config.LambdaConfigs = append(config.LambdaConfigs, notification.LambdaConfig{
+			Config: c,
+			Lambda: l.Arn.ValueString(),
+		})
+	}
+	return config, diags
+}
+
+// flattenNotificationConfigs converts the entries read from the server.
+// Entries keep the order of prior, matched by id, so a server which sorts
+// the entries differently does not cause a diff. New entries follow in
+// server order.
+func flattenNotificationConfigs(ctx context.Context, configs []bucketNotificationConfig, prior []bucketNotificationConfigModel) ([]bucketNotificationConfigModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var entries []bucketNotificationConfigModel
+	for _, c := range configs {
+		var events []string
+		for _, e := range c.Events {
+			events = append(events, string(e))
+		}
+		eventsSet, d := types.SetValueFrom(ctx, types.StringType, events)
+		diags.Append(d...)
 
-		filter := &notification.Filter{}
-		if prefix := q.FilterPrefix.ValueString(); prefix != "" {
-			filter.S3Key.FilterRules = append(filter.S3Key.FilterRules,
-				notification.FilterRule{Name: "prefix", Value: prefix})
+		var prefix, suffix string
+		if c.Filter != nil {
+			for _, rule := range c.Filter.S3Key.FilterRules {
+				switch rule.Name {
+				case "prefix":
+					prefix = rule.Value
+				case "suffix":
+					suffix = rule.Value
+				}
+			}
 		}
-		if suffix := q.FilterSuffix.ValueString(); suffix != "" {
-			filter.S3Key.FilterRules = append(filter.S3Key.FilterRules,
-				notification.FilterRule{Name: "suffix", Value: suffix})
+
+		entry := bucketNotificationConfigModel{
+			Id:           types.StringValue(c.ID),
+			Arn:          types.StringValue(c.arn),
+			Events:       eventsSet,
+			FilterPrefix: stringOrNull(prefix),
+			FilterSuffix: stringOrNull(suffix),
 		}
-		if len(filter.S3Key.FilterRules) == 0 {
-			filter = nil
+		// Servers which drop the id get the one the provider derived.
+		if c.ID == "" {
+			id, d := notificationID(ctx, entry)
+			diags.Append(d...)
+			entry.Id = types.StringValue(id)
 		}
+		entries = append(entries, entry)
+	}
 
-		config.QueueConfigs = append(config.QueueConfigs, notification.QueueConfig{
-			Config: notification.Config{
-				Events: events,
-				Filter: filter,
-			},
-			Queue: q.Arn.ValueString(),
+	position := func(entry bucketNotificationConfigModel) int {
+		i := slices.IndexFunc(prior, func(p bucketNotificationConfigModel) bool {
+			return p.Id.Equal(entry.Id)
 		})
+		if i < 0 {
+			return len(prior)
+		}
+		return i
+	}
+	slices.SortStableFunc(entries, func(a, b bucketNotificationConfigModel) int {
+		return position(a) - position(b)
+	})
+	return entries, diags
+}
+
+// notificationID derives a stable id from the target, events and filters of
+// an entry.
+func notificationID(ctx context.Context, entry bucketNotificationConfigModel) (string, diag.Diagnostics) {
+	var events []string
+	diags := entry.Events.ElementsAs(ctx, &events, false)
+	slices.Sort(events)
+	parts := append([]string{entry.Arn.ValueString(), entry.FilterPrefix.ValueString(), entry.FilterSuffix.ValueString()}, events...)
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return "tf-" + hex.EncodeToString(sum[:8]), diags
+}
+
+// notificationIDModifier plans the derived id of entries without a
+// configured id.
+type notificationIDModifier struct{}
+
+func (m notificationIDModifier) Description(_ context.Context) string {
+	return "Derives the id from the other attributes of the entry if it is not configured."
+}
+
+func (m notificationIDModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m notificationIDModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var entry bucketNotificationConfigModel
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, req.Path.ParentPath(), &entry)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if entry.Arn.IsUnknown() || entry.Events.IsUnknown() || entry.FilterPrefix.IsUnknown() || entry.FilterSuffix.IsUnknown() {
+		return
+	}
+	id, diags := notificationID(ctx, entry)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	return config
+	resp.PlanValue = types.StringValue(id)
 }
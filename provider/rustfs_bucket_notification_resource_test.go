@@ -2,16 +2,24 @@ package provider
 
 import (
 	"context"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/notification"
 )
 
 func TestBuildNotificationConfig_SingleQueue(t *testing.T) {
 	eventsSet, _ := types.SetValueFrom(context.Background(), types.StringType, []string{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"})
 	plan := bucketNotificationResourceModel{
 		Bucket: types.StringValue("test-bucket"),
-		Queue: []bucketNotificationQueueModel{
+		Queue: []bucketNotificationConfigModel{
 			{
 				Arn:          types.StringValue("arn:minio:sqs::PRIMARY:amqp"),
 				Events:       eventsSet,
@@ -21,7 +29,10 @@ func TestBuildNotificationConfig_SingleQueue(t *testing.T) {
 		},
 	}
 
-	config := buildNotificationConfig(plan)
+	config, diags := buildNotificationConfig(context.Background(), plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
 
 	if len(config.QueueConfigs) != 1 {
 		t.Fatalf("expected 1 queue config, got %d", len(config.QueueConfigs))
@@ -65,7 +76,7 @@ func TestBuildNotificationConfig_NoFilter(t *testing.T) {
 	eventsSet, _ := types.SetValueFrom(context.Background(), types.StringType, []string{"s3:ObjectCreated:*"})
 	plan := bucketNotificationResourceModel{
 		Bucket: types.StringValue("test-bucket"),
-		Queue: []bucketNotificationQueueModel{
+		Queue: []bucketNotificationConfigModel{
 			{
 				Arn:    types.StringValue("arn:minio:sqs::PRIMARY:amqp"),
 				Events: eventsSet,
@@ -73,7 +84,10 @@ func TestBuildNotificationConfig_NoFilter(t *testing.T) {
 		},
 	}
 
-	config := buildNotificationConfig(plan)
+	config, diags := buildNotificationConfig(context.Background(), plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
 	if len(config.QueueConfigs) != 1 {
 		t.Fatalf("expected 1 queue config, got %d", len(config.QueueConfigs))
 	}
@@ -87,14 +101,213 @@ func TestBuildNotificationConfig_MultipleQueues(t *testing.T) {
 	events2, _ := types.SetValueFrom(context.Background(), types.StringType, []string{"s3:ObjectRemoved:*"})
 	plan := bucketNotificationResourceModel{
 		Bucket: types.StringValue("test-bucket"),
-		Queue: []bucketNotificationQueueModel{
+		Queue: []bucketNotificationConfigModel{
 			{Arn: types.StringValue("arn:minio:sqs::PRIMARY:q1"), Events: events1},
 			{Arn: types.StringValue("arn:minio:sqs::PRIMARY:q2"), Events: events2},
 		},
 	}
 
-	config := buildNotificationConfig(plan)
+	config, diags := buildNotificationConfig(context.Background(), plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
 	if len(config.QueueConfigs) != 2 {
 		t.Fatalf("expected 2 queue configs, got %d", len(config.QueueConfigs))
 	}
 }
+
+func TestBuildNotificationConfig_TopicAndLambda(t *testing.T) {
+	events, _ := types.SetValueFrom(context.Background(), types.StringType, []string{"s3:ObjectCreated:*"})
+	plan := bucketNotificationResourceModel{
+		Bucket: types.StringValue("test-bucket"),
+		Topic: []bucketNotificationConfigModel{
+			{Id: types.StringValue("kafka"), Arn: types.StringValue("arn:rustfs:sns::primary:kafka"), Events: events},
+		},
+		Lambda: []bucketNotificationConfigModel{
+			{Arn: types.StringValue("arn:rustfs:lambda::primary:webhook"), Events: events},
+		},
+	}
+
+	config, diags := buildNotificationConfig(context.Background(), plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(config.TopicConfigs) != 1 || config.TopicConfigs[0].Topic != "arn:rustfs:sns::primary:kafka" || config.TopicConfigs[0].ID != "kafka" {
+		t.Errorf("unexpected topic configs %+v", config.TopicConfigs)
+	}
+	if len(config.LambdaConfigs) != 1 || config.LambdaConfigs[0].Lambda != "arn:rustfs:lambda::primary:webhook" {
+		t.Fatalf("unexpected lambda configs %+v", config.LambdaConfigs)
+	}
+	if id, _ := notificationID(context.Background(), plan.Lambda[0]); config.LambdaConfigs[0].ID != id {
+		t.Errorf("expected derived id, got %q", config.LambdaConfigs[0].ID)
+	}
+}
+
+func TestNotificationID_Stable(t *testing.T) {
+	ctx := context.Background()
+	events, _ := types.SetValueFrom(ctx, types.StringType, []string{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"})
+	reordered, _ := types.SetValueFrom(ctx, types.StringType, []string{"s3:ObjectRemoved:*", "s3:ObjectCreated:*"})
+	entry := bucketNotificationConfigModel{Arn: types.StringValue("arn:rustfs:sqs::primary:webhook"), Events: events, FilterPrefix: types.StringValue("uploads/")}
+	same := bucketNotificationConfigModel{Arn: types.StringValue("arn:rustfs:sqs::primary:webhook"), Events: reordered, FilterPrefix: types.StringValue("uploads/")}
+	other := bucketNotificationConfigModel{Arn: types.StringValue("arn:rustfs:sqs::primary:webhook"), Events: events, FilterPrefix: types.StringValue("images/")}
+
+	id, _ := notificationID(ctx, entry)
+	if sameID, _ := notificationID(ctx, same); id != sameID {
+		t.Error("expected the id to ignore the event order")
+	}
+	if otherID, _ := notificationID(ctx, other); id == otherID {
+		t.Error("expected different ids for different filters")
+	}
+}
+
+func TestBuildNotificationConfig_UnknownEvents(t *testing.T) {
+	plan := bucketNotificationResourceModel{
+		Bucket: types.StringValue("test-bucket"),
+		Queue: []bucketNotificationConfigModel{
+			{Arn: types.StringValue("arn:minio:sqs::PRIMARY:amqp"), Events: types.SetUnknown(types.StringType)},
+		},
+	}
+	if _, diags := buildNotificationConfig(context.Background(), plan); !diags.HasError() {
+		t.Error("expected an error for unknown events instead of an empty event list")
+	}
+}
+
+func TestFlattenNotificationConfigs_PreservesOrder(t *testing.T) {
+	ctx := context.Background()
+	config := func(id string) bucketNotificationConfig {
+		return bucketNotificationConfig{
+			Config: notification.Config{ID: id, Events: []notification.EventType{notification.ObjectCreatedAll}},
+			arn:    "arn:rustfs:sqs::" + id + ":webhook",
+		}
+	}
+	prior := []bucketNotificationConfigModel{{Id: types.StringValue("b")}, {Id: types.StringValue("a")}}
+
+	// The server returns the entries sorted and one entry added out of band.
+	entries, diags := flattenNotificationConfigs(ctx, []bucketNotificationConfig{config("a"), config("b"), config("c")}, prior)
+	if diags.HasError() {
+		t.Fatalf("flatten diagnostics: %v", diags)
+	}
+	var ids []string
+	for _, entry := range entries {
+		ids = append(ids, entry.Id.ValueString())
+	}
+	if !reflect.DeepEqual(ids, []string{"b", "a", "c"}) {
+		t.Errorf("unexpected order %v", ids)
+	}
+	if !entries[0].FilterPrefix.IsNull() {
+		t.Error("expected null filter_prefix without filter")
+	}
+}
+
+func TestBucketNotificationResourceModifyPlan_UnknownTarget(t *testing.T) {
+	ctx := context.Background()
+	client, server := testProviderClient(t)
+	server.AddNotificationTarget("arn:rustfs:sqs::primary:webhook")
+	r := NewBucketNotificationResource()
+	events, _ := types.SetValueFrom(ctx, types.StringType, []string{"s3:ObjectCreated:*"})
+
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &bucketNotificationResourceModel{
+		Bucket: types.StringValue("events"),
+		Queue: []bucketNotificationConfigModel{
+			{Id: types.StringValue("known"), Arn: types.StringValue("arn:rustfs:sqs::primary:webhook"), Events: events},
+			{Id: types.StringValue("typo"), Arn: types.StringValue("arn:rustfs:sqs::primary:webhok"), Events: events},
+		},
+	})
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan(plan)}
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: tfsdk.Plan(plan)}, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one error for the unknown target, got %v", resp.Diagnostics)
+	}
+	if !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "webhok") {
+		t.Errorf("unexpected diagnostic %v", resp.Diagnostics.Errors()[0])
+	}
+}
+
+// Topics and lambdas reference targets of rustfs_notify_* resources, which
+// the server lists with sqs ARNs only.
+func TestBucketNotificationResourceModifyPlan_TopicAndLambdaTargets(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	target := NewNotifyKafkaResource()
+	targetPlan := testEventTargetPlan(t, target, client, map[string]any{
+		"name":    types.StringValue("primary"),
+		"brokers": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("kafka-1:9092")}),
+		"topic":   types.StringValue("events"),
+	})
+	targetResp := &resource.CreateResponse{State: testResourceState(t, target, client)}
+	target.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(targetPlan)}, targetResp)
+	if targetResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", targetResp.Diagnostics)
+	}
+	var arn types.String
+	targetResp.State.GetAttribute(ctx, path.Root("arn"), &arn)
+
+	r := NewBucketNotificationResource()
+	events, _ := types.SetValueFrom(ctx, types.StringType, []string{"s3:ObjectCreated:*"})
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &bucketNotificationResourceModel{
+		Bucket: types.StringValue("events"),
+		Queue: []bucketNotificationConfigModel{
+			{Id: types.StringValue("queue"), Arn: arn, Events: events},
+		},
+		Topic: []bucketNotificationConfigModel{
+			{Id: types.StringValue("topic"), Arn: types.StringValue(strings.Replace(arn.ValueString(), ":sqs:", ":sns:", 1)), Events: events},
+			{Id: types.StringValue("typo"), Arn: types.StringValue("arn:rustfs:sns::primary:kafak"), Events: events},
+		},
+		Lambda: []bucketNotificationConfigModel{
+			{Id: types.StringValue("lambda"), Arn: types.StringValue(strings.Replace(arn.ValueString(), ":sqs:", ":lambda:", 1)), Events: events},
+		},
+	})
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan(plan)}
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: tfsdk.Plan(plan)}, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "kafak") {
+		t.Errorf("expected one error for the unknown topic target, got %v", resp.Diagnostics)
+	}
+}
+
+func TestBucketNotificationResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	if err := client.Minio.MakeBucket(ctx, "events", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewBucketNotificationResource()
+	events, _ := types.SetValueFrom(ctx, types.StringType, []string{"s3:ObjectCreated:*"})
+	model := bucketNotificationResourceModel{
+		Bucket: types.StringValue("events"),
+		Queue: []bucketNotificationConfigModel{
+			{Id: types.StringValue("uploads"), Arn: types.StringValue("arn:rustfs:sqs::primary:webhook"), Events: events, FilterPrefix: types.StringValue("uploads/"), FilterSuffix: types.StringNull()},
+		},
+		Topic: []bucketNotificationConfigModel{
+			{Id: types.StringValue("kafka"), Arn: types.StringValue("arn:rustfs:sns::primary:kafka"), Events: events, FilterPrefix: types.StringNull(), FilterSuffix: types.StringValue(".jpg")},
+		},
+	}
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &model)
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var read bucketNotificationResourceModel
+	readResp.State.Get(ctx, &read)
+	if !reflect.DeepEqual(read, model) {
+		t.Errorf("unexpected state after read:\n got %+v\nwant %+v", read, model)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/minio/minio-go/v7"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
 )
 
 var (
@@ -53,19 +56,23 @@ func (r *BucketObjectLockResource) Schema(_ context.Context, _ resource.SchemaRe
 			"mode": schema.StringAttribute{
 				Required:    true,
 				Description: "Object lock retention mode: COMPLIANCE or GOVERNANCE.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(minio.Compliance), string(minio.Governance)),
+				},
 			},
 			"days": schema.Int64Attribute{
 				Optional:    true,
-				Description: "Retention period in days.",
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+				Description: "Retention period in days. Exactly one of days and years must be set.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.ExactlyOneOf(path.MatchRoot("years")),
 				},
 			},
 			"years": schema.Int64Attribute{
 				Optional:    true,
-				Description: "Retention period in years.",
-				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.RequiresReplace(),
+				Description: "Retention period in years. Exactly one of days and years must be set.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
 				},
 			},
 		},
@@ -142,23 +149,30 @@ func (r *BucketObjectLockResource) Read(ctx context.Context, req resource.ReadRe
 
 	mode, validity, unit, err := r.client.Minio.GetBucketObjectLockConfig(ctx, state.Bucket.ValueString())
 	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading object lock",
 			"Could not read object lock: "+err.Error(),
 		)
 		return
 	}
-
-	if mode != nil {
-		state.Mode = types.StringValue(string(*mode))
+	// Object lock stays enabled, the resource only manages the default rule.
+	if mode == nil || *mode == "" || validity == nil || unit == nil {
+		resp.State.RemoveResource(ctx)
+		return
 	}
-	if validity != nil && unit != nil {
-		switch *unit {
-		case minio.Days:
-			state.Days = types.Int64Value(int64(*validity)) // #nosec G115
-		case minio.Years:
-			state.Years = types.Int64Value(int64(*validity)) // #nosec G115
-		}
+
+	state.Mode = types.StringValue(string(*mode))
+	state.Days = types.Int64Null()
+	state.Years = types.Int64Null()
+	switch *unit {
+	case minio.Days:
+		state.Days = types.Int64Value(int64(*validity)) // #nosec G115
+	case minio.Years:
+		state.Years = types.Int64Value(int64(*validity)) // #nosec G115
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -183,7 +197,22 @@ func (r *BucketObjectLockResource) Update(ctx context.Context, req resource.Upda
 }
 
 func (r *BucketObjectLockResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// Object lock cannot be removed from a bucket; only the bucket itself can be deleted.
+	var data BucketObjectLockResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Object lock cannot be disabled on a bucket, only the default
+	// retention rule is removed.
+	err := r.client.Minio.SetObjectLockConfig(ctx, data.Bucket.ValueString(), nil, nil, nil)
+	if err != nil && !rustfs.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error removing object lock",
+			"Could not remove default retention: "+err.Error(),
+		)
+		return
+	}
 }
 
 func (r *BucketObjectLockResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
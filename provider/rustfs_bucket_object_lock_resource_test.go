@@ -1,9 +1,13 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
 )
 
 func TestBucketObjectLockResourceSchema(t *testing.T) {
@@ -39,3 +43,67 @@ func TestBucketObjectLockResourceMetadata(t *testing.T) {
 		t.Errorf("expected rustfs_bucket_object_lock, got %s", resp.TypeName)
 	}
 }
+
+func TestBucketObjectLockResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	if err := client.Minio.MakeBucket(ctx, "locked", minio.MakeBucketOptions{ObjectLocking: true}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewBucketObjectLockResource()
+	model := BucketObjectLockResourceModel{
+		Bucket: types.StringValue("locked"),
+		Mode:   types.StringValue("GOVERNANCE"),
+		Days:   types.Int64Value(30),
+		Years:  types.Int64Null(),
+	}
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &model)
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	// The default retention is updated in place, switching days to years.
+	model.Mode = types.StringValue("COMPLIANCE")
+	model.Days = types.Int64Null()
+	model.Years = types.Int64Value(1)
+	plan.Set(ctx, &model)
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan(plan), State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("update diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	importResp := &resource.ImportStateResponse{State: testResourceState(t, r, client)}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "locked"}, importResp)
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var read BucketObjectLockResourceModel
+	readResp.State.Get(ctx, &read)
+	if read != model {
+		t.Errorf("unexpected state after read:\n got %+v\nwant %+v", read, model)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	readResp = &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected removed default retention to be removed from state")
+	}
+
+	// Object lock itself stays enabled.
+	enabled, _, _, _, err := client.Minio.GetObjectLockConfig(ctx, "locked")
+	if err != nil || enabled != "Enabled" {
+		t.Errorf("expected object lock to stay enabled, got %q: %v", enabled, err)
+	}
+}
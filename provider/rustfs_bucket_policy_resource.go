@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+var (
+	_ resource.Resource                = &BucketPolicyResource{}
+	_ resource.ResourceWithImportState = &BucketPolicyResource{}
+)
+
+type BucketPolicyResource struct {
+	client *AllClient
+}
+
+type BucketPolicyResourceModel struct {
+	Bucket types.String         `tfsdk:"bucket"`
+	Policy jsontypes.Normalized `tfsdk:"policy"`
+}
+
+func NewBucketPolicyResource() resource.Resource {
+	return &BucketPolicyResource{}
+}
+
+func (r *BucketPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_policy"
+}
+
+func (r *BucketPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Manage RustFS bucket policy",
+		MarkdownDescription: "Manage the S3 bucket policy of a RustFS bucket, e.g. for anonymous read access",
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the bucket.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Required:    true,
+				Description: "Bucket policy JSON document. Whitespace and key order are ignored when comparing.",
+			},
+		},
+	}
+}
+
+func (r *BucketPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*AllClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AllClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *BucketPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan BucketPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Minio.SetBucketPolicy(ctx, plan.Bucket.ValueString(), plan.Policy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error setting bucket policy",
+			"Could not set bucket policy: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state BucketPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// minio-go reports a missing policy as an empty document.
+	policy, err := r.client.Minio.GetBucketPolicy(ctx, state.Bucket.ValueString())
+	if err != nil && !rustfs.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error reading bucket policy",
+			"Could not read bucket policy: "+err.Error(),
+		)
+		return
+	}
+	if err != nil || policy == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Policy = jsontypes.NewNormalizedValue(policy)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BucketPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan BucketPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Minio.SetBucketPolicy(ctx, plan.Bucket.ValueString(), plan.Policy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating bucket policy",
+			"Could not update bucket policy: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BucketPolicyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An empty policy removes the bucket policy.
+	err := r.client.Minio.SetBucketPolicy(ctx, data.Bucket.ValueString(), "")
+	if err != nil && !rustfs.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error removing bucket policy",
+			"Could not remove bucket policy: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *BucketPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("bucket"), req, resp)
+}
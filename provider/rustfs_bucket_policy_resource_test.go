@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
+)
+
+const testBucketPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::public/*"]
+    }
+  ]
+}`
+
+func TestBucketPolicyResourceSchema(t *testing.T) {
+	r := NewBucketPolicyResource()
+	resp := &resource.SchemaResponse{}
+	r.Schema(nil, resource.SchemaRequest{}, resp)
+
+	if diags := resp.Diagnostics; diags.HasError() {
+		t.Fatalf("schema diagnostics: %v", diags)
+	}
+
+	attrs := resp.Schema.GetAttributes()
+	if _, ok := attrs["bucket"]; !ok {
+		t.Error("expected bucket attribute")
+	}
+	if _, ok := attrs["policy"]; !ok {
+		t.Error("expected policy attribute")
+	}
+}
+
+func TestBucketPolicyResourceMetadata(t *testing.T) {
+	r := NewBucketPolicyResource()
+	resp := &resource.MetadataResponse{}
+	r.Metadata(nil, resource.MetadataRequest{ProviderTypeName: "rustfs"}, resp)
+
+	if resp.TypeName != "rustfs_bucket_policy" {
+		t.Errorf("expected rustfs_bucket_policy, got %s", resp.TypeName)
+	}
+}
+
+func TestBucketPolicySemanticEquality(t *testing.T) {
+	compact := `{"Statement":[{"Resource":["arn:aws:s3:::public/*"],"Action":["s3:GetObject"],"Principal":{"AWS":["*"]},"Effect":"Allow"}],"Version":"2012-10-17"}`
+	equal, diags := jsontypes.NewNormalizedValue(testBucketPolicy).StringSemanticEquals(context.Background(), jsontypes.NewNormalizedValue(compact))
+	if diags.HasError() {
+		t.Fatalf("semantic equality diagnostics: %v", diags)
+	}
+	if !equal {
+		t.Error("expected reformatted policy to be semantically equal")
+	}
+
+	changed := `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::public/*"]}]}`
+	equal, _ = jsontypes.NewNormalizedValue(testBucketPolicy).StringSemanticEquals(context.Background(), jsontypes.NewNormalizedValue(changed))
+	if equal {
+		t.Error("expected changed policy to differ")
+	}
+}
+
+func TestBucketPolicyResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	if err := client.Minio.MakeBucket(ctx, "public", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewBucketPolicyResource()
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &BucketPolicyResourceModel{
+		Bucket: types.StringValue("public"),
+		Policy: jsontypes.NewNormalizedValue(testBucketPolicy),
+	})
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	// Import only knows the bucket name, Read fills in the policy.
+	importResp := &resource.ImportStateResponse{State: testResourceState(t, r, client)}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "public"}, importResp)
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var imported BucketPolicyResourceModel
+	readResp.State.Get(ctx, &imported)
+	if equal, _ := imported.Policy.StringSemanticEquals(ctx, jsontypes.NewNormalizedValue(testBucketPolicy)); !equal {
+		t.Errorf("unexpected imported policy %s", imported.Policy.ValueString())
+	}
+
+	deleteResp := &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected removed policy to be removed from state")
+	}
+}
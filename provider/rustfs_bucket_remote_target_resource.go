@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+var (
+	_ resource.Resource                = &BucketRemoteTargetResource{}
+	_ resource.ResourceWithImportState = &BucketRemoteTargetResource{}
+)
+
+type BucketRemoteTargetResource struct {
+	client *AllClient
+}
+
+type bucketRemoteTargetResourceModel struct {
+	Bucket          types.String   `tfsdk:"bucket"`
+	Endpoint        types.String   `tfsdk:"endpoint"`
+	TargetBucket    types.String   `tfsdk:"target_bucket"`
+	AccessKey       types.String   `tfsdk:"access_key"`
+	SecretKey       types.String   `tfsdk:"secret_key"`
+	Secure          types.Bool     `tfsdk:"secure"`
+	Region          types.String   `tfsdk:"region"`
+	StorageClass    types.String   `tfsdk:"storage_class"`
+	BandwidthLimit  types.Int64    `tfsdk:"bandwidth_limit"`
+	ReplicationSync types.Bool     `tfsdk:"replication_sync"`
+	DisableProxy    types.Bool     `tfsdk:"disable_proxy"`
+	Arn             types.String   `tfsdk:"arn"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewBucketRemoteTargetResource() resource.Resource {
+	return &BucketRemoteTargetResource{}
+}
+
+func (r *BucketRemoteTargetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_remote_target"
+}
+
+func (r *BucketRemoteTargetResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Manage RustFS bucket remote targets",
+		MarkdownDescription: "Register a remote endpoint a RustFS bucket replicates to. The exported `arn` is the `destination_bucket` of `rustfs_bucket_replication` rules.",
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				Required:      true,
+				Description:   "Name of the source bucket. Changing this forces recreation.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"endpoint": schema.StringAttribute{
+				Required:      true,
+				Description:   "Host and port of the remote server, e.g. replica.example.com:9000. Changing this forces recreation.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"target_bucket": schema.StringAttribute{
+				Required:      true,
+				Description:   "Name of the bucket on the remote server. Changing this forces recreation.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"access_key": schema.StringAttribute{
+				Required:    true,
+				Description: "Access key for the remote server.",
+			},
+			"secret_key": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "Secret key for the remote server. It is not read back from the server.",
+			},
+			"secure": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Use TLS to connect to the remote server. Default: true.",
+			},
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Description: "Region of the remote bucket.",
+			},
+			"storage_class": schema.StringAttribute{
+				Optional:    true,
+				Description: "Storage class of the replicated objects on the remote server.",
+			},
+			"bandwidth_limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Replication bandwidth limit in bytes per second.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"replication_sync": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Replicate synchronously. Default: false.",
+			},
+			"disable_proxy": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Do not proxy reads of missing objects to the remote server. Default: false.",
+			},
+			"arn": schema.StringAttribute{
+				Computed:      true,
+				Description:   "ARN of the remote target assigned by the server.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *BucketRemoteTargetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*AllClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *AllClient, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = client
+}
+
+func (r *BucketRemoteTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan bucketRemoteTargetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	arn, err := r.client.RustClient.SetRemoteTarget(ctx, buildBucketTarget(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error adding remote target", "Could not add remote target: "+err.Error())
+		return
+	}
+
+	plan.Arn = types.StringValue(arn)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketRemoteTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state bucketRemoteTargetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	target, err := r.client.RustClient.GetRemoteTarget(ctx, state.Bucket.ValueString(), state.Arn.ValueString())
+	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading remote target", "Could not read remote target: "+err.Error())
+		return
+	}
+
+	state.Endpoint = types.StringValue(target.Endpoint)
+	state.TargetBucket = types.StringValue(target.TargetBucket)
+	if target.Credentials != nil && target.Credentials.AccessKey != "" {
+		state.AccessKey = types.StringValue(target.Credentials.AccessKey)
+	}
+	state.Secure = types.BoolValue(target.Secure)
+	state.Region = stringOrNull(target.Region)
+	state.StorageClass = stringOrNull(target.StorageClass)
+	state.BandwidthLimit = int64OrNull(target.BandwidthLimit)
+	state.ReplicationSync = types.BoolValue(target.ReplicationSync)
+	state.DisableProxy = types.BoolValue(target.DisableProxy)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BucketRemoteTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan bucketRemoteTargetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	arn, err := r.client.RustClient.UpdateRemoteTarget(ctx, buildBucketTarget(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating remote target", "Could not update remote target: "+err.Error())
+		return
+	}
+
+	plan.Arn = types.StringValue(arn)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketRemoteTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data bucketRemoteTargetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if err := r.client.RustClient.RemoveRemoteTarget(ctx, data.Bucket.ValueString(), data.Arn.ValueString()); err != nil {
+		if rustfs.IsNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Error removing remote target", "Could not remove remote target: "+err.Error())
+		return
+	}
+}
+
+// ImportState imports a remote target by "<bucket>/<arn>". The secret key
+// is not returned by the server and must be set in the configuration.
+func (r *BucketRemoteTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	bucket, arn, ok := strings.Cut(req.ID, "/")
+	if !ok || bucket == "" || arn == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Expected <bucket>/<arn>, got: %q", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucket)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("arn"), arn)...)
+}
+
+func buildBucketTarget(plan bucketRemoteTargetResourceModel) rustfs.BucketTarget {
+	target := rustfs.BucketTarget{
+		SourceBucket: plan.Bucket.ValueString(),
+		Endpoint:     plan.Endpoint.ValueString(),
+		TargetBucket: plan.TargetBucket.ValueString(),
+		Credentials: &rustfs.TargetCredentials{
+			AccessKey: plan.AccessKey.ValueString(),
+			SecretKey: plan.SecretKey.ValueString(),
+		},
+		Secure:          plan.Secure.ValueBool(),
+		Type:            rustfs.ReplicationTargetType,
+		Region:          plan.Region.ValueString(),
+		StorageClass:    plan.StorageClass.ValueString(),
+		BandwidthLimit:  plan.BandwidthLimit.ValueInt64(),
+		ReplicationSync: plan.ReplicationSync.ValueBool(),
+		DisableProxy:    plan.DisableProxy.ValueBool(),
+	}
+	// The ARN is unknown when the target is created.
+	if !plan.Arn.IsUnknown() {
+		target.Arn = plan.Arn.ValueString()
+	}
+	return target
+}
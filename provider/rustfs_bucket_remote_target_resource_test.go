@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestBucketRemoteTargetResourceMetadata(t *testing.T) {
+	r := NewBucketRemoteTargetResource()
+	resp := &resource.MetadataResponse{}
+	r.Metadata(nil, resource.MetadataRequest{ProviderTypeName: "rustfs"}, resp)
+
+	if resp.TypeName != "rustfs_bucket_remote_target" {
+		t.Errorf("expected rustfs_bucket_remote_target, got %s", resp.TypeName)
+	}
+}
+
+func TestBucketRemoteTargetResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	if err := client.Minio.MakeBucket(ctx, "source", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewBucketRemoteTargetResource()
+	plan := testResourceState(t, r, client)
+	var model bucketRemoteTargetResourceModel
+	plan.Get(ctx, &model)
+	model.Bucket = types.StringValue("source")
+	model.Endpoint = types.StringValue("replica.example.com:9000")
+	model.TargetBucket = types.StringValue("replica")
+	model.AccessKey = types.StringValue("replicator")
+	model.SecretKey = types.StringValue("replicator-secret")
+	model.Secure = types.BoolValue(true)
+	model.BandwidthLimit = types.Int64Value(1 << 20)
+	model.ReplicationSync = types.BoolValue(false)
+	model.DisableProxy = types.BoolValue(false)
+	model.Arn = types.StringUnknown()
+	plan.Set(ctx, &model)
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+	var created bucketRemoteTargetResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.Arn.ValueString() == "" {
+		t.Fatal("expected the ARN in state")
+	}
+
+	// Import knows bucket and ARN, the secret key is not read back.
+	importResp := &resource.ImportStateResponse{State: testResourceState(t, r, client)}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "source/" + created.Arn.ValueString()}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("import diagnostics: %v", importResp.Diagnostics)
+	}
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var imported bucketRemoteTargetResourceModel
+	readResp.State.Get(ctx, &imported)
+	if imported.Endpoint.ValueString() != "replica.example.com:9000" || imported.AccessKey.ValueString() != "replicator" || imported.BandwidthLimit.ValueInt64() != 1<<20 {
+		t.Errorf("unexpected imported remote target %+v", imported)
+	}
+	if !imported.SecretKey.IsNull() {
+		t.Error("expected no secret key after import")
+	}
+
+	created.BandwidthLimit = types.Int64Null()
+	updatePlan := createResp.State
+	updatePlan.Set(ctx, &created)
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan(updatePlan)}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("update diagnostics: %v", updateResp.Diagnostics)
+	}
+	readResp = &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	var updated bucketRemoteTargetResourceModel
+	readResp.State.Get(ctx, &updated)
+	if !updated.BandwidthLimit.IsNull() || updated.SecretKey.ValueString() != "replicator-secret" {
+		t.Errorf("unexpected updated remote target %+v", updated)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	readResp = &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected removed remote target to be removed from state")
+	}
+}
+
+func TestBucketRemoteTargetResourceImport_InvalidID(t *testing.T) {
+	client, _ := testProviderClient(t)
+	r := NewBucketRemoteTargetResource()
+	resp := &resource.ImportStateResponse{State: testResourceState(t, r, client)}
+	r.(resource.ResourceWithImportState).ImportState(context.Background(), resource.ImportStateRequest{ID: "only-bucket"}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error for an import ID without ARN")
+	}
+}
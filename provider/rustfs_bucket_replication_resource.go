@@ -3,21 +3,33 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/replication"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
 )
 
 var (
-	_ resource.Resource                = &BucketReplicationResource{}
-	_ resource.ResourceWithImportState = &BucketReplicationResource{}
+	_ resource.Resource                 = &BucketReplicationResource{}
+	_ resource.ResourceWithImportState  = &BucketReplicationResource{}
+	_ resource.ResourceWithUpgradeState = &BucketReplicationResource{}
 )
 
 type BucketReplicationResource struct {
@@ -25,13 +37,27 @@ type BucketReplicationResource struct {
 }
 
 type bucketReplicationResourceModel struct {
-	Bucket                 types.String `tfsdk:"bucket"`
-	Role                   types.String `tfsdk:"role"`
-	DestinationBucket      types.String `tfsdk:"destination_bucket"`
-	Priority               types.Int64  `tfsdk:"priority"`
-	Status                 types.String `tfsdk:"status"`
-	DeleteMarkerReplication types.String `tfsdk:"delete_marker_replication"`
-	DeleteReplication      types.String `tfsdk:"delete_replication"`
+	Bucket types.String           `tfsdk:"bucket"`
+	Role   types.String           `tfsdk:"role"`
+	Rule   []replicationRuleModel `tfsdk:"rule"`
+}
+
+type replicationRuleModel struct {
+	Id                        types.String            `tfsdk:"id"`
+	Priority                  types.Int64             `tfsdk:"priority"`
+	Status                    types.String            `tfsdk:"status"`
+	DestinationBucket         types.String            `tfsdk:"destination_bucket"`
+	StorageClass              types.String            `tfsdk:"storage_class"`
+	Filter                    *replicationFilterModel `tfsdk:"filter"`
+	DeleteMarkerReplication   types.String            `tfsdk:"delete_marker_replication"`
+	DeleteReplication         types.String            `tfsdk:"delete_replication"`
+	ExistingObjectReplication types.String            `tfsdk:"existing_object_replication"`
+	ReplicaModifications      types.String            `tfsdk:"replica_modifications"`
+}
+
+type replicationFilterModel struct {
+	Prefix types.String `tfsdk:"prefix"`
+	Tags   types.Map    `tfsdk:"tags"`
 }
 
 func NewBucketReplicationResource() resource.Resource {
@@ -44,46 +70,138 @@ func (r *BucketReplicationResource) Metadata(_ context.Context, req resource.Met
 
 func (r *BucketReplicationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		Description:         "Manage RustFS bucket replication",
 		MarkdownDescription: "Manage RustFS bucket replication configuration",
 		Attributes: map[string]schema.Attribute{
 			"bucket": schema.StringAttribute{
-				Required:    true,
-				Description: "Name of the source bucket.",
+				Required:      true,
+				Description:   "Name of the source bucket.",
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
 			"role": schema.StringAttribute{
-				Required:    true,
-				Description: "Replication role ARN.",
-			},
-			"destination_bucket": schema.StringAttribute{
-				Required:    true,
-				Description: "Destination bucket ARN.",
-			},
-			"priority": schema.Int64Attribute{
 				Optional:    true,
-				Computed:    true,
-				Default:     int64default.StaticInt64(1),
-				Description: "Rule priority.",
+				Description: "Replication role ARN. Not needed when the rules use the ARN of a rustfs_bucket_remote_target.",
 			},
-			"status": schema.StringAttribute{
-				Optional:    true,
-				Computed:    true,
-				Default:     stringdefault.StaticString("Enabled"),
-				Description: "Rule status: Enabled or Disabled.",
-			},
-			"delete_marker_replication": schema.StringAttribute{
-				Optional:    true,
-				Description: "Delete marker replication: Enabled or Disabled.",
-			},
-			"delete_replication": schema.StringAttribute{
-				Optional:    true,
-				Description: "Delete replication: Enabled or Disabled.",
+		},
+		Blocks: map[string]schema.Block{
+			"rule": schema.ListNestedBlock{
+				Description: "Replication rules.",
+				Validators: []validator.List{
+					listvalidator.IsRequired(),
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Required:    true,
+							Description: "Unique identifier of the rule.",
+						},
+						"priority": schema.Int64Attribute{
+							Required:    true,
+							Description: "Rule priority. Rules with a higher priority win if several rules match an object.",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+						"status": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("Enabled"),
+							Description: "Rule status: Enabled or Disabled.",
+							Validators:  []validator.String{replicationStatusValidator()},
+						},
+						"destination_bucket": schema.StringAttribute{
+							Required:    true,
+							Description: "Destination bucket ARN, e.g. the arn of a rustfs_bucket_remote_target.",
+						},
+						"storage_class": schema.StringAttribute{
+							Optional:    true,
+							Description: "Storage class of the replicated objects.",
+						},
+						"delete_marker_replication": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("Disabled"),
+							Description: "Delete marker replication: Enabled or Disabled.",
+							Validators:  []validator.String{replicationStatusValidator()},
+						},
+						"delete_replication": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("Disabled"),
+							Description: "Delete replication: Enabled or Disabled.",
+							Validators:  []validator.String{replicationStatusValidator()},
+						},
+						"existing_object_replication": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("Disabled"),
+							Description: "Replication of objects created before the rule: Enabled or Disabled.",
+							Validators:  []validator.String{replicationStatusValidator()},
+						},
+						"replica_modifications": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("Enabled"),
+							Description: "Sync of metadata changes on replicas back to the source: Enabled or Disabled.",
+							Validators:  []validator.String{replicationStatusValidator()},
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"filter": schema.SingleNestedBlock{
+							Description: "Objects the rule applies to. Without a filter the rule applies to all objects.",
+							Validators:  []validator.Object{replicationFilterValidator{}},
+							Attributes: map[string]schema.Attribute{
+								"prefix": schema.StringAttribute{
+									Optional:    true,
+									Description: "Object key prefix.",
+									Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+								},
+								"tags": schema.MapAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Description: "Object tags which must all match.",
+									Validators:  []validator.Map{mapvalidator.SizeAtLeast(1)},
+								},
+							},
+						},
+					},
+				},
 			},
 		},
 	}
 }
 
+func replicationStatusValidator() validator.String {
+	return stringvalidator.OneOf(string(replication.Enabled), string(replication.Disabled))
+}
+
+// replicationFilterValidator rejects a filter without prefix and tags. The
+// server stores it as no filter, which would never match the configuration.
+type replicationFilterValidator struct{}
+
+func (v replicationFilterValidator) Description(_ context.Context) string {
+	return "filter must set prefix or tags"
+}
+
+func (v replicationFilterValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v replicationFilterValidator) ValidateObject(_ context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for _, value := range req.ConfigValue.Attributes() {
+		if !value.IsNull() {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Empty replication filter",
+		"The filter must set prefix or tags. Remove the filter block to replicate all objects.")
+}
+
 func (r *BucketReplicationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -103,7 +221,11 @@ func (r *BucketReplicationResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	cfg := buildReplicationConfig(plan)
+	cfg, diags := buildReplicationConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if err := r.client.Minio.SetBucketReplication(ctx, plan.Bucket.ValueString(), cfg); err != nil {
 		resp.Diagnostics.AddError("Error setting bucket replication", "Could not set replication: "+err.Error())
 		return
@@ -119,24 +241,19 @@ func (r *BucketReplicationResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
+	// minio-go reports a missing replication configuration as empty config.
 	cfg, err := r.client.Minio.GetBucketReplication(ctx, state.Bucket.ValueString())
-	if err != nil {
+	if err != nil && !rustfs.IsNotFound(err) {
 		resp.Diagnostics.AddError("Error reading bucket replication", "Could not read: "+err.Error())
 		return
 	}
-
-	state.Role = types.StringValue(cfg.Role)
-	if len(cfg.Rules) > 0 {
-		rule := cfg.Rules[0]
-		state.Status = types.StringValue(string(rule.Status))
-		state.Priority = types.Int64Value(int64(rule.Priority))
-		state.DeleteMarkerReplication = types.StringValue(string(rule.DeleteMarkerReplication.Status))
-		state.DeleteReplication = types.StringValue(string(rule.DeleteReplication.Status))
-		if rule.Destination.Bucket != "" {
-			state.DestinationBucket = types.StringValue(rule.Destination.Bucket)
-		}
+	if err != nil || len(cfg.Rules) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
+	state.Role = stringOrNull(cfg.Role)
+	state.Rule = flattenReplicationRules(cfg.Rules, state.Rule)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -147,7 +264,11 @@ func (r *BucketReplicationResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	cfg := buildReplicationConfig(plan)
+	cfg, diags := buildReplicationConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if err := r.client.Minio.SetBucketReplication(ctx, plan.Bucket.ValueString(), cfg); err != nil {
 		resp.Diagnostics.AddError("Error updating bucket replication", "Could not update: "+err.Error())
 		return
@@ -163,7 +284,9 @@ func (r *BucketReplicationResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
-	if err := r.client.Minio.RemoveBucketReplication(ctx, data.Bucket.ValueString()); err != nil {
+	// minio-go expects the 200 of MinIO, RustFS replies 204 like S3.
+	err := r.client.Minio.RemoveBucketReplication(ctx, data.Bucket.ValueString())
+	if err != nil && minio.ToErrorResponse(err).StatusCode != http.StatusNoContent && !rustfs.IsNotFound(err) {
 		resp.Diagnostics.AddError("Error removing bucket replication", "Could not remove: "+err.Error())
 		return
 	}
@@ -173,33 +296,187 @@ func (r *BucketReplicationResource) ImportState(ctx context.Context, req resourc
 	resource.ImportStatePassthroughID(ctx, path.Root("bucket"), req, resp)
 }
 
-func buildReplicationConfig(plan bucketReplicationResourceModel) replication.Config {
-	var rules []replication.Rule
+// bucketReplicationResourceModelV0 is the state of schema version 0, which
+// managed a single rule with the ID rule-1.
+type bucketReplicationResourceModelV0 struct {
+	Bucket                  types.String `tfsdk:"bucket"`
+	Role                    types.String `tfsdk:"role"`
+	DestinationBucket       types.String `tfsdk:"destination_bucket"`
+	Priority                types.Int64  `tfsdk:"priority"`
+	Status                  types.String `tfsdk:"status"`
+	DeleteMarkerReplication types.String `tfsdk:"delete_marker_replication"`
+	DeleteReplication       types.String `tfsdk:"delete_replication"`
+}
 
-	rule := replication.Rule{
-		ID:       "rule-1",
-		Status:   replication.Status(plan.Status.ValueString()),
-		Priority: int(plan.Priority.ValueInt64()),
-		Destination: replication.Destination{
-			Bucket: plan.DestinationBucket.ValueString(),
+// UpgradeState moves the rule attributes of schema version 0 into the
+// first rule block.
+func (r *BucketReplicationResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"bucket":                    schema.StringAttribute{Required: true},
+					"role":                      schema.StringAttribute{Required: true},
+					"destination_bucket":        schema.StringAttribute{Required: true},
+					"priority":                  schema.Int64Attribute{Optional: true, Computed: true},
+					"status":                    schema.StringAttribute{Optional: true, Computed: true},
+					"delete_marker_replication": schema.StringAttribute{Optional: true},
+					"delete_replication":        schema.StringAttribute{Optional: true},
+				},
+			},
+			StateUpgrader: upgradeBucketReplicationStateV0,
 		},
 	}
+}
 
-	if plan.DeleteMarkerReplication.ValueString() != "" {
-		rule.DeleteMarkerReplication = replication.DeleteMarkerReplication{
-			Status: replication.Status(plan.DeleteMarkerReplication.ValueString()),
-		}
+func upgradeBucketReplicationStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior bucketReplicationResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if plan.DeleteReplication.ValueString() != "" {
-		rule.DeleteReplication = replication.DeleteReplication{
-			Status: replication.Status(plan.DeleteReplication.ValueString()),
-		}
+
+	rule := replicationRuleModel{
+		Id:                        types.StringValue("rule-1"),
+		Priority:                  prior.Priority,
+		Status:                    replicationStatusOr(replication.Status(prior.Status.ValueString()), replication.Enabled),
+		DestinationBucket:         prior.DestinationBucket,
+		StorageClass:              types.StringNull(),
+		DeleteMarkerReplication:   replicationStatusOr(replication.Status(prior.DeleteMarkerReplication.ValueString()), replication.Disabled),
+		DeleteReplication:         replicationStatusOr(replication.Status(prior.DeleteReplication.ValueString()), replication.Disabled),
+		ExistingObjectReplication: types.StringValue(string(replication.Disabled)),
+		ReplicaModifications:      types.StringValue(string(replication.Enabled)),
+	}
+	if rule.Priority.IsNull() {
+		rule.Priority = types.Int64Value(1)
+	}
+	upgraded := bucketReplicationResourceModel{
+		Bucket: prior.Bucket,
+		Role:   stringOrNull(prior.Role.ValueString()),
+		Rule:   []replicationRuleModel{rule},
 	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+}
+
+func buildReplicationConfig(ctx context.Context, plan bucketReplicationResourceModel) (replication.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var rules []replication.Rule
 
-	rules = append(rules, rule)
+	for _, r := range plan.Rule {
+		rule := replication.Rule{
+			ID:       r.Id.ValueString(),
+			Status:   replication.Status(r.Status.ValueString()),
+			Priority: int(r.Priority.ValueInt64()),
+			Destination: replication.Destination{
+				Bucket:       r.DestinationBucket.ValueString(),
+				StorageClass: r.StorageClass.ValueString(),
+			},
+			DeleteMarkerReplication: replication.DeleteMarkerReplication{
+				Status: replication.Status(r.DeleteMarkerReplication.ValueString()),
+			},
+			DeleteReplication: replication.DeleteReplication{
+				Status: replication.Status(r.DeleteReplication.ValueString()),
+			},
+			ExistingObjectReplication: replication.ExistingObjectReplication{
+				Status: replication.Status(r.ExistingObjectReplication.ValueString()),
+			},
+			SourceSelectionCriteria: replication.SourceSelectionCriteria{
+				ReplicaModifications: replication.ReplicaModifications{
+					Status: replication.Status(r.ReplicaModifications.ValueString()),
+				},
+			},
+		}
+
+		if r.Filter != nil {
+			var tags map[string]string
+			diags.Append(r.Filter.Tags.ElementsAs(ctx, &tags, false)...)
+			prefix := r.Filter.Prefix.ValueString()
+			// S3 accepts a single predicate only, several go into And.
+			switch {
+			case len(tags) == 0:
+				rule.Filter.Prefix = prefix
+			case len(tags) == 1 && prefix == "":
+				for key, value := range tags {
+					rule.Filter.Tag = replication.Tag{Key: key, Value: value}
+				}
+			default:
+				rule.Filter.And.Prefix = prefix
+				for _, key := range slices.Sorted(maps.Keys(tags)) {
+					rule.Filter.And.Tags = append(rule.Filter.And.Tags, replication.Tag{Key: key, Value: tags[key]})
+				}
+			}
+		}
+
+		rules = append(rules, rule)
+	}
 
 	return replication.Config{
 		Role:  plan.Role.ValueString(),
 		Rules: rules,
+	}, diags
+}
+
+// replicationStatusOr returns status or fallback if the server left it out.
+func replicationStatusOr(status replication.Status, fallback replication.Status) types.String {
+	if status == "" {
+		status = fallback
+	}
+	return types.StringValue(string(status))
+}
+
+// flattenReplicationRules converts the rules read from the server. Rules
+// keep the order of prior, matched by id, so a server which sorts the rules
+// differently does not cause a diff. New rules follow in server order.
+func flattenReplicationRules(rules []replication.Rule, prior []replicationRuleModel) []replicationRuleModel {
+	models := make([]replicationRuleModel, 0, len(rules))
+	for _, rule := range rules {
+		m := replicationRuleModel{
+			Id:                        types.StringValue(rule.ID),
+			Priority:                  types.Int64Value(int64(rule.Priority)),
+			Status:                    types.StringValue(string(rule.Status)),
+			DestinationBucket:         types.StringValue(rule.Destination.Bucket),
+			StorageClass:              stringOrNull(rule.Destination.StorageClass),
+			DeleteMarkerReplication:   replicationStatusOr(rule.DeleteMarkerReplication.Status, replication.Disabled),
+			DeleteReplication:         replicationStatusOr(rule.DeleteReplication.Status, replication.Disabled),
+			ExistingObjectReplication: replicationStatusOr(rule.ExistingObjectReplication.Status, replication.Disabled),
+			ReplicaModifications:      replicationStatusOr(rule.SourceSelectionCriteria.ReplicaModifications.Status, replication.Enabled),
+		}
+
+		tags := map[string]attr.Value{}
+		prefix := rule.Filter.Prefix
+		if rule.Filter.Tag.Key != "" {
+			tags[rule.Filter.Tag.Key] = types.StringValue(rule.Filter.Tag.Value)
+		}
+		if rule.Filter.And.Prefix != "" || len(rule.Filter.And.Tags) > 0 {
+			prefix = rule.Filter.And.Prefix
+			for _, tag := range rule.Filter.And.Tags {
+				tags[tag.Key] = types.StringValue(tag.Value)
+			}
+		}
+		if prefix != "" || len(tags) > 0 {
+			m.Filter = &replicationFilterModel{
+				Prefix: stringOrNull(prefix),
+				Tags:   types.MapNull(types.StringType),
+			}
+			if len(tags) > 0 {
+				m.Filter.Tags, _ = types.MapValue(types.StringType, tags)
+			}
+		}
+
+		models = append(models, m)
+	}
+
+	position := func(rule replicationRuleModel) int {
+		i := slices.IndexFunc(prior, func(p replicationRuleModel) bool {
+			return p.Id.Equal(rule.Id)
+		})
+		if i < 0 {
+			return len(prior)
+		}
+		return i
 	}
+	slices.SortStableFunc(models, func(a, b replicationRuleModel) int {
+		return position(a) - position(b)
+	})
+	return models
 }
@@ -1,21 +1,58 @@
 package provider
 
 import (
+	"context"
+	"reflect"
+	"slices"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
 )
 
+func testReplicationRule(id string, priority int64) replicationRuleModel {
+	return replicationRuleModel{
+		Id:                        types.StringValue(id),
+		Priority:                  types.Int64Value(priority),
+		Status:                    types.StringValue("Enabled"),
+		DestinationBucket:         types.StringValue("arn:aws:s3:::dest"),
+		StorageClass:              types.StringNull(),
+		DeleteMarkerReplication:   types.StringValue("Disabled"),
+		DeleteReplication:         types.StringValue("Disabled"),
+		ExistingObjectReplication: types.StringValue("Disabled"),
+		ReplicaModifications:      types.StringValue("Enabled"),
+	}
+}
+
+func testReplicationTags(t *testing.T, tags map[string]string) types.Map {
+	t.Helper()
+	elements := map[string]attr.Value{}
+	for key, value := range tags {
+		elements[key] = types.StringValue(value)
+	}
+	m, diags := types.MapValue(types.StringType, elements)
+	if diags.HasError() {
+		t.Fatalf("map diagnostics: %v", diags)
+	}
+	return m
+}
+
 func TestBuildReplicationConfig_basic(t *testing.T) {
 	plan := bucketReplicationResourceModel{
-		Bucket:            types.StringValue("source-bucket"),
-		Role:              types.StringValue("arn:minio:replication::id:src"),
-		DestinationBucket: types.StringValue("arn:aws:s3:::dest"),
-		Priority:          types.Int64Value(1),
-		Status:            types.StringValue("Enabled"),
+		Bucket: types.StringValue("source-bucket"),
+		Role:   types.StringValue("arn:minio:replication::id:src"),
+		Rule:   []replicationRuleModel{testReplicationRule("rule-1", 1)},
 	}
 
-	cfg := buildReplicationConfig(plan)
+	cfg, diags := buildReplicationConfig(context.Background(), plan)
+	if diags.HasError() {
+		t.Fatalf("build diagnostics: %v", diags)
+	}
 
 	if cfg.Role != "arn:minio:replication::id:src" {
 		t.Errorf("expected role, got %s", cfg.Role)
@@ -32,23 +69,207 @@ func TestBuildReplicationConfig_basic(t *testing.T) {
 }
 
 func TestBuildReplicationConfig_deleteReplication(t *testing.T) {
+	rule := testReplicationRule("rule-1", 1)
+	rule.DeleteMarkerReplication = types.StringValue("Enabled")
 	plan := bucketReplicationResourceModel{
-		Bucket:                 types.StringValue("source"),
-		Role:                   types.StringValue("arn:minio:replication::id:src"),
-		DestinationBucket:      types.StringValue("arn:aws:s3:::dest"),
-		Priority:               types.Int64Value(1),
-		Status:                 types.StringValue("Enabled"),
-		DeleteMarkerReplication: types.StringValue("Enabled"),
-		DeleteReplication:      types.StringValue("Disabled"),
+		Bucket: types.StringValue("source"),
+		Rule:   []replicationRuleModel{rule},
+	}
+
+	cfg, _ := buildReplicationConfig(context.Background(), plan)
+	built := cfg.Rules[0]
+
+	if built.DeleteMarkerReplication.Status != "Enabled" {
+		t.Errorf("expected Enabled, got %s", built.DeleteMarkerReplication.Status)
+	}
+	if built.DeleteReplication.Status != "Disabled" {
+		t.Errorf("expected Disabled, got %s", built.DeleteReplication.Status)
+	}
+}
+
+func TestBuildReplicationConfig_filters(t *testing.T) {
+	ctx := context.Background()
+	prefixOnly := testReplicationRule("prefix", 1)
+	prefixOnly.Filter = &replicationFilterModel{Prefix: types.StringValue("logs/"), Tags: types.MapNull(types.StringType)}
+	tagOnly := testReplicationRule("tag", 2)
+	tagOnly.Filter = &replicationFilterModel{Prefix: types.StringNull(), Tags: testReplicationTags(t, map[string]string{"class": "hot"})}
+	combined := testReplicationRule("and", 3)
+	combined.Filter = &replicationFilterModel{Prefix: types.StringValue("data/"), Tags: testReplicationTags(t, map[string]string{"team": "ops", "class": "hot"})}
+	plan := bucketReplicationResourceModel{Rule: []replicationRuleModel{prefixOnly, tagOnly, combined}}
+
+	cfg, diags := buildReplicationConfig(ctx, plan)
+	if diags.HasError() {
+		t.Fatalf("build diagnostics: %v", diags)
+	}
+	if cfg.Rules[0].Filter.Prefix != "logs/" {
+		t.Errorf("expected prefix filter, got %+v", cfg.Rules[0].Filter)
+	}
+	if cfg.Rules[1].Filter.Tag.Key != "class" {
+		t.Errorf("expected tag filter, got %+v", cfg.Rules[1].Filter)
+	}
+	and := cfg.Rules[2].Filter.And
+	if and.Prefix != "data/" || len(and.Tags) != 2 || and.Tags[0].Key != "class" {
+		t.Errorf("expected sorted And filter, got %+v", and)
+	}
+
+	if flattened := flattenReplicationRules(cfg.Rules, nil); !reflect.DeepEqual(flattened, plan.Rule) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", flattened, plan.Rule)
+	}
+}
+
+func TestReplicationFilterValidator(t *testing.T) {
+	attrTypes := map[string]attr.Type{"prefix": types.StringType, "tags": types.MapType{ElemType: types.StringType}}
+	for name, tc := range map[string]struct {
+		filter types.Object
+		valid  bool
+	}{
+		"no filter": {types.ObjectNull(attrTypes), true},
+		"empty": {types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			"prefix": types.StringNull(),
+			"tags":   types.MapNull(types.StringType),
+		}), false},
+		"prefix": {types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			"prefix": types.StringValue("logs/"),
+			"tags":   types.MapNull(types.StringType),
+		}), true},
+		"tags": {types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			"prefix": types.StringNull(),
+			"tags":   testReplicationTags(t, map[string]string{"class": "hot"}),
+		}), true},
+	} {
+		resp := &validator.ObjectResponse{}
+		replicationFilterValidator{}.ValidateObject(context.Background(), validator.ObjectRequest{
+			Path:        path.Root("rule").AtListIndex(0).AtName("filter"),
+			ConfigValue: tc.filter,
+		}, resp)
+		if resp.Diagnostics.HasError() == tc.valid {
+			t.Errorf("%s: unexpected diagnostics %v", name, resp.Diagnostics)
+		}
+	}
+}
+
+func TestBucketReplicationResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	if err := client.Minio.MakeBucket(ctx, "source", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Register the destination first and replicate to its ARN.
+	targets := NewBucketRemoteTargetResource()
+	targetPlan := testResourceState(t, targets, client)
+	var target bucketRemoteTargetResourceModel
+	targetPlan.Get(ctx, &target)
+	target.Bucket = types.StringValue("source")
+	target.Endpoint = types.StringValue("replica.example.com:9000")
+	target.TargetBucket = types.StringValue("replica")
+	target.AccessKey = types.StringValue("replicator")
+	target.SecretKey = types.StringValue("replicator-secret")
+	target.Secure = types.BoolValue(true)
+	target.ReplicationSync = types.BoolValue(false)
+	target.DisableProxy = types.BoolValue(false)
+	target.Arn = types.StringUnknown()
+	targetPlan.Set(ctx, &target)
+	targetResp := &resource.CreateResponse{State: testResourceState(t, targets, client)}
+	targets.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(targetPlan)}, targetResp)
+	if targetResp.Diagnostics.HasError() {
+		t.Fatalf("remote target diagnostics: %v", targetResp.Diagnostics)
+	}
+	targetResp.State.Get(ctx, &target)
+
+	first := testReplicationRule("logs", 2)
+	first.DestinationBucket = target.Arn
+	first.Filter = &replicationFilterModel{Prefix: types.StringValue("logs/"), Tags: types.MapNull(types.StringType)}
+	second := testReplicationRule("everything", 1)
+	second.DestinationBucket = target.Arn
+	second.ExistingObjectReplication = types.StringValue("Enabled")
+	rules := []replicationRuleModel{first, second}
+
+	r := NewBucketReplicationResource()
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &bucketReplicationResourceModel{
+		Bucket: types.StringValue("source"),
+		Role:   types.StringNull(),
+		Rule:   rules,
+	})
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
 	}
 
-	cfg := buildReplicationConfig(plan)
-	rule := cfg.Rules[0]
+	// Import only knows the bucket name, Read fills in all rules.
+	importResp := &resource.ImportStateResponse{State: testResourceState(t, r, client)}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "source"}, importResp)
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var imported bucketReplicationResourceModel
+	readResp.State.Get(ctx, &imported)
+	if !reflect.DeepEqual(imported.Rule, rules) {
+		t.Errorf("unexpected imported rules:\n got %+v\nwant %+v", imported.Rule, rules)
+	}
+
+	// The server returns the rules in reverse order, the state keeps the
+	// configured order.
+	cfg, err := client.Minio.GetBucketReplication(ctx, "source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Reverse(cfg.Rules)
+	if err := client.Minio.SetBucketReplication(ctx, "source", cfg); err != nil {
+		t.Fatal(err)
+	}
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var reordered bucketReplicationResourceModel
+	readResp.State.Get(ctx, &reordered)
+	if !reflect.DeepEqual(reordered.Rule, rules) {
+		t.Errorf("unexpected rules after reordering:\n got %+v\nwant %+v", reordered.Rule, rules)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected removed replication to be removed from state")
+	}
+}
+
+func TestBucketReplicationResourceUpgradeState_V0(t *testing.T) {
+	ctx := context.Background()
+	r := NewBucketReplicationResource()
+	upgrader := r.(resource.ResourceWithUpgradeState).UpgradeState(ctx)[0]
+	prior := tfsdk.State{Schema: *upgrader.PriorSchema, Raw: testNullObject(upgrader.PriorSchema.Type())}
+	prior.Set(ctx, &bucketReplicationResourceModelV0{
+		Bucket:                  types.StringValue("source"),
+		Role:                    types.StringValue(""),
+		DestinationBucket:       types.StringValue("arn:aws:s3:::dest"),
+		Priority:                types.Int64Value(2),
+		Status:                  types.StringValue("Enabled"),
+		DeleteMarkerReplication: types.StringValue("Enabled"),
+		DeleteReplication:       types.StringNull(),
+	})
 
-	if rule.DeleteMarkerReplication.Status != "Enabled" {
-		t.Errorf("expected Enabled, got %s", rule.DeleteMarkerReplication.Status)
+	resp := &resource.UpgradeStateResponse{State: testResourceState(t, r, nil)}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrade diagnostics: %v", resp.Diagnostics)
 	}
-	if rule.DeleteReplication.Status != "Disabled" {
-		t.Errorf("expected Disabled, got %s", rule.DeleteReplication.Status)
+	var upgraded bucketReplicationResourceModel
+	resp.State.Get(ctx, &upgraded)
+	want := testReplicationRule("rule-1", 2)
+	want.DeleteMarkerReplication = types.StringValue("Enabled")
+	if upgraded.Bucket.ValueString() != "source" || !upgraded.Role.IsNull() || !reflect.DeepEqual(upgraded.Rule, []replicationRuleModel{want}) {
+		t.Errorf("unexpected upgraded state %+v", upgraded)
 	}
 }
@@ -3,21 +3,28 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/minio/minio-go/v7"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
 	_ resource.Resource                = &bucketRessource{}
 	_ resource.ResourceWithImportState = &bucketRessource{}
+	_ resource.ResourceWithModifyPlan  = &bucketRessource{}
 )
 
 // NewbucketRessource is a helper function to simplify the provider implementation.
@@ -30,8 +37,18 @@ type bucketRessource struct {
 	client *AllClient
 }
 
+// emptyBucketWorkers is the number of concurrent RemoveObjects calls used by
+// force_destroy. Each call deletes in batches of up to 1000 objects.
+const emptyBucketWorkers = 4
+
 type bucketResourceModel struct {
-	Name types.String `tfsdk:"name"`
+	Name              types.String `tfsdk:"name"`
+	Region            types.String `tfsdk:"region"`
+	CreationDate      types.String `tfsdk:"creation_date"`
+	ForceDestroy      types.Bool   `tfsdk:"force_destroy"`
+	ObjectLockEnabled types.Bool   `tfsdk:"object_lock_enabled"`
+	Tags              types.Map    `tfsdk:"tags"`
+	TagsAll           types.Map    `tfsdk:"tags_all"`
 }
 
 // Metadata returns the resource type name.
@@ -52,6 +69,39 @@ func (r *bucketRessource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"region": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Region the bucket is created in. Defaults to the provider region",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creation_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "Creation date of the bucket in RFC 3339 format",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"force_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Delete all objects, versions and delete markers on destroy so a non-empty bucket can be removed. Governance retention is bypassed, compliance retention and legal holds still prevent deletion",
+			},
+			"object_lock_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Create the bucket with object lock enabled. Required by rustfs_bucket_object_lock and implies versioning",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"tags":     tagsAttribute(),
+			"tags_all": tagsAllAttribute(),
 		},
 	}
 }
@@ -98,8 +148,12 @@ func (r *bucketRessource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	if plan.Region.IsNull() || plan.Region.IsUnknown() {
+		plan.Region = types.StringValue(r.client.Region)
+	}
 	err = r.client.Minio.MakeBucket(ctx, plan.Name.ValueString(), minio.MakeBucketOptions{
-		Region: "us-east-1",
+		Region:        plan.Region.ValueString(),
+		ObjectLocking: plan.ObjectLockEnabled.ValueBool(),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -109,9 +163,99 @@ func (r *bucketRessource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 	tflog.Trace(ctx, "created a resource")
+
+	applied, diags := expandTags(ctx, r.client, plan.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(applied) > 0 {
+		if err := setBucketTags(ctx, r.client.Minio, plan.Name.ValueString(), applied); err != nil {
+			resp.Diagnostics.AddError(
+				"Error tagging bucket",
+				"Could not set bucket tags: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	found, err := r.readBucket(ctx, &plan)
+	if err == nil && !found {
+		err = fmt.Errorf("bucket %s not found after creation", plan.Name.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading bucket",
+			"Could not read bucket after creation: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(r.readTags(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+// readTags refreshes tags and tags_all of the bucket in model.
+func (r *bucketRessource) readTags(ctx context.Context, model *bucketResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	applied, err := getBucketTags(ctx, r.client.Minio, model.Name.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error reading bucket tags",
+			"Could not read bucket tags: "+err.Error(),
+		)
+		return diags
+	}
+	model.Tags, model.TagsAll, diags = flattenTags(ctx, r.client, applied, model.Tags)
+	return diags
+}
+
+// readBucket refreshes region, creation date and object lock state of the
+// bucket in model. It reports false if the bucket does not exist.
+func (r *bucketRessource) readBucket(ctx context.Context, model *bucketResourceModel) (bool, error) {
+	name := model.Name.ValueString()
+	exists, err := r.client.Minio.BucketExists(ctx, name)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	region, err := r.client.Minio.GetBucketLocation(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	model.Region = types.StringValue(region)
+
+	lock, _, _, _, err := r.client.Minio.GetObjectLockConfig(ctx, name)
+	switch {
+	case err == nil:
+		model.ObjectLockEnabled = types.BoolValue(lock == "Enabled")
+	case rustfs.IsNotFound(err):
+		model.ObjectLockEnabled = types.BoolValue(false)
+	default:
+		return false, err
+	}
+	// force_destroy only exists in Terraform, e.g. it is unset after import.
+	if model.ForceDestroy.IsNull() {
+		model.ForceDestroy = types.BoolValue(false)
+	}
+
+	// S3 has no per-bucket call for the creation date.
+	buckets, err := r.client.Minio.ListBuckets(ctx)
+	if err != nil {
+		return false, err
+	}
+	model.CreationDate = types.StringNull()
+	for _, bucket := range buckets {
+		if bucket.Name == name {
+			model.CreationDate = types.StringValue(bucket.CreationDate.UTC().Format(time.RFC3339))
+			break
+		}
+	}
+	return true, nil
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (r *bucketRessource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state bucketResourceModel
@@ -120,11 +264,24 @@ func (r *bucketRessource) Read(ctx context.Context, req resource.ReadRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
+	found, err := r.readBucket(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading bucket",
+			"Could not read bucket: "+err.Error(),
+		)
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(r.readTags(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
 	// Save update status
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -134,13 +291,31 @@ func (r *bucketRessource) Read(ctx context.Context, req resource.ReadRequest, re
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *bucketRessource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan bucketResourceModel
+	var plan, state bucketResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	applied, diags := expandTags(ctx, r.client, plan.Tags)
+	resp.Diagnostics.Append(diags...)
+	plan.TagsAll, diags = types.MapValueFrom(ctx, types.StringType, applied)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plan.TagsAll.Equal(state.TagsAll) {
+		if err := setBucketTags(ctx, r.client.Minio, plan.Name.ValueString(), applied); err != nil {
+			resp.Diagnostics.AddError(
+				"Error tagging bucket",
+				"Could not update bucket tags: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -159,6 +334,16 @@ func (r *bucketRessource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	if data.ForceDestroy.ValueBool() {
+		if err := emptyBucket(ctx, r.client.Minio, data.Name.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting bucket",
+				"Could not empty bucket: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	err := r.client.Minio.RemoveBucket(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -168,6 +353,84 @@ func (r *bucketRessource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+// emptyBucket removes every object version and delete marker of bucket. One
+// lister feeds several concurrent RemoveObjects calls.
+func emptyBucket(ctx context.Context, client *minio.Client, bucket string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objects := make(chan minio.ObjectInfo)
+	listDone := make(chan struct{})
+	var listErr error
+	go func() {
+		defer close(listDone)
+		defer close(objects)
+		for object := range client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Recursive: true, WithVersions: true}) {
+			if object.Err != nil {
+				listErr = object.Err
+				return
+			}
+			select {
+			case objects <- object:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		failed    int
+		removeErr error
+	)
+	for range emptyBucketWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			opts := minio.RemoveObjectsOptions{GovernanceBypass: true}
+			for result := range client.RemoveObjects(ctx, bucket, objects, opts) {
+				mu.Lock()
+				if failed == 0 {
+					removeErr = fmt.Errorf("%s (version %s): %w", result.ObjectName, result.VersionID, result.Err)
+				}
+				failed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	cancel()
+	<-listDone
+
+	if listErr != nil {
+		return fmt.Errorf("listing objects: %w", listErr)
+	}
+	if failed > 0 {
+		return fmt.Errorf("could not remove %d objects, first error: %w", failed, removeErr)
+	}
+	return nil
+}
+
+func (r *bucketRessource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planTagsAll(ctx, r.client, req, resp)
+}
+
 func (r *bucketRessource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	exists, err := r.client.Minio.BucketExists(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing bucket",
+			"Could not check bucket existence: "+err.Error(),
+		)
+		return
+	}
+	if !exists {
+		resp.Diagnostics.AddError(
+			"Error importing bucket",
+			fmt.Sprintf("Bucket %q does not exist", req.ID),
+		)
+		return
+	}
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
@@ -1,11 +1,186 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/minio/minio-go/v7"
 )
 
+func TestBucketResourceSchema(t *testing.T) {
+	r := NewBucketRessource()
+	resp := &fwresource.SchemaResponse{}
+	r.Schema(nil, fwresource.SchemaRequest{}, resp)
+
+	if diags := resp.Diagnostics; diags.HasError() {
+		t.Fatalf("schema diagnostics: %v", diags)
+	}
+
+	region, ok := resp.Schema.GetAttributes()["region"].(schema.StringAttribute)
+	if !ok {
+		t.Fatal("expected region string attribute")
+	}
+	if !region.Optional || !region.Computed {
+		t.Error("expected region to be optional and computed")
+	}
+}
+
+func testCreateBucket(t *testing.T, r fwresource.Resource, client *AllClient, name string, modify ...func(*bucketResourceModel)) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	plan := testResourceState(t, r, client)
+	var model bucketResourceModel
+	plan.Get(ctx, &model)
+	model.Name = types.StringValue(name)
+	model.Region = types.StringUnknown()
+	model.CreationDate = types.StringUnknown()
+	model.ForceDestroy = types.BoolValue(false)
+	model.ObjectLockEnabled = types.BoolValue(false)
+	for _, m := range modify {
+		m(&model)
+	}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("set diagnostics: %v", diags)
+	}
+
+	resp := &fwresource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan(plan)}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", resp.Diagnostics)
+	}
+	return resp.State
+}
+
+func TestBucketResourceDrift(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	r := NewBucketRessource()
+	state := testCreateBucket(t, r, client, "drift")
+
+	var created bucketResourceModel
+	state.Get(ctx, &created)
+	if created.Region.ValueString() != "us-east-1" {
+		t.Errorf("expected provider region, got %q", created.Region.ValueString())
+	}
+	if created.CreationDate.IsNull() || created.CreationDate.IsUnknown() {
+		t.Error("expected creation date to be set")
+	}
+
+	readResp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	if readResp.State.Raw.IsNull() {
+		t.Fatal("expected existing bucket to stay in state")
+	}
+
+	if err := client.Minio.RemoveBucket(ctx, "drift"); err != nil {
+		t.Fatal(err)
+	}
+	readResp = &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected bucket deleted outside of terraform to be removed from state")
+	}
+}
+
+// Servers report a bucket without object lock with one of two codes.
+func TestBucketResourceRead_NoObjectLockConfiguration(t *testing.T) {
+	ctx := context.Background()
+	client, server := testProviderClient(t)
+	server.SetNotFoundCode("object-lock", "NoSuchObjectLockConfiguration")
+	r := NewBucketRessource()
+	state := testCreateBucket(t, r, client, "unlocked")
+
+	readResp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var read bucketResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.ObjectLockEnabled.ValueBool() {
+		t.Error("expected object lock to be disabled")
+	}
+}
+
+func TestBucketResourceImport(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	r := NewBucketRessource()
+	testCreateBucket(t, r, client, "existing")
+
+	resp := &fwresource.ImportStateResponse{State: testResourceState(t, r, client)}
+	r.(fwresource.ResourceWithImportState).ImportState(ctx, fwresource.ImportStateRequest{ID: "existing"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("import diagnostics: %v", resp.Diagnostics)
+	}
+	var name types.String
+	resp.State.GetAttribute(ctx, path.Root("name"), &name)
+	if name.ValueString() != "existing" {
+		t.Errorf("expected imported name, got %q", name.ValueString())
+	}
+
+	resp = &fwresource.ImportStateResponse{State: testResourceState(t, r, client)}
+	r.(fwresource.ResourceWithImportState).ImportState(ctx, fwresource.ImportStateRequest{ID: "unknown"}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected import of unknown bucket to fail")
+	}
+}
+
+func TestBucketResourceForceDestroy(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	r := NewBucketRessource()
+	state := testCreateBucket(t, r, client, "locked", func(m *bucketResourceModel) {
+		m.ObjectLockEnabled = types.BoolValue(true)
+	})
+
+	var created bucketResourceModel
+	state.Get(ctx, &created)
+	if !created.ObjectLockEnabled.ValueBool() {
+		t.Error("expected object lock to be enabled")
+	}
+	for i := range 25 {
+		content := strings.Repeat("x", i)
+		if _, err := client.Minio.PutObject(ctx, "locked", fmt.Sprintf("dir/%d", i%5), strings.NewReader(content), int64(len(content)), minio.PutObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := client.Minio.RemoveObject(ctx, "locked", "dir/0", minio.RemoveObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	deleteResp := &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, deleteResp)
+	if !deleteResp.Diagnostics.HasError() {
+		t.Fatal("expected delete of non-empty bucket to fail without force_destroy")
+	}
+
+	created.ForceDestroy = types.BoolValue(true)
+	state.Set(ctx, &created)
+	deleteResp = &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	if exists, _ := client.Minio.BucketExists(ctx, "locked"); exists {
+		t.Error("expected bucket to be removed")
+	}
+}
+
 func TestAccBucketResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -18,6 +193,7 @@ resource "rustfs_bucket" "test" {
 }
 `, Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("rustfs_bucket.test", "name", "somebucket"),
+					resource.TestCheckResourceAttrSet("rustfs_bucket.test", "creation_date"),
 				)},
 		}})
 }
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+var (
+	_ resource.Resource                = &BucketTaggingResource{}
+	_ resource.ResourceWithImportState = &BucketTaggingResource{}
+	_ resource.ResourceWithModifyPlan  = &BucketTaggingResource{}
+)
+
+type BucketTaggingResource struct {
+	client *AllClient
+}
+
+type BucketTaggingResourceModel struct {
+	Bucket  types.String `tfsdk:"bucket"`
+	Tags    types.Map    `tfsdk:"tags"`
+	TagsAll types.Map    `tfsdk:"tags_all"`
+}
+
+func NewBucketTaggingResource() resource.Resource {
+	return &BucketTaggingResource{}
+}
+
+func (r *BucketTaggingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_tagging"
+}
+
+func (r *BucketTaggingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	tags := tagsAttribute()
+	tags.Optional = false
+	tags.Required = true
+	resp.Schema = schema.Schema{
+		Description:         "Manage RustFS bucket tags",
+		MarkdownDescription: "Manage the tags of a RustFS bucket. Do not combine with the `tags` attribute of `rustfs_bucket` for the same bucket.",
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the bucket.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tags":     tags,
+			"tags_all": tagsAllAttribute(),
+		},
+	}
+}
+
+func (r *BucketTaggingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*AllClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AllClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *BucketTaggingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan BucketTaggingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketTaggingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state BucketTaggingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applied, err := getBucketTags(ctx, r.client.Minio, state.Bucket.ValueString())
+	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading bucket tags",
+			"Could not read bucket tags: "+err.Error(),
+		)
+		return
+	}
+	// Tags removed outside of Terraform remove the resource. A resource
+	// applying no tags at all, e.g. tags = {} without default_tags, stays.
+	if len(applied) == 0 && len(state.TagsAll.Elements()) > 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	tags, tagsAll, diags := flattenTags(ctx, r.client, applied, state.Tags)
+	resp.Diagnostics.Append(diags...)
+	state.Tags, state.TagsAll = tags, tagsAll
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BucketTaggingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan BucketTaggingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BucketTaggingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BucketTaggingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Minio.RemoveBucketTagging(ctx, data.Bucket.ValueString())
+	if err != nil && !rustfs.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error removing bucket tags",
+			"Could not remove bucket tags: "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *BucketTaggingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planTagsAll(ctx, r.client, req, resp)
+}
+
+func (r *BucketTaggingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("bucket"), req, resp)
+}
+
+// apply sets the merged tags on the bucket and records them in tags_all.
+func (r *BucketTaggingResource) apply(ctx context.Context, plan *BucketTaggingResourceModel) diag.Diagnostics {
+	applied, diags := expandTags(ctx, r.client, plan.Tags)
+	if diags.HasError() {
+		return diags
+	}
+	if err := setBucketTags(ctx, r.client.Minio, plan.Bucket.ValueString(), applied); err != nil {
+		diags.AddError(
+			"Error setting bucket tags",
+			"Could not set bucket tags: "+err.Error(),
+		)
+		return diags
+	}
+	var d diag.Diagnostics
+	plan.TagsAll, d = types.MapValueFrom(ctx, types.StringType, applied)
+	diags.Append(d...)
+	return diags
+}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/minio/minio-go/v7"
@@ -24,8 +29,11 @@ type BucketVersioningResource struct {
 }
 
 type BucketVersioningResourceModel struct {
-	Bucket types.String `tfsdk:"bucket"`
-	Status types.String `tfsdk:"status"`
+	Bucket           types.String `tfsdk:"bucket"`
+	Status           types.String `tfsdk:"status"`
+	ExcludedPrefixes types.List   `tfsdk:"excluded_prefixes"`
+	ExcludeFolders   types.Bool   `tfsdk:"exclude_folders"`
+	MfaDelete        types.String `tfsdk:"mfa_delete"`
 }
 
 func NewBucketVersioningResource() resource.Resource {
@@ -51,6 +59,35 @@ func (r *BucketVersioningResource) Schema(_ context.Context, _ resource.SchemaRe
 			"status": schema.StringAttribute{
 				Required:    true,
 				Description: "Versioning status: Enabled or Suspended.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(minio.Enabled, minio.Suspended),
+				},
+			},
+			"excluded_prefixes": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Object key prefixes excluded from versioning, e.g. _temporary/. Requires status Enabled.",
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 10),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"exclude_folders": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Exclude folder objects (keys ending in /) from versioning. Requires status Enabled. Default: false.",
+			},
+			"mfa_delete": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "MFA delete status: Enabled or Disabled. The apply fails if the server does not apply it. No MFA token is sent, so servers which require one for the change reject it.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("Enabled", "Disabled"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -78,9 +115,12 @@ func (r *BucketVersioningResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	err := r.client.Minio.SetBucketVersioning(ctx, plan.Bucket.ValueString(), minio.BucketVersioningConfiguration{
-		Status: plan.Status.ValueString(),
-	})
+	config, diags := buildVersioningConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.Minio.SetBucketVersioning(ctx, plan.Bucket.ValueString(), config)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error setting bucket versioning",
@@ -90,6 +130,10 @@ func (r *BucketVersioningResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	tflog.Trace(ctx, "created bucket versioning")
+	resp.Diagnostics.Append(r.readMfaDelete(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -109,7 +153,7 @@ func (r *BucketVersioningResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	state.Status = types.StringValue(config.Status)
+	resp.Diagnostics.Append(flattenVersioningConfig(ctx, config, &state)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -120,9 +164,12 @@ func (r *BucketVersioningResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	err := r.client.Minio.SetBucketVersioning(ctx, plan.Bucket.ValueString(), minio.BucketVersioningConfiguration{
-		Status: plan.Status.ValueString(),
-	})
+	config, diags := buildVersioningConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.Minio.SetBucketVersioning(ctx, plan.Bucket.ValueString(), config)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating bucket versioning",
@@ -131,6 +178,10 @@ func (r *BucketVersioningResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	resp.Diagnostics.Append(r.readMfaDelete(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -154,3 +205,86 @@ func (r *BucketVersioningResource) Delete(ctx context.Context, req resource.Dele
 func (r *BucketVersioningResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("bucket"), req, resp)
 }
+
+// readMfaDelete reads back the MFA delete status. Servers which do not
+// support MFA delete ignore it, so a configured status they did not apply
+// is an error instead of a permanent diff. If the status was not
+// configured, the one the server reports is used.
+func (r *BucketVersioningResource) readMfaDelete(ctx context.Context, plan *BucketVersioningResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	config, err := r.client.Minio.GetBucketVersioning(ctx, plan.Bucket.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error reading bucket versioning",
+			"Could not read bucket versioning: "+err.Error(),
+		)
+		return diags
+	}
+	if plan.MfaDelete.IsUnknown() {
+		plan.MfaDelete = stringOrNull(config.MFADelete)
+		return diags
+	}
+	if !mfaDeleteEqual(plan.MfaDelete.ValueString(), config.MFADelete) {
+		diags.AddAttributeError(
+			path.Root("mfa_delete"),
+			"MFA delete not applied",
+			fmt.Sprintf("The server reports MFA delete %q instead of %q. The server may not support MFA delete, or require an MFA token for the change, which the provider does not send. Remove mfa_delete from the configuration.",
+				config.MFADelete, plan.MfaDelete.ValueString()),
+		)
+	}
+	return diags
+}
+
+// mfaDeleteEqual reports whether the server status matches the configured
+// one. Servers without MFA delete report nothing, which means Disabled.
+func mfaDeleteEqual(configured, server string) bool {
+	return configured == server || (configured == "Disabled" && server == "")
+}
+
+func buildVersioningConfig(ctx context.Context, plan BucketVersioningResourceModel) (minio.BucketVersioningConfiguration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	config := minio.BucketVersioningConfiguration{
+		Status:         plan.Status.ValueString(),
+		ExcludeFolders: plan.ExcludeFolders.ValueBool(),
+	}
+	if !plan.MfaDelete.IsUnknown() {
+		config.MFADelete = plan.MfaDelete.ValueString()
+	}
+	var prefixes []string
+	diags.Append(plan.ExcludedPrefixes.ElementsAs(ctx, &prefixes, false)...)
+	for _, prefix := range prefixes {
+		config.ExcludedPrefixes = append(config.ExcludedPrefixes, minio.ExcludedPrefix{Prefix: prefix})
+	}
+
+	// The server only accepts exclusions while versioning is enabled.
+	if config.Status != minio.Enabled {
+		if len(prefixes) > 0 {
+			diags.AddAttributeError(path.Root("excluded_prefixes"), "Invalid versioning exclusion", "excluded_prefixes requires status Enabled.")
+		}
+		if config.ExcludeFolders {
+			diags.AddAttributeError(path.Root("exclude_folders"), "Invalid versioning exclusion", "exclude_folders requires status Enabled.")
+		}
+	}
+	return config, diags
+}
+
+func flattenVersioningConfig(ctx context.Context, config minio.BucketVersioningConfiguration, state *BucketVersioningResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	state.Status = types.StringValue(config.Status)
+	state.ExcludeFolders = types.BoolValue(config.ExcludeFolders)
+	if !mfaDeleteEqual(state.MfaDelete.ValueString(), config.MFADelete) {
+		state.MfaDelete = stringOrNull(config.MFADelete)
+	}
+
+	state.ExcludedPrefixes = types.ListNull(types.StringType)
+	if len(config.ExcludedPrefixes) > 0 {
+		prefixes := make([]string, 0, len(config.ExcludedPrefixes))
+		for _, excluded := range config.ExcludedPrefixes {
+			prefixes = append(prefixes, excluded.Prefix)
+		}
+		var d diag.Diagnostics
+		state.ExcludedPrefixes, d = types.ListValueFrom(ctx, types.StringType, prefixes)
+		diags.Append(d...)
+	}
+	return diags
+}
@@ -1,9 +1,14 @@
 package provider
 
 import (
+	"context"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
 )
 
 func TestBucketVersioningResourceSchema(t *testing.T) {
@@ -33,3 +38,116 @@ func TestBucketVersioningResourceMetadata(t *testing.T) {
 		t.Errorf("expected rustfs_bucket_versioning, got %s", resp.TypeName)
 	}
 }
+
+func TestBuildVersioningConfig_RequiresEnabled(t *testing.T) {
+	ctx := context.Background()
+	prefixes, _ := types.ListValueFrom(ctx, types.StringType, []string{"_temporary/"})
+	_, diags := buildVersioningConfig(ctx, BucketVersioningResourceModel{
+		Bucket:           types.StringValue("spark"),
+		Status:           types.StringValue("Suspended"),
+		ExcludedPrefixes: prefixes,
+		ExcludeFolders:   types.BoolValue(true),
+		MfaDelete:        types.StringNull(),
+	})
+	if diags.ErrorsCount() != 2 {
+		t.Errorf("expected errors for both exclusions, got %v", diags)
+	}
+}
+
+func TestBucketVersioningResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	if err := client.Minio.MakeBucket(ctx, "spark", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewBucketVersioningResource()
+	prefixes, _ := types.ListValueFrom(ctx, types.StringType, []string{"_temporary/", "checkpoints/"})
+	model := BucketVersioningResourceModel{
+		Bucket:           types.StringValue("spark"),
+		Status:           types.StringValue("Enabled"),
+		ExcludedPrefixes: prefixes,
+		ExcludeFolders:   types.BoolValue(true),
+		MfaDelete:        types.StringUnknown(),
+	}
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &model)
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+	config, err := client.Minio.GetBucketVersioning(ctx, "spark")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !config.ExcludeFolders || len(config.ExcludedPrefixes) != 2 || config.ExcludedPrefixes[0].Prefix != "_temporary/" {
+		t.Errorf("unexpected versioning configuration %+v", config)
+	}
+
+	importResp := &resource.ImportStateResponse{State: testResourceState(t, r, client)}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "spark"}, importResp)
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var read BucketVersioningResourceModel
+	readResp.State.Get(ctx, &read)
+	model.MfaDelete = types.StringNull()
+	if !reflect.DeepEqual(read, model) {
+		t.Errorf("unexpected state after read:\n got %+v\nwant %+v", read, model)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+}
+
+// The fake does not support MFA delete, like servers which ignore it.
+func TestBucketVersioningResourceCreate_MfaDeleteNotApplied(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	if err := client.Minio.MakeBucket(ctx, "records", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewBucketVersioningResource()
+	model := BucketVersioningResourceModel{
+		Bucket:           types.StringValue("records"),
+		Status:           types.StringValue("Enabled"),
+		ExcludedPrefixes: types.ListNull(types.StringType),
+		ExcludeFolders:   types.BoolValue(false),
+		MfaDelete:        types.StringValue("Enabled"),
+	}
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &model)
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Fatal("expected an error for MFA delete the server did not apply")
+	}
+	var stored BucketVersioningResourceModel
+	createResp.State.Get(ctx, &stored)
+	if !stored.MfaDelete.IsNull() {
+		t.Errorf("expected the unapplied MFA delete status not to be stored, got %s", stored.MfaDelete)
+	}
+
+	// Disabled matches a server without MFA delete support.
+	model.MfaDelete = types.StringValue("Disabled")
+	plan.Set(ctx, &model)
+	createResp = &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	var read BucketVersioningResourceModel
+	readResp.State.Get(ctx, &read)
+	if read.MfaDelete.ValueString() != "Disabled" {
+		t.Errorf("expected mfa_delete Disabled to be kept, got %s", read.MfaDelete)
+	}
+}
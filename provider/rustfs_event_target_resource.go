@@ -0,0 +1,612 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+var (
+	_ resource.Resource                = &EventTargetResource{}
+	_ resource.ResourceWithImportState = &EventTargetResource{}
+)
+
+// EventTargetResource manages a notification or audit target of one
+// backend, e.g. rustfs_notify_webhook or rustfs_audit_kafka. The backend
+// attributes are sent to the server as configuration keys of the same name.
+type EventTargetResource struct {
+	client  *AllClient
+	audit   bool
+	backend eventTargetBackend
+}
+
+type eventTargetBackend struct {
+	service    string
+	title      string
+	attributes map[string]schema.Attribute
+}
+
+func NewNotifyWebhookResource() resource.Resource {
+	return &EventTargetResource{backend: webhookTargetBackend}
+}
+
+func NewNotifyKafkaResource() resource.Resource {
+	return &EventTargetResource{backend: kafkaTargetBackend}
+}
+
+func NewNotifyNatsResource() resource.Resource {
+	return &EventTargetResource{backend: natsTargetBackend}
+}
+
+func NewNotifyMqttResource() resource.Resource {
+	return &EventTargetResource{backend: mqttTargetBackend}
+}
+
+func NewNotifyRedisResource() resource.Resource {
+	return &EventTargetResource{backend: redisTargetBackend}
+}
+
+func NewNotifyPostgresResource() resource.Resource {
+	return &EventTargetResource{backend: postgresTargetBackend}
+}
+
+func NewAuditWebhookResource() resource.Resource {
+	return &EventTargetResource{audit: true, backend: webhookTargetBackend}
+}
+
+func NewAuditKafkaResource() resource.Resource {
+	return &EventTargetResource{audit: true, backend: kafkaTargetBackend}
+}
+
+func NewAuditNatsResource() resource.Resource {
+	return &EventTargetResource{audit: true, backend: natsTargetBackend}
+}
+
+func NewAuditMqttResource() resource.Resource {
+	return &EventTargetResource{audit: true, backend: mqttTargetBackend}
+}
+
+func NewAuditRedisResource() resource.Resource {
+	return &EventTargetResource{audit: true, backend: redisTargetBackend}
+}
+
+func NewAuditPostgresResource() resource.Resource {
+	return &EventTargetResource{audit: true, backend: postgresTargetBackend}
+}
+
+var targetFormatValidator = stringvalidator.OneOf("namespace", "access")
+
+var webhookTargetBackend = eventTargetBackend{
+	service: "webhook",
+	title:   "webhook",
+	attributes: map[string]schema.Attribute{
+		"endpoint": schema.StringAttribute{
+			Required:    true,
+			Description: "URL the events are posted to.",
+		},
+		"auth_token": schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Token sent in the Authorization header.",
+		},
+		"client_cert": schema.StringAttribute{
+			Optional:    true,
+			Description: "Path to the client certificate for mTLS on the server.",
+		},
+		"client_key": schema.StringAttribute{
+			Optional:    true,
+			Description: "Path to the client key for mTLS on the server.",
+		},
+	},
+}
+
+var kafkaTargetBackend = eventTargetBackend{
+	service: "kafka",
+	title:   "Kafka",
+	attributes: map[string]schema.Attribute{
+		"brokers": schema.ListAttribute{
+			Required:    true,
+			ElementType: types.StringType,
+			Description: "Kafka brokers in host:port format.",
+			Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+		},
+		"topic": schema.StringAttribute{
+			Required:    true,
+			Description: "Topic the events are published to.",
+		},
+		"sasl": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Authenticate with SASL.",
+		},
+		"sasl_username": schema.StringAttribute{
+			Optional:    true,
+			Description: "SASL username.",
+		},
+		"sasl_password": schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			Description: "SASL password.",
+		},
+		"sasl_mechanism": schema.StringAttribute{
+			Optional:    true,
+			Description: "SASL mechanism: plain, sha256 or sha512.",
+			Validators:  []validator.String{stringvalidator.OneOf("plain", "sha256", "sha512")},
+		},
+		"tls": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Connect to the brokers with TLS.",
+		},
+		"tls_skip_verify": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Do not verify the broker certificates.",
+		},
+		"version": schema.StringAttribute{
+			Optional:    true,
+			Description: "Kafka protocol version, e.g. 2.8.0.",
+		},
+	},
+}
+
+var natsTargetBackend = eventTargetBackend{
+	service: "nats",
+	title:   "NATS",
+	attributes: map[string]schema.Attribute{
+		"address": schema.StringAttribute{
+			Required:    true,
+			Description: "NATS server in host:port format.",
+		},
+		"subject": schema.StringAttribute{
+			Required:    true,
+			Description: "Subject the events are published to.",
+		},
+		"username": schema.StringAttribute{
+			Optional:    true,
+			Description: "NATS username.",
+		},
+		"password": schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			Description: "NATS password.",
+		},
+		"token": schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			Description: "NATS token.",
+		},
+		"tls": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Connect to the server with TLS.",
+		},
+		"tls_skip_verify": schema.BoolAttribute{
+			Optional:    true,
+			Description: "Do not verify the server certificate.",
+		},
+	},
+}
+
+var mqttTargetBackend = eventTargetBackend{
+	service: "mqtt",
+	title:   "MQTT",
+	attributes: map[string]schema.Attribute{
+		"broker": schema.StringAttribute{
+			Required:    true,
+			Description: "MQTT broker URL, e.g. tcp://mqtt.example.com:1883.",
+		},
+		"topic": schema.StringAttribute{
+			Required:    true,
+			Description: "Topic the events are published to.",
+		},
+		"qos": schema.Int64Attribute{
+			Optional:    true,
+			Description: "Quality of service level: 0, 1 or 2.",
+			Validators:  []validator.Int64{int64validator.Between(0, 2)},
+		},
+		"username": schema.StringAttribute{
+			Optional:    true,
+			Description: "MQTT username.",
+		},
+		"password": schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			Description: "MQTT password.",
+		},
+		"keep_alive_interval": schema.StringAttribute{
+			Optional:    true,
+			Description: "Keep alive interval as duration, e.g. 10s.",
+		},
+		"reconnect_interval": schema.StringAttribute{
+			Optional:    true,
+			Description: "Reconnect interval as duration, e.g. 5s.",
+		},
+	},
+}
+
+var redisTargetBackend = eventTargetBackend{
+	service: "redis",
+	title:   "Redis",
+	attributes: map[string]schema.Attribute{
+		"address": schema.StringAttribute{
+			Required:    true,
+			Description: "Redis server in host:port format.",
+		},
+		"key": schema.StringAttribute{
+			Required:    true,
+			Description: "Redis key the events are stored under.",
+		},
+		"format": schema.StringAttribute{
+			Optional:    true,
+			Description: "Event format: namespace or access.",
+			Validators:  []validator.String{targetFormatValidator},
+		},
+		"user": schema.StringAttribute{
+			Optional:    true,
+			Description: "Redis user.",
+		},
+		"password": schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Redis password.",
+		},
+	},
+}
+
+var postgresTargetBackend = eventTargetBackend{
+	service: "postgres",
+	title:   "PostgreSQL",
+	attributes: map[string]schema.Attribute{
+		"connection_string": schema.StringAttribute{
+			Required:    true,
+			Sensitive:   true,
+			Description: "PostgreSQL connection string, e.g. host=db user=rustfs password=secret dbname=events.",
+		},
+		"table": schema.StringAttribute{
+			Required:    true,
+			Description: "Table the events are stored in.",
+		},
+		"format": schema.StringAttribute{
+			Optional:    true,
+			Description: "Event format: namespace or access.",
+			Validators:  []validator.String{targetFormatValidator},
+		},
+	},
+}
+
+func (r *EventTargetResource) targetType() string {
+	if r.audit {
+		return rustfs.AuditTargetPrefix + r.backend.service
+	}
+	return rustfs.NotifyTargetPrefix + r.backend.service
+}
+
+func (r *EventTargetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.targetType()
+}
+
+func (r *EventTargetResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	kind := "notification"
+	if r.audit {
+		kind = "audit"
+	}
+	attributes := map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Required:      true,
+			Description:   "Name of the target. Changing this forces recreation.",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+		},
+		"enable": schema.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(true),
+			Description: "Send events to the target. Default: true.",
+		},
+		"queue_dir": schema.StringAttribute{
+			Optional:    true,
+			Description: "Directory on the server where undelivered events are queued.",
+		},
+		"queue_limit": schema.Int64Attribute{
+			Optional:    true,
+			Description: "Maximum number of queued events.",
+			Validators:  []validator.Int64{int64validator.AtLeast(1)},
+		},
+	}
+	if !r.audit {
+		attributes["arn"] = schema.StringAttribute{
+			Computed:      true,
+			Description:   "ARN of the target, used in rustfs_bucket_notification.",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+		}
+	}
+	maps.Copy(attributes, r.backend.attributes)
+
+	resp.Schema = schema.Schema{
+		Description:         fmt.Sprintf("Manage a RustFS %s %s target", r.backend.title, kind),
+		MarkdownDescription: fmt.Sprintf("Manage a RustFS %s %s target. Configured settings changed on the server are read back, credentials are not.", r.backend.title, kind),
+		Attributes:          attributes,
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *EventTargetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*AllClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *AllClient, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = client
+}
+
+func (r *EventTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var createTimeouts timeouts.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &createTimeouts)...)
+	createTimeout, diags := createTimeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	name := r.setTarget(ctx, req.Plan, &resp.Diagnostics, "Error creating target", "Could not create target: ")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.State.Raw = req.Plan.Raw
+	r.setArn(ctx, name, &resp.State, &resp.Diagnostics)
+}
+
+func (r *EventTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var name types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.RustClient.GetNotificationTarget(ctx, r.targetType(), name.ValueString())
+	if err == nil {
+		var config []rustfs.TargetKeyValue
+		config, err = r.client.RustClient.GetNotificationTargetConfig(ctx, r.targetType(), name.ValueString())
+		if err == nil {
+			r.refreshSettings(ctx, config, req.State, &resp.State, &resp.Diagnostics)
+		}
+	}
+	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading target", "Could not read target: "+err.Error())
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setArn(ctx, name.ValueString(), &resp.State, &resp.Diagnostics)
+}
+
+func (r *EventTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var updateTimeouts timeouts.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &updateTimeouts)...)
+	updateTimeout, diags := updateTimeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	name := r.setTarget(ctx, req.Plan, &resp.Diagnostics, "Error updating target", "Could not update target: ")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.State.Raw = req.Plan.Raw
+	r.setArn(ctx, name, &resp.State, &resp.Diagnostics)
+}
+
+func (r *EventTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var name types.String
+	var deleteTimeouts timeouts.Value
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("timeouts"), &deleteTimeouts)...)
+	deleteTimeout, diags := deleteTimeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if err := r.client.RustClient.RemoveNotificationTarget(ctx, r.targetType(), name.ValueString()); err != nil {
+		if rustfs.IsNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Error removing target", "Could not remove target: "+err.Error())
+		return
+	}
+}
+
+// ImportState imports a target by name. The settings are taken from the
+// configuration on the next apply.
+func (r *EventTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, p path.Path, target any) diag.Diagnostics
+}
+
+type attributeSetter interface {
+	SetAttribute(ctx context.Context, p path.Path, value any) diag.Diagnostics
+}
+
+// setTarget sends the planned configuration to the server and returns the
+// name of the target.
+func (r *EventTargetResource) setTarget(ctx context.Context, plan attributeGetter, diags *diag.Diagnostics, summary, detail string) string {
+	var name types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	keyValues, d := r.keyValues(ctx, plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return ""
+	}
+	if err := r.client.RustClient.SetNotificationTarget(ctx, r.targetType(), name.ValueString(), keyValues); err != nil {
+		diags.AddError(summary, detail+err.Error())
+	}
+	return name.ValueString()
+}
+
+// configAttributes returns the attributes sent as configuration keys.
+func (r *EventTargetResource) configAttributes() map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{
+		"enable":      schema.BoolAttribute{},
+		"queue_dir":   schema.StringAttribute{},
+		"queue_limit": schema.Int64Attribute{},
+	}
+	maps.Copy(attributes, r.backend.attributes)
+	return attributes
+}
+
+// keyValues converts the configured attributes to configuration keys.
+// Booleans are sent as on/off and lists comma separated, unset attributes
+// are left out.
+func (r *EventTargetResource) keyValues(ctx context.Context, plan attributeGetter) ([]rustfs.TargetKeyValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	attributes := r.configAttributes()
+
+	var keyValues []rustfs.TargetKeyValue
+	for _, key := range slices.Sorted(maps.Keys(attributes)) {
+		var value string
+		var isSet bool
+		switch attributes[key].(type) {
+		case schema.BoolAttribute:
+			var v types.Bool
+			diags.Append(plan.GetAttribute(ctx, path.Root(key), &v)...)
+			value, isSet = "off", !v.IsNull() && !v.IsUnknown()
+			if v.ValueBool() {
+				value = "on"
+			}
+		case schema.Int64Attribute:
+			var v types.Int64
+			diags.Append(plan.GetAttribute(ctx, path.Root(key), &v)...)
+			value, isSet = strconv.FormatInt(v.ValueInt64(), 10), !v.IsNull() && !v.IsUnknown()
+		case schema.ListAttribute:
+			var v types.List
+			var values []string
+			diags.Append(plan.GetAttribute(ctx, path.Root(key), &v)...)
+			diags.Append(v.ElementsAs(ctx, &values, false)...)
+			value, isSet = strings.Join(values, ","), !v.IsNull() && !v.IsUnknown()
+		default:
+			var v types.String
+			diags.Append(plan.GetAttribute(ctx, path.Root(key), &v)...)
+			value, isSet = v.ValueString(), !v.IsNull() && !v.IsUnknown()
+		}
+		if isSet {
+			keyValues = append(keyValues, rustfs.TargetKeyValue{Key: key, Value: value})
+		}
+	}
+	return keyValues, diags
+}
+
+// refreshSettings updates the configured attributes from the configuration
+// keys the server returns. The server returns every key with its default,
+// e.g. queue_limit=0, keys the configuration leaves unset are ignored.
+// Sensitive attributes are kept as configured, the server does not return
+// credentials in clear text.
+func (r *EventTargetResource) refreshSettings(ctx context.Context, config []rustfs.TargetKeyValue, prior attributeGetter, state attributeSetter, diags *diag.Diagnostics) {
+	current, d := r.keyValues(ctx, prior)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+	configured := map[string]string{}
+	for _, kv := range current {
+		configured[kv.Key] = kv.Value
+	}
+
+	attributes := r.configAttributes()
+	for _, kv := range config {
+		attribute, ok := attributes[kv.Key]
+		if !ok || attribute.IsSensitive() {
+			continue
+		}
+		value, isSet := configured[kv.Key]
+		if !isSet || value == kv.Value {
+			continue
+		}
+		key := path.Root(kv.Key)
+		switch attribute.(type) {
+		case schema.BoolAttribute:
+			diags.Append(state.SetAttribute(ctx, key, kv.Value == "on" || kv.Value == "true")...)
+		case schema.Int64Attribute:
+			if kv.Value == "" {
+				diags.Append(state.SetAttribute(ctx, key, types.Int64Null())...)
+				continue
+			}
+			v, err := strconv.ParseInt(kv.Value, 10, 64)
+			if err != nil {
+				diags.AddAttributeError(key, "Error reading target", fmt.Sprintf("Could not parse %s %q: %s", kv.Key, kv.Value, err))
+				continue
+			}
+			diags.Append(state.SetAttribute(ctx, key, v)...)
+		case schema.ListAttribute:
+			if kv.Value == "" {
+				diags.Append(state.SetAttribute(ctx, key, types.ListNull(types.StringType))...)
+				continue
+			}
+			diags.Append(state.SetAttribute(ctx, key, strings.Split(kv.Value, ","))...)
+		default:
+			diags.Append(state.SetAttribute(ctx, key, stringOrNull(kv.Value))...)
+		}
+	}
+}
+
+// setArn stores the ARN the server lists for a notification target. Audit
+// targets have no ARN.
+func (r *EventTargetResource) setArn(ctx context.Context, name string, state attributeSetter, diags *diag.Diagnostics) {
+	if r.audit {
+		return
+	}
+	arns, err := r.client.RustClient.ListNotificationTargetARNs(ctx)
+	if err != nil {
+		diags.AddError("Error reading target ARN", "Could not list target ARNs: "+err.Error())
+		return
+	}
+	// ARNs look like arn:rustfs:sqs:<region>:<name>:<service>.
+	arn := "arn:rustfs:sqs::" + name + ":" + r.backend.service
+	for _, candidate := range arns {
+		if strings.HasSuffix(candidate, ":"+name+":"+r.backend.service) {
+			arn = candidate
+			break
+		}
+	}
+	diags.Append(state.SetAttribute(ctx, path.Root("arn"), arn)...)
+}
@@ -0,0 +1,293 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+func TestEventTargetResourceMetadata(t *testing.T) {
+	resources := map[string]func() resource.Resource{
+		"rustfs_notify_webhook":  NewNotifyWebhookResource,
+		"rustfs_notify_kafka":    NewNotifyKafkaResource,
+		"rustfs_notify_nats":     NewNotifyNatsResource,
+		"rustfs_notify_mqtt":     NewNotifyMqttResource,
+		"rustfs_notify_redis":    NewNotifyRedisResource,
+		"rustfs_notify_postgres": NewNotifyPostgresResource,
+		"rustfs_audit_webhook":   NewAuditWebhookResource,
+		"rustfs_audit_kafka":     NewAuditKafkaResource,
+		"rustfs_audit_nats":      NewAuditNatsResource,
+		"rustfs_audit_mqtt":      NewAuditMqttResource,
+		"rustfs_audit_redis":     NewAuditRedisResource,
+		"rustfs_audit_postgres":  NewAuditPostgresResource,
+	}
+	for name, newResource := range resources {
+		r := newResource()
+		resp := &resource.MetadataResponse{}
+		r.Metadata(nil, resource.MetadataRequest{ProviderTypeName: "rustfs"}, resp)
+		if resp.TypeName != name {
+			t.Errorf("expected %s, got %s", name, resp.TypeName)
+		}
+
+		schemaResp := &resource.SchemaResponse{}
+		r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+		if schemaResp.Diagnostics.HasError() {
+			t.Fatalf("%s schema diagnostics: %v", name, schemaResp.Diagnostics)
+		}
+		_, hasArn := schemaResp.Schema.Attributes["arn"]
+		if audit := r.(*EventTargetResource).audit; hasArn == audit {
+			t.Errorf("%s: unexpected arn attribute presence %v", name, hasArn)
+		}
+	}
+}
+
+func TestEventTargetResourceSchema_SensitiveCredentials(t *testing.T) {
+	sensitive := map[string][]string{
+		"webhook":  {"auth_token"},
+		"kafka":    {"sasl_password"},
+		"nats":     {"password", "token"},
+		"mqtt":     {"password"},
+		"redis":    {"password"},
+		"postgres": {"connection_string"},
+	}
+	for _, backend := range []eventTargetBackend{webhookTargetBackend, kafkaTargetBackend, natsTargetBackend, mqttTargetBackend, redisTargetBackend, postgresTargetBackend} {
+		for _, name := range sensitive[backend.service] {
+			a, ok := backend.attributes[name].(schema.StringAttribute)
+			if !ok || !a.Sensitive {
+				t.Errorf("%s: expected %s to be a sensitive string", backend.service, name)
+			}
+		}
+	}
+}
+
+func testEventTargetPlan(t *testing.T, r resource.Resource, client *AllClient, values map[string]any) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	plan := testResourceState(t, r, client)
+	values = maps.Clone(values)
+	if _, ok := values["enable"]; !ok {
+		values["enable"] = types.BoolValue(true)
+	}
+	if _, ok := plan.Schema.GetAttributes()["arn"]; ok {
+		values["arn"] = types.StringUnknown()
+	}
+	for name, value := range values {
+		if diags := plan.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("set %s: %v", name, diags)
+		}
+	}
+	return plan
+}
+
+func TestEventTargetResource_KeyValues(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	r := NewNotifyKafkaResource()
+	brokers, _ := types.ListValueFrom(ctx, types.StringType, []string{"kafka-1:9092", "kafka-2:9092"})
+	plan := testEventTargetPlan(t, r, client, map[string]any{
+		"name":          types.StringValue("primary"),
+		"enable":        types.BoolValue(false),
+		"brokers":       brokers,
+		"topic":         types.StringValue("events"),
+		"sasl":          types.BoolValue(true),
+		"sasl_password": types.StringValue("secret"),
+		"queue_limit":   types.Int64Value(1000),
+	})
+
+	keyValues, diags := r.(*EventTargetResource).keyValues(ctx, tfsdk.Plan(plan))
+	if diags.HasError() {
+		t.Fatalf("key values diagnostics: %v", diags)
+	}
+	got := map[string]string{}
+	for _, kv := range keyValues {
+		got[kv.Key] = kv.Value
+	}
+	want := map[string]string{
+		"enable":        "off",
+		"brokers":       "kafka-1:9092,kafka-2:9092",
+		"topic":         "events",
+		"sasl":          "on",
+		"sasl_password": "secret",
+		"queue_limit":   "1000",
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("unexpected key values:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestEventTargetResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, server := testProviderClient(t)
+	r := NewNotifyWebhookResource()
+	plan := testEventTargetPlan(t, r, client, map[string]any{
+		"name":       types.StringValue("primary"),
+		"endpoint":   types.StringValue("https://hooks.example.com/rustfs"),
+		"auth_token": types.StringValue("token"),
+	})
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+	var arn types.String
+	createResp.State.GetAttribute(ctx, path.Root("arn"), &arn)
+	if arn.ValueString() != "arn:rustfs:sqs::primary:webhook" {
+		t.Errorf("unexpected ARN %s", arn)
+	}
+	config, ok := server.NotificationTarget("notify_webhook", "primary")
+	if !ok || config["endpoint"] != "https://hooks.example.com/rustfs" || config["auth_token"] != "token" || config["enable"] != "on" {
+		t.Errorf("unexpected target configuration %v", config)
+	}
+
+	// Import only knows the name, Read fills in the ARN.
+	importResp := &resource.ImportStateResponse{State: testResourceState(t, r, client)}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "primary"}, importResp)
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	readResp.State.GetAttribute(ctx, path.Root("arn"), &arn)
+	if arn.ValueString() != "arn:rustfs:sqs::primary:webhook" {
+		t.Errorf("unexpected imported ARN %s", arn)
+	}
+	// Settings of an import are taken from the configuration on the next
+	// apply, the server returns defaults for all keys.
+	var endpoint types.String
+	readResp.State.GetAttribute(ctx, path.Root("endpoint"), &endpoint)
+	if !endpoint.IsNull() {
+		t.Errorf("unexpected imported endpoint %s", endpoint)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected removed target to be removed from state")
+	}
+}
+
+func TestEventTargetResourceAuditUpdate(t *testing.T) {
+	ctx := context.Background()
+	client, server := testProviderClient(t)
+	r := NewAuditMqttResource()
+	plan := testEventTargetPlan(t, r, client, map[string]any{
+		"name":   types.StringValue("audit"),
+		"broker": types.StringValue("tcp://mqtt.example.com:1883"),
+		"topic":  types.StringValue("audit"),
+	})
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	plan.SetAttribute(ctx, path.Root("qos"), types.Int64Value(1))
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan(plan), State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("update diagnostics: %v", updateResp.Diagnostics)
+	}
+	if config, _ := server.NotificationTarget("audit_mqtt", "audit"); config["qos"] != "1" {
+		t.Errorf("unexpected target configuration %v", config)
+	}
+
+	// Audit targets cannot be used in bucket notifications.
+	arns, err := client.RustClient.ListNotificationTargetARNs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(arns) != 0 {
+		t.Errorf("expected no notification ARNs, got %v", arns)
+	}
+
+	readResp := &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+		t.Fatalf("expected the audit target to exist: %v", readResp.Diagnostics)
+	}
+	// Defaults such as keep_alive_interval=0s leave the state unchanged.
+	if !readResp.State.Raw.Equal(updateResp.State.Raw) {
+		t.Errorf("expected unchanged state, got %v", readResp.State.Raw)
+	}
+}
+
+func TestEventTargetResourceRead_EditedOutOfBand(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	r := NewNotifyKafkaResource()
+	plan := testEventTargetPlan(t, r, client, map[string]any{
+		"name":        types.StringValue("events"),
+		"brokers":     types.ListValueMust(types.StringType, []attr.Value{types.StringValue("kafka-1:9092")}),
+		"topic":       types.StringValue("events"),
+		"queue_limit": types.Int64Value(10),
+	})
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	// The server returns defaults for unset keys, e.g. sasl_mechanism=plain
+	// and version="". They do not change the unset attributes.
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.Equal(createResp.State.Raw) {
+		t.Errorf("expected unchanged state, got %v", readResp.State.Raw)
+	}
+
+	err := client.RustClient.SetNotificationTarget(ctx, "notify_kafka", "events", []rustfs.TargetKeyValue{
+		{Key: "brokers", Value: "kafka-1:9092,kafka-2:9092"},
+		{Key: "enable", Value: "off"},
+		{Key: "queue_limit", Value: "100"},
+		{Key: "topic", Value: "audit events"},
+		{Key: "version", Value: "3.6.0"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var brokers []string
+	var enable types.Bool
+	var queueLimit types.Int64
+	var topic types.String
+	readResp.State.GetAttribute(ctx, path.Root("brokers"), &brokers)
+	readResp.State.GetAttribute(ctx, path.Root("enable"), &enable)
+	readResp.State.GetAttribute(ctx, path.Root("queue_limit"), &queueLimit)
+	readResp.State.GetAttribute(ctx, path.Root("topic"), &topic)
+	if !slices.Equal(brokers, []string{"kafka-1:9092", "kafka-2:9092"}) {
+		t.Errorf("unexpected brokers %v", brokers)
+	}
+	if enable.ValueBool() || queueLimit.ValueInt64() != 100 || topic.ValueString() != "audit events" {
+		t.Errorf("unexpected enable %s, queue limit %s and topic %s", enable, queueLimit, topic)
+	}
+	// Keys not in the configuration are not managed.
+	var version types.String
+	readResp.State.GetAttribute(ctx, path.Root("version"), &version)
+	if !version.IsNull() {
+		t.Errorf("expected unset version to stay null, got %s", version)
+	}
+}
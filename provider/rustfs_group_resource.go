@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -24,9 +25,10 @@ type GroupResource struct {
 }
 
 type GroupResourceModel struct {
-	Name    types.String `tfsdk:"name"`
-	Status  types.String `tfsdk:"status"`
-	Members types.Set    `tfsdk:"members"`
+	Name     types.String   `tfsdk:"name"`
+	Status   types.String   `tfsdk:"status"`
+	Members  types.Set      `tfsdk:"members"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewGroupResource() resource.Resource {
@@ -37,7 +39,7 @@ func (r *GroupResource) Metadata(_ context.Context, req resource.MetadataRequest
 	resp.TypeName = req.ProviderTypeName + "_group"
 }
 
-func (r *GroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *GroupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description:         "Manage RustFS IAM groups",
 		MarkdownDescription: "Manage RustFS IAM groups and their members",
@@ -60,6 +62,14 @@ func (r *GroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Description: "Set of user access keys that are members of this group.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -85,6 +95,14 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	var members []string
 	resp.Diagnostics.Append(plan.Members.ElementsAs(ctx, &members, false)...)
 	if resp.Diagnostics.HasError() {
@@ -97,7 +115,7 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	if len(members) > 0 {
-		err := r.client.RustClient.UpdateGroupMembers(rustfs.GroupAddRemove{
+		err := r.client.RustClient.UpdateGroupMembers(ctx, rustfs.GroupAddRemove{
 			Group:    plan.Name.ValueString(),
 			Members:  members,
 			IsRemove: false,
@@ -113,7 +131,7 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	if status != "enabled" {
-		if err := r.client.RustClient.SetGroupStatus(plan.Name.ValueString(), status); err != nil {
+		if err := r.client.RustClient.SetGroupStatus(ctx, plan.Name.ValueString(), status); err != nil {
 			resp.Diagnostics.AddError(
 				"Error setting group status",
 				"Could not set group status: "+err.Error(),
@@ -134,8 +152,20 @@ func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	info, err := r.client.RustClient.GetGroup(state.Name.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	info, err := r.client.RustClient.GetGroup(ctx, state.Name.ValueString())
 	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading group",
 			"Could not read group: "+err.Error(),
@@ -158,13 +188,21 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	var planMembers []string
 	resp.Diagnostics.Append(plan.Members.ElementsAs(ctx, &planMembers, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.client.RustClient.DeleteGroup(plan.Name.ValueString()); err != nil {
+	if err := r.client.RustClient.DeleteGroup(ctx, plan.Name.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating group",
 			"Could not reset group members: "+err.Error(),
@@ -177,7 +215,7 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		if status == "" {
 			status = "enabled"
 		}
-		if err := r.client.RustClient.UpdateGroupMembers(rustfs.GroupAddRemove{
+		if err := r.client.RustClient.UpdateGroupMembers(ctx, rustfs.GroupAddRemove{
 			Group:    plan.Name.ValueString(),
 			Members:  planMembers,
 			IsRemove: false,
@@ -192,7 +230,7 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	if plan.Status.ValueString() != "" {
-		if err := r.client.RustClient.SetGroupStatus(plan.Name.ValueString(), plan.Status.ValueString()); err != nil {
+		if err := r.client.RustClient.SetGroupStatus(ctx, plan.Name.ValueString(), plan.Status.ValueString()); err != nil {
 			resp.Diagnostics.AddError(
 				"Error updating group status",
 				"Could not set group status: "+err.Error(),
@@ -211,7 +249,18 @@ func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	if err := r.client.RustClient.DeleteGroup(data.Name.ValueString()); err != nil {
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if err := r.client.RustClient.DeleteGroup(ctx, data.Name.ValueString()); err != nil {
+		if rustfs.IsNotFound(err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error deleting group",
 			"Could not delete group: "+err.Error(),
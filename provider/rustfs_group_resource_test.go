@@ -1,9 +1,13 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
 )
 
 func TestGroupResourceSchema(t *testing.T) {
@@ -36,3 +40,72 @@ func TestGroupResourceMetadata(t *testing.T) {
 		t.Errorf("expected rustfs_group, got %s", resp.TypeName)
 	}
 }
+
+func TestGroupResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	for _, user := range []string{"alice", "bob"} {
+		if err := client.RustClient.CreateUserAccount(ctx, rustfs.UserAccount{AccessKey: user, SecretKey: user + "-secret"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := NewGroupResource()
+	empty := testResourceState(t, r, client)
+
+	var model GroupResourceModel
+	if diags := empty.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("get diagnostics: %v", diags)
+	}
+	model.Name = types.StringValue("devs")
+	model.Status = types.StringValue("disabled")
+	model.Members, _ = types.SetValueFrom(ctx, types.StringType, []string{"alice"})
+	plan := empty
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("set diagnostics: %v", diags)
+	}
+
+	createResp := &resource.CreateResponse{State: empty}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+	group, err := client.RustClient.GetGroup(ctx, "devs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.Status != "disabled" || len(group.Members) != 1 {
+		t.Errorf("unexpected group %+v", group)
+	}
+
+	model.Members, _ = types.SetValueFrom(ctx, types.StringType, []string{"alice", "bob"})
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("set diagnostics: %v", diags)
+	}
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan(plan), State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("update diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	readResp := &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var read GroupResourceModel
+	readResp.State.Get(ctx, &read)
+	if len(read.Members.Elements()) != 2 || read.Status.ValueString() != "disabled" {
+		t.Errorf("unexpected state %+v", read)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	readResp = &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected deleted group to be removed from state")
+	}
+}
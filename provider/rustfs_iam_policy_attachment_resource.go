@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+var (
+	_ resource.Resource                = &PolicyAttachmentResource{}
+	_ resource.ResourceWithImportState = &PolicyAttachmentResource{}
+)
+
+// PolicyAttachmentResource attaches policies to a user or group. The server
+// stores the policies of a principal as one comma separated list, so the
+// resource only adds and removes its own policies and keeps the others.
+type PolicyAttachmentResource struct {
+	client *AllClient
+	group  bool
+}
+
+// policyAttachmentMu serializes the read-modify-write of the policy lists,
+// several attachments may target the same principal.
+var policyAttachmentMu sync.Mutex
+
+func NewIamUserPolicyAttachmentResource() resource.Resource {
+	return &PolicyAttachmentResource{}
+}
+
+func NewIamGroupPolicyAttachmentResource() resource.Resource {
+	return &PolicyAttachmentResource{group: true}
+}
+
+// principal is the name of the attribute holding the user or group.
+func (r *PolicyAttachmentResource) principal() string {
+	if r.group {
+		return "group"
+	}
+	return "user"
+}
+
+func (r *PolicyAttachmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_" + r.principal() + "_policy_attachment"
+}
+
+func (r *PolicyAttachmentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         fmt.Sprintf("Attach policies to a RustFS %s", r.principal()),
+		MarkdownDescription: fmt.Sprintf("Attach policies to a %s. Policies attached otherwise, e.g. by another attachment, are kept. Destroying the resource detaches only the policies of this resource.", r.principal()),
+		Attributes: map[string]schema.Attribute{
+			r.principal(): schema.StringAttribute{
+				Required:      true,
+				Description:   fmt.Sprintf("Name of the %s. Changing this forces recreation.", r.principal()),
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"policies": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Names of the policies to attach.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^,\s]+$`), "must be a policy name without commas or spaces"),
+					),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *PolicyAttachmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*AllClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AllClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *PolicyAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var createTimeouts timeouts.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &createTimeouts)...)
+	createTimeout, diags := createTimeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	name, planned := r.attachment(ctx, req.Plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.updatePolicies(ctx, name, nil, planned, &resp.Diagnostics, "Error attaching policies", "Could not attach policies: ")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.State.Raw = req.Plan.Raw
+}
+
+func (r *PolicyAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var readTimeouts timeouts.Value
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("timeouts"), &readTimeouts)...)
+	readTimeout, diags := readTimeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	name, managed := r.attachment(ctx, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	attached, err := r.attachedPolicies(ctx, name)
+	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading policies", "Could not read attached policies: "+err.Error())
+		return
+	}
+
+	// Imported attachments take over all attached policies.
+	policies := attached
+	if managed != nil {
+		policies = slices.DeleteFunc(slices.Clone(managed), func(policy string) bool {
+			return !slices.Contains(attached, policy)
+		})
+	}
+	if len(policies) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policies"), policies)...)
+}
+
+func (r *PolicyAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var updateTimeouts timeouts.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("timeouts"), &updateTimeouts)...)
+	updateTimeout, diags := updateTimeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	name, planned := r.attachment(ctx, req.Plan, &resp.Diagnostics)
+	_, prior := r.attachment(ctx, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	detach := slices.DeleteFunc(prior, func(policy string) bool {
+		return slices.Contains(planned, policy)
+	})
+	r.updatePolicies(ctx, name, detach, planned, &resp.Diagnostics, "Error updating policies", "Could not update attached policies: ")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.State.Raw = req.Plan.Raw
+}
+
+func (r *PolicyAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var deleteTimeouts timeouts.Value
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("timeouts"), &deleteTimeouts)...)
+	deleteTimeout, diags := deleteTimeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	name, prior := r.attachment(ctx, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.updatePolicies(ctx, name, prior, nil, &resp.Diagnostics, "Error detaching policies", "Could not detach policies: ")
+}
+
+// ImportState imports all policies attached to a user or group by its name.
+func (r *PolicyAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root(r.principal()), req, resp)
+}
+
+// attachment returns the principal and the policies of a plan or state.
+// The policies are nil if they are not known, e.g. after an import.
+func (r *PolicyAttachmentResource) attachment(ctx context.Context, data attributeGetter, diags *diag.Diagnostics) (string, []string) {
+	var name types.String
+	var policies types.Set
+	diags.Append(data.GetAttribute(ctx, path.Root(r.principal()), &name)...)
+	diags.Append(data.GetAttribute(ctx, path.Root("policies"), &policies)...)
+	if diags.HasError() || policies.IsNull() || policies.IsUnknown() {
+		return name.ValueString(), nil
+	}
+	var names []string
+	diags.Append(policies.ElementsAs(ctx, &names, false)...)
+	slices.Sort(names)
+	return name.ValueString(), names
+}
+
+// attachedPolicies returns the policies effectively attached to the
+// principal.
+func (r *PolicyAttachmentResource) attachedPolicies(ctx context.Context, name string) ([]string, error) {
+	query := rustfs.PolicyEntitiesQuery{Users: []string{name}}
+	if r.group {
+		query = rustfs.PolicyEntitiesQuery{Groups: []string{name}}
+	}
+	entities, err := r.client.RustClient.GetPolicyEntities(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	policies := []string{}
+	for _, mapping := range entities.UserMappings {
+		if !r.group && mapping.User == name {
+			policies = append(policies, mapping.Policies...)
+		}
+	}
+	for _, mapping := range entities.GroupMappings {
+		if r.group && mapping.Group == name {
+			policies = append(policies, mapping.Policies...)
+		}
+	}
+	return policies, nil
+}
+
+// updatePolicies detaches and attaches policies, keeping the other
+// policies attached to the principal.
+func (r *PolicyAttachmentResource) updatePolicies(ctx context.Context, name string, detach, attach []string, diags *diag.Diagnostics, summary, detail string) {
+	policyAttachmentMu.Lock()
+	defer policyAttachmentMu.Unlock()
+
+	attached, err := r.attachedPolicies(ctx, name)
+	if err != nil {
+		// Policies of a removed principal are gone already.
+		if rustfs.IsNotFound(err) && len(attach) == 0 {
+			return
+		}
+		diags.AddError(summary, detail+err.Error())
+		return
+	}
+	policies := mergePolicies(attached, detach, attach)
+	if slices.Equal(policies, attached) {
+		return
+	}
+	if r.group {
+		err = r.client.RustClient.SetGroupPolicies(ctx, name, policies)
+	} else {
+		err = r.client.RustClient.SetUserPolicies(ctx, name, policies)
+	}
+	if err != nil {
+		diags.AddError(summary, detail+err.Error())
+	}
+}
+
+// mergePolicies removes detach from the attached policies and appends the
+// policies of attach that are not attached yet.
+func mergePolicies(attached, detach, attach []string) []string {
+	policies := []string{}
+	for _, policy := range slices.Concat(attached, attach) {
+		if slices.Contains(detach, policy) || slices.Contains(policies, policy) {
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	return policies
+}
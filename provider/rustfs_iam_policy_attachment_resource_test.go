@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+func TestPolicyAttachmentResourceMetadata(t *testing.T) {
+	for expected, r := range map[string]resource.Resource{
+		"rustfs_iam_user_policy_attachment":  NewIamUserPolicyAttachmentResource(),
+		"rustfs_iam_group_policy_attachment": NewIamGroupPolicyAttachmentResource(),
+	} {
+		resp := &resource.MetadataResponse{}
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "rustfs"}, resp)
+		if resp.TypeName != expected {
+			t.Errorf("expected %s, got %s", expected, resp.TypeName)
+		}
+	}
+}
+
+func TestMergePolicies(t *testing.T) {
+	merged := mergePolicies([]string{"consoleAdmin", "readonly", "diagnostics"}, []string{"readonly"}, []string{"diagnostics", "readwrite"})
+	expected := []string{"consoleAdmin", "diagnostics", "readwrite"}
+	if !slices.Equal(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+	if merged := mergePolicies([]string{"readonly"}, []string{"readonly"}, nil); len(merged) != 0 {
+		t.Errorf("expected no policies, got %v", merged)
+	}
+}
+
+func testAttachedPolicies(t *testing.T, client *AllClient, query rustfs.PolicyEntitiesQuery) []string {
+	t.Helper()
+	entities, err := client.RustClient.GetPolicyEntities(context.Background(), query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mapping := range entities.UserMappings {
+		return mapping.Policies
+	}
+	for _, mapping := range entities.GroupMappings {
+		return mapping.Policies
+	}
+	return nil
+}
+
+func TestUserPolicyAttachmentResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	if err := client.RustClient.CreateUserAccount(ctx, rustfs.UserAccount{AccessKey: "alice", SecretKey: "alice-secret", Policy: "consoleAdmin"}); err != nil {
+		t.Fatal(err)
+	}
+	query := rustfs.PolicyEntitiesQuery{Users: []string{"alice"}}
+	r := NewIamUserPolicyAttachmentResource()
+
+	plan := testResourceState(t, r, client)
+	plan.SetAttribute(ctx, path.Root("user"), "alice")
+	plan.SetAttribute(ctx, path.Root("policies"), []string{"readonly", "diagnostics"})
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+	// Policies attached otherwise are kept.
+	if policies := testAttachedPolicies(t, client, query); !slices.Equal(policies, []string{"consoleAdmin", "diagnostics", "readonly"}) {
+		t.Errorf("unexpected attached policies %v", policies)
+	}
+
+	// Only the policies of the resource show up, detached ones are dropped.
+	if err := client.RustClient.SetUserPolicies(ctx, "alice", []string{"consoleAdmin", "readonly"}); err != nil {
+		t.Fatal(err)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var policies []string
+	readResp.State.GetAttribute(ctx, path.Root("policies"), &policies)
+	if !slices.Equal(policies, []string{"readonly"}) {
+		t.Errorf("expected the refreshed policies to be [readonly], got %v", policies)
+	}
+
+	updatePlan := testResourceState(t, r, client)
+	updatePlan.SetAttribute(ctx, path.Root("user"), "alice")
+	updatePlan.SetAttribute(ctx, path.Root("policies"), []string{"readwrite"})
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan(updatePlan), State: readResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("update diagnostics: %v", updateResp.Diagnostics)
+	}
+	if policies := testAttachedPolicies(t, client, query); !slices.Equal(policies, []string{"consoleAdmin", "readwrite"}) {
+		t.Errorf("unexpected attached policies %v", policies)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	if policies := testAttachedPolicies(t, client, query); !slices.Equal(policies, []string{"consoleAdmin"}) {
+		t.Errorf("expected only consoleAdmin to stay attached, got %v", policies)
+	}
+	readResp = &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected detached policies to be removed from state")
+	}
+}
+
+func TestGroupPolicyAttachmentResourceImport(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	if err := client.RustClient.UpdateGroupMembers(ctx, rustfs.GroupAddRemove{Group: "devs", Members: []string{}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.RustClient.SetGroupPolicies(ctx, "devs", []string{"readwrite", "diagnostics"}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewIamGroupPolicyAttachmentResource()
+
+	importResp := &resource.ImportStateResponse{State: testResourceState(t, r, client)}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "devs"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("import diagnostics: %v", importResp.Diagnostics)
+	}
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var policies []string
+	readResp.State.GetAttribute(ctx, path.Root("policies"), &policies)
+	slices.Sort(policies)
+	if !slices.Equal(policies, []string{"diagnostics", "readwrite"}) {
+		t.Errorf("expected all group policies to be imported, got %v", policies)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	if policies := testAttachedPolicies(t, client, rustfs.PolicyEntitiesQuery{Groups: []string{"devs"}}); len(policies) != 0 {
+		t.Errorf("expected no group policies, got %v", policies)
+	}
+
+	// A removed group removes the attachment.
+	if err := client.RustClient.DeleteGroup(ctx, "devs"); err != nil {
+		t.Fatal(err)
+	}
+	readResp = &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected the attachment of a removed group to be removed from state")
+	}
+}
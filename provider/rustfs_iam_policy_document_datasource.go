@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+var _ datasource.DataSource = &IamPolicyDocumentDataSource{}
+
+// IamPolicyDocumentDataSource builds policy documents without calling the
+// server.
+type IamPolicyDocumentDataSource struct{}
+
+type IamPolicyDocumentDataSourceModel struct {
+	Version                 types.String                   `tfsdk:"version"`
+	SourcePolicyDocuments   []string                       `tfsdk:"source_policy_documents"`
+	OverridePolicyDocuments []string                       `tfsdk:"override_policy_documents"`
+	Statement               []policyDocumentStatementModel `tfsdk:"statement"`
+	Json                    types.String                   `tfsdk:"json"`
+}
+
+type policyDocumentStatementModel struct {
+	Sid          types.String                   `tfsdk:"sid"`
+	Effect       types.String                   `tfsdk:"effect"`
+	Actions      []string                       `tfsdk:"actions"`
+	NotActions   []string                       `tfsdk:"not_actions"`
+	Resources    []string                       `tfsdk:"resources"`
+	NotResources []string                       `tfsdk:"not_resources"`
+	Principals   []policyDocumentPrincipalModel `tfsdk:"principals"`
+	Condition    []policyConditionModel         `tfsdk:"condition"`
+}
+
+type policyDocumentPrincipalModel struct {
+	Type        string   `tfsdk:"type"`
+	Identifiers []string `tfsdk:"identifiers"`
+}
+
+func NewIamPolicyDocumentDataSource() datasource.DataSource {
+	return &IamPolicyDocumentDataSource{}
+}
+
+func (d *IamPolicyDocumentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_policy_document"
+}
+
+func (d *IamPolicyDocumentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	actions := func(description string) schema.SetAttribute {
+		return schema.SetAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			Description: description,
+			Validators: []validator.Set{
+				setvalidator.ValueStringsAre(policyActionValidator{}),
+			},
+		}
+	}
+	resp.Schema = schema.Schema{
+		Description:         "Build a RustFS policy document",
+		MarkdownDescription: "Build a policy JSON document from statements without calling the server. The `json` output can be used as `policy` of `rustfs_policy` and `rustfs_bucket_policy`.",
+		Attributes: map[string]schema.Attribute{
+			"version": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Policy language version. Default: 2012-10-17.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("2012-10-17"),
+				},
+			},
+			"source_policy_documents": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Policy documents the statements are added to. Statements of `statement` replace source statements with the same sid. Sids must be unique across the source documents.",
+			},
+			"override_policy_documents": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Policy documents applied in order after `statement`. Their statements replace statements with the same sid, statements without sid are added.",
+			},
+			"statement": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "Statements of the document.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"sid": schema.StringAttribute{
+							Optional:    true,
+							Description: "Statement identifier, used to merge statements of source and override documents.",
+						},
+						"effect": schema.StringAttribute{
+							Optional:    true,
+							Description: "Allow or Deny. Default: Allow.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("Allow", "Deny"),
+							},
+						},
+						"actions":     actions("S3 or admin actions the statement applies to, e.g. s3:GetObject or s3:Get*."),
+						"not_actions": actions("S3 or admin actions the statement does not apply to."),
+						"resources": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Resources the statement applies to.",
+						},
+						"not_resources": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Resources the statement does not apply to.",
+						},
+						"principals": schema.SetNestedAttribute{
+							Optional:    true,
+							Description: "Principals of bucket policy statements.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										Required:    true,
+										Description: "Type of the principals, e.g. AWS.",
+									},
+									"identifiers": schema.SetAttribute{
+										ElementType: types.StringType,
+										Required:    true,
+										Description: "Principals, e.g. * for everyone.",
+									},
+								},
+							},
+						},
+						"condition": schema.SetNestedAttribute{
+							Optional:    true,
+							Description: "Conditions of the statement. All conditions must match.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"test": schema.StringAttribute{
+										Required:    true,
+										Description: "Condition operator, e.g. StringLike or IpAddress.",
+									},
+									"variable": schema.StringAttribute{
+										Required:    true,
+										Description: "Condition key, e.g. s3:prefix or aws:SourceIp.",
+									},
+									"values": schema.SetAttribute{
+										ElementType: types.StringType,
+										Required:    true,
+										Description: "Values of the condition key. Any value may match.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"json": schema.StringAttribute{
+				Computed:    true,
+				Description: "Policy JSON document.",
+			},
+		},
+	}
+}
+
+func (d *IamPolicyDocumentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config IamPolicyDocumentDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Version.IsNull() {
+		config.Version = types.StringValue("2012-10-17")
+	}
+	statements, diags := buildPolicyDocument(config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	document, err := json.MarshalIndent(struct {
+		Version   string                   `json:"Version"`
+		Statement []rustfs.PolicyStatement `json:"Statement"`
+	}{config.Version.ValueString(), statements}, "", "  ")
+	if err != nil {
+		resp.Diagnostics.AddError("Error encoding policy", "Could not encode the policy document: "+err.Error())
+		return
+	}
+	config.Json = types.StringValue(string(document))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// buildPolicyDocument merges the source documents, the statements and the
+// override documents by sid.
+func buildPolicyDocument(config IamPolicyDocumentDataSourceModel) ([]rustfs.PolicyStatement, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	statements := []rustfs.PolicyStatement{}
+	sids := map[string]int{}
+	merge := func(statement rustfs.PolicyStatement) {
+		if i, ok := sids[statement.Sid]; ok && statement.Sid != "" {
+			statements[i] = statement
+			return
+		}
+		if statement.Sid != "" {
+			sids[statement.Sid] = len(statements)
+		}
+		statements = append(statements, statement)
+	}
+	parse := func(attribute string, i int, document string) []rustfs.PolicyStatement {
+		policy, err := rustfs.ParsePolicy([]byte(document))
+		if err != nil {
+			diags.AddAttributeError(path.Root(attribute).AtListIndex(i), "Invalid policy document", "Could not parse the policy document: "+err.Error())
+		}
+		return policy.Statement
+	}
+
+	for i, document := range config.SourcePolicyDocuments {
+		for _, statement := range parse("source_policy_documents", i, document) {
+			if _, ok := sids[statement.Sid]; ok && statement.Sid != "" {
+				diags.AddAttributeError(path.Root("source_policy_documents").AtListIndex(i), "Duplicate statement sid",
+					fmt.Sprintf("The sid %q is used by more than one source policy document.", statement.Sid))
+				continue
+			}
+			merge(statement)
+		}
+	}
+
+	current := map[string]bool{}
+	for i, statement := range config.Statement {
+		sid := statement.Sid.ValueString()
+		if current[sid] {
+			diags.AddAttributeError(path.Root("statement").AtListIndex(i).AtName("sid"), "Duplicate statement sid",
+				fmt.Sprintf("The sid %q is used by more than one statement.", sid))
+			continue
+		}
+		if sid != "" {
+			current[sid] = true
+		}
+		merge(buildPolicyDocumentStatement(statement))
+	}
+
+	for i, document := range config.OverridePolicyDocuments {
+		for _, statement := range parse("override_policy_documents", i, document) {
+			merge(statement)
+		}
+	}
+	return statements, diags
+}
+
+func buildPolicyDocumentStatement(statement policyDocumentStatementModel) rustfs.PolicyStatement {
+	effect := statement.Effect.ValueString()
+	if effect == "" {
+		effect = "Allow"
+	}
+	built := buildPolicyStatements([]policyStatementModel{{
+		Sid:         statement.Sid,
+		Effect:      effect,
+		Action:      statement.Actions,
+		NotAction:   statement.NotActions,
+		Ressource:   statement.Resources,
+		NotResource: statement.NotResources,
+		Condition:   statement.Condition,
+	}})[0]
+	for _, principal := range statement.Principals {
+		if built.Principal == nil {
+			built.Principal = rustfs.PolicyPrincipal{}
+		}
+		built.Principal[principal.Type] = append(built.Principal[principal.Type], principal.Identifiers...)
+	}
+	return built
+}
+
+// policyActionValidator checks that an action is known to RustFS.
+type policyActionValidator struct{}
+
+func (v policyActionValidator) Description(_ context.Context) string {
+	return "value must be *, an S3 or admin action known to RustFS or a wildcard matching one"
+}
+
+func (v policyActionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v policyActionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if !rustfs.IsPolicyAction(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Unknown policy action",
+			fmt.Sprintf("%q is not an S3 or admin action known to RustFS, e.g. s3:GetObject, s3:Get* or admin:CreateUser.", req.ConfigValue.ValueString()))
+	}
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+func TestIamPolicyDocumentDataSourceMetadata(t *testing.T) {
+	d := NewIamPolicyDocumentDataSource()
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(nil, datasource.MetadataRequest{ProviderTypeName: "rustfs"}, resp)
+
+	if resp.TypeName != "rustfs_iam_policy_document" {
+		t.Errorf("expected rustfs_iam_policy_document, got %s", resp.TypeName)
+	}
+}
+
+func TestIamPolicyDocumentDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	d := NewIamPolicyDocumentDataSource()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	config := tfsdk.State{Schema: schemaResp.Schema, Raw: testNullObject(schemaResp.Schema.Type())}
+	config.Set(ctx, &IamPolicyDocumentDataSourceModel{
+		Version: types.StringNull(),
+		Statement: []policyDocumentStatementModel{
+			{
+				Sid:       types.StringValue("PublicRead"),
+				Effect:    types.StringNull(),
+				Actions:   []string{"s3:GetObject"},
+				Resources: []string{"arn:aws:s3:::public/*"},
+				Principals: []policyDocumentPrincipalModel{
+					{Type: "AWS", Identifiers: []string{"*"}},
+				},
+				Condition: []policyConditionModel{
+					{Test: "IpAddress", Variable: "aws:SourceIp", Values: []string{"10.0.0.0/8"}},
+				},
+			},
+		},
+		Json: types.StringNull(),
+	})
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: testNullObject(schemaResp.Schema.Type())}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", resp.Diagnostics)
+	}
+	var model IamPolicyDocumentDataSourceModel
+	resp.State.Get(ctx, &model)
+	if model.Version.ValueString() != "2012-10-17" {
+		t.Errorf("expected the default version, got %s", model.Version)
+	}
+
+	policy, err := rustfs.ParsePolicy([]byte(model.Json.ValueString()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	statement := policy.Statement[0]
+	if statement.Sid != "PublicRead" || statement.Effect != "Allow" || statement.Principal["AWS"][0] != "*" || statement.Condition["IpAddress"]["aws:SourceIp"].Strings()[0] != "10.0.0.0/8" {
+		t.Errorf("unexpected statement in %s", model.Json.ValueString())
+	}
+}
+
+func TestBuildPolicyDocument_Merge(t *testing.T) {
+	source := `{"Version":"2012-10-17","Statement":[{"Sid":"Read","Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::a/*"},{"Effect":"Allow","Action":"s3:ListBucket","Resource":"arn:aws:s3:::a"}]}`
+	override := `{"Statement":[{"Sid":"Write","Effect":"Deny","Action":"s3:PutObject","Resource":"*"},{"Sid":"Extra","Effect":"Allow","Action":"s3:ListAllMyBuckets","Resource":"*"}]}`
+	config := IamPolicyDocumentDataSourceModel{
+		SourcePolicyDocuments:   []string{source},
+		OverridePolicyDocuments: []string{override},
+		Statement: []policyDocumentStatementModel{
+			{Sid: types.StringValue("Read"), Actions: []string{"s3:GetObject"}, Resources: []string{"arn:aws:s3:::b/*"}},
+			{Sid: types.StringValue("Write"), Actions: []string{"s3:PutObject"}, Resources: []string{"arn:aws:s3:::b/*"}},
+		},
+	}
+	statements, diags := buildPolicyDocument(config)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	encoded, _ := json.Marshal(statements)
+	if len(statements) != 4 {
+		t.Fatalf("expected 4 statements, got %s", encoded)
+	}
+	// Statements keep the position of the statement they replace.
+	if statements[0].Sid != "Read" || statements[0].Resource[0] != "arn:aws:s3:::b/*" {
+		t.Errorf("expected the statement to replace the source statement, got %s", encoded)
+	}
+	if statements[1].Sid != "" || statements[1].Action[0] != "s3:ListBucket" {
+		t.Errorf("expected the source statement without sid, got %s", encoded)
+	}
+	if statements[2].Sid != "Write" || statements[2].Effect != "Deny" {
+		t.Errorf("expected the override to replace the statement, got %s", encoded)
+	}
+	if statements[3].Sid != "Extra" {
+		t.Errorf("expected the override statement to be added, got %s", encoded)
+	}
+}
+
+func TestBuildPolicyDocument_Errors(t *testing.T) {
+	source := `{"Statement":[{"Sid":"Read","Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`
+	for name, config := range map[string]IamPolicyDocumentDataSourceModel{
+		"duplicate source sid": {SourcePolicyDocuments: []string{source, source}},
+		"duplicate statement sid": {Statement: []policyDocumentStatementModel{
+			{Sid: types.StringValue("Read"), Actions: []string{"s3:GetObject"}},
+			{Sid: types.StringValue("Read"), Actions: []string{"s3:PutObject"}},
+		}},
+		"invalid override": {OverridePolicyDocuments: []string{`{"Statement":`}},
+	} {
+		if _, diags := buildPolicyDocument(config); !diags.HasError() {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPolicyActionValidator(t *testing.T) {
+	for action, valid := range map[string]bool{
+		"s3:GetObject":     true,
+		"s3:List*":         true,
+		"admin:CreateUser": true,
+		"s3:GetObjects":    false,
+		"iam:CreateUser":   false,
+	} {
+		resp := &validator.StringResponse{}
+		policyActionValidator{}.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("actions"),
+			ConfigValue: types.StringValue(action),
+		}, resp)
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("unexpected diagnostics for %s: %v", action, resp.Diagnostics)
+		}
+	}
+}
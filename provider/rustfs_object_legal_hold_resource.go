@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+var (
+	_ resource.Resource                = &ObjectLegalHoldResource{}
+	_ resource.ResourceWithImportState = &ObjectLegalHoldResource{}
+)
+
+type ObjectLegalHoldResource struct {
+	client *AllClient
+}
+
+type objectLegalHoldResourceModel struct {
+	Bucket    types.String `tfsdk:"bucket"`
+	Key       types.String `tfsdk:"key"`
+	VersionId types.String `tfsdk:"version_id"`
+	Status    types.String `tfsdk:"status"`
+}
+
+func NewObjectLegalHoldResource() resource.Resource {
+	return &ObjectLegalHoldResource{}
+}
+
+func (r *ObjectLegalHoldResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_legal_hold"
+}
+
+// objectVersionAttributes are the attributes identifying an object version
+// in rustfs_object_legal_hold and rustfs_object_retention.
+func objectVersionAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"bucket": schema.StringAttribute{
+			Required:      true,
+			Description:   "Name of the bucket. The bucket must have object lock enabled. Changing this forces recreation.",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+		},
+		"key": schema.StringAttribute{
+			Required:      true,
+			Description:   "Key of the object. Changing this forces recreation.",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+		},
+		"version_id": schema.StringAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Version of the object. Defaults to the current version when the resource is created. Changing this forces recreation.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplaceIfConfigured(),
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	}
+}
+
+func (r *ObjectLegalHoldResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := objectVersionAttributes()
+	attributes["status"] = schema.StringAttribute{
+		Optional:    true,
+		Computed:    true,
+		Description: "Legal hold status: ON or OFF. Default: ON.",
+		Default:     stringdefault.StaticString(string(minio.LegalHoldEnabled)),
+		Validators: []validator.String{
+			stringvalidator.OneOf(string(minio.LegalHoldEnabled), string(minio.LegalHoldDisabled)),
+		},
+	}
+	resp.Schema = schema.Schema{
+		Description:         "Manage the legal hold of a RustFS object",
+		MarkdownDescription: "Manage the legal hold of an object version. An object version on legal hold cannot be deleted, independent of its retention. Destroying the resource releases the legal hold.",
+		Attributes:          attributes,
+	}
+}
+
+func (r *ObjectLegalHoldResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*AllClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AllClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *ObjectLegalHoldResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan objectLegalHoldResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	versionID, err := objectVersionID(ctx, r.client.Minio, plan.Bucket.ValueString(), plan.Key.ValueString(), plan.VersionId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading object",
+			"Could not read object version: "+err.Error(),
+		)
+		return
+	}
+	plan.VersionId = types.StringValue(versionID)
+
+	if err := r.setLegalHold(ctx, plan, plan.Status.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error setting legal hold",
+			"Could not set legal hold: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ObjectLegalHoldResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state objectLegalHoldResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported legal holds refer to the current version.
+	versionID, err := objectVersionID(ctx, r.client.Minio, state.Bucket.ValueString(), state.Key.ValueString(), state.VersionId)
+	if err == nil {
+		state.VersionId = types.StringValue(versionID)
+		var status *minio.LegalHoldStatus
+		status, err = r.client.Minio.GetObjectLegalHold(ctx, state.Bucket.ValueString(), state.Key.ValueString(), minio.GetObjectLegalHoldOptions{
+			VersionID: versionID,
+		})
+		if err == nil {
+			state.Status = types.StringValue(string(*status))
+		}
+	}
+	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading legal hold",
+			"Could not read legal hold: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ObjectLegalHoldResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan objectLegalHoldResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setLegalHold(ctx, plan, plan.Status.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating legal hold",
+			"Could not update legal hold: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ObjectLegalHoldResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data objectLegalHoldResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setLegalHold(ctx, data, string(minio.LegalHoldDisabled))
+	if err != nil && !rustfs.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error releasing legal hold",
+			"Could not release legal hold: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the legal hold of the current object version by
+// "<bucket>/<key>".
+func (r *ObjectLegalHoldResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importObjectVersion(ctx, req, resp)
+}
+
+func (r *ObjectLegalHoldResource) setLegalHold(ctx context.Context, data objectLegalHoldResourceModel, status string) error {
+	legalHold := minio.LegalHoldStatus(status)
+	return r.client.Minio.PutObjectLegalHold(ctx, data.Bucket.ValueString(), data.Key.ValueString(), minio.PutObjectLegalHoldOptions{
+		VersionID: data.VersionId.ValueString(),
+		Status:    &legalHold,
+	})
+}
+
+// objectVersionID returns versionID or the current version of the object
+// if versionID is not known.
+func objectVersionID(ctx context.Context, client *minio.Client, bucket, key string, versionID types.String) (string, error) {
+	if versionID.ValueString() != "" {
+		return versionID.ValueString(), nil
+	}
+	info, err := client.StatObject(ctx, bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return "", err
+	}
+	return info.VersionID, nil
+}
+
+func importObjectVersion(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	bucket, key, ok := strings.Cut(req.ID, "/")
+	if !ok || bucket == "" || key == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Expected <bucket>/<key>, got: %q", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket"), bucket)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), key)...)
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
+)
+
+// testLockedObject creates a bucket with object lock and an object in it
+// and returns the version of the object.
+func testLockedObject(t *testing.T, client *AllClient, bucket, key string) string {
+	t.Helper()
+	ctx := context.Background()
+	if err := client.Minio.MakeBucket(ctx, bucket, minio.MakeBucketOptions{ObjectLocking: true}); err != nil {
+		t.Fatal(err)
+	}
+	info, err := client.Minio.PutObject(ctx, bucket, key, strings.NewReader("report"), 6, minio.PutObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info.VersionID
+}
+
+func TestObjectLegalHoldResourceMetadata(t *testing.T) {
+	r := NewObjectLegalHoldResource()
+	resp := &resource.MetadataResponse{}
+	r.Metadata(nil, resource.MetadataRequest{ProviderTypeName: "rustfs"}, resp)
+
+	if resp.TypeName != "rustfs_object_legal_hold" {
+		t.Errorf("expected rustfs_object_legal_hold, got %s", resp.TypeName)
+	}
+}
+
+func TestObjectLegalHoldResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	versionID := testLockedObject(t, client, "records", "reports/2026.pdf")
+	r := NewObjectLegalHoldResource()
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &objectLegalHoldResourceModel{
+		Bucket:    types.StringValue("records"),
+		Key:       types.StringValue("reports/2026.pdf"),
+		VersionId: types.StringUnknown(),
+		Status:    types.StringValue("ON"),
+	})
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+	var created objectLegalHoldResourceModel
+	createResp.State.Get(ctx, &created)
+	if created.VersionId.ValueString() != versionID {
+		t.Errorf("expected the current version %s, got %s", versionID, created.VersionId)
+	}
+
+	// The version cannot be deleted while on legal hold.
+	err := client.Minio.RemoveObject(ctx, "records", "reports/2026.pdf", minio.RemoveObjectOptions{VersionID: versionID})
+	if minio.ToErrorResponse(err).Code != "AccessDenied" {
+		t.Errorf("expected AccessDenied, got %v", err)
+	}
+
+	importResp := &resource.ImportStateResponse{State: testResourceState(t, r, client)}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: "records/reports/2026.pdf"}, importResp)
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var imported objectLegalHoldResourceModel
+	readResp.State.Get(ctx, &imported)
+	if imported != created {
+		t.Errorf("unexpected imported state:\n got %+v\nwant %+v", imported, created)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	if err := client.Minio.RemoveObject(ctx, "records", "reports/2026.pdf", minio.RemoveObjectOptions{VersionID: versionID}); err != nil {
+		t.Errorf("expected the released version to be deletable: %v", err)
+	}
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected deleted object version to be removed from state")
+	}
+}
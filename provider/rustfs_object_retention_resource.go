@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+var (
+	_ resource.Resource                = &ObjectRetentionResource{}
+	_ resource.ResourceWithImportState = &ObjectRetentionResource{}
+)
+
+type ObjectRetentionResource struct {
+	client *AllClient
+}
+
+type objectRetentionResourceModel struct {
+	Bucket                    types.String `tfsdk:"bucket"`
+	Key                       types.String `tfsdk:"key"`
+	VersionId                 types.String `tfsdk:"version_id"`
+	Mode                      types.String `tfsdk:"mode"`
+	RetainUntilDate           types.String `tfsdk:"retain_until_date"`
+	BypassGovernanceRetention types.Bool   `tfsdk:"bypass_governance_retention"`
+}
+
+var rfc3339Pattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`)
+
+func NewObjectRetentionResource() resource.Resource {
+	return &ObjectRetentionResource{}
+}
+
+func (r *ObjectRetentionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_retention"
+}
+
+func (r *ObjectRetentionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := objectVersionAttributes()
+	attributes["mode"] = schema.StringAttribute{
+		Required:    true,
+		Description: "Retention mode: COMPLIANCE or GOVERNANCE.",
+		Validators: []validator.String{
+			stringvalidator.OneOf(string(minio.Compliance), string(minio.Governance)),
+		},
+	}
+	attributes["retain_until_date"] = schema.StringAttribute{
+		Required:    true,
+		Description: "Date until the object version is retained, in RFC 3339 format, e.g. 2030-01-01T00:00:00Z.",
+		Validators: []validator.String{
+			stringvalidator.RegexMatches(rfc3339Pattern, "must be an RFC 3339 date, e.g. 2030-01-01T00:00:00Z"),
+		},
+	}
+	attributes["bypass_governance_retention"] = schema.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+		Description: "Allow shortening or removing GOVERNANCE retention. Requires the s3:BypassGovernanceRetention permission. Default: false.",
+	}
+	resp.Schema = schema.Schema{
+		Description:         "Manage the retention of a RustFS object",
+		MarkdownDescription: "Manage the retention of an object version. COMPLIANCE retention can only be extended. GOVERNANCE retention can be shortened and is removed on destroy if `bypass_governance_retention` is set.",
+		Attributes:          attributes,
+	}
+}
+
+func (r *ObjectRetentionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*AllClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AllClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *ObjectRetentionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan objectRetentionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	versionID, err := objectVersionID(ctx, r.client.Minio, plan.Bucket.ValueString(), plan.Key.ValueString(), plan.VersionId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading object",
+			"Could not read object version: "+err.Error(),
+		)
+		return
+	}
+	plan.VersionId = types.StringValue(versionID)
+
+	resp.Diagnostics.Append(r.setRetention(ctx, plan, "Error setting object retention", "Could not set object retention: ")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ObjectRetentionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state objectRetentionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported retentions refer to the current version.
+	versionID, err := objectVersionID(ctx, r.client.Minio, state.Bucket.ValueString(), state.Key.ValueString(), state.VersionId)
+	var mode *minio.RetentionMode
+	var until *time.Time
+	if err == nil {
+		state.VersionId = types.StringValue(versionID)
+		mode, until, err = r.client.Minio.GetObjectRetention(ctx, state.Bucket.ValueString(), state.Key.ValueString(), versionID)
+	}
+	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading object retention",
+			"Could not read object retention: "+err.Error(),
+		)
+		return
+	}
+	if mode == nil || *mode == "" || until == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Mode = types.StringValue(string(*mode))
+	// Keep the configured format as long as it is the same point in time.
+	if prior, err := time.Parse(time.RFC3339, state.RetainUntilDate.ValueString()); err != nil || !prior.Equal(*until) {
+		state.RetainUntilDate = types.StringValue(until.UTC().Format(time.RFC3339))
+	}
+	if state.BypassGovernanceRetention.IsNull() {
+		state.BypassGovernanceRetention = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ObjectRetentionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan objectRetentionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setRetention(ctx, plan, "Error updating object retention", "Could not update object retention: ")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ObjectRetentionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data objectRetentionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	until, err := time.Parse(time.RFC3339, data.RetainUntilDate.ValueString())
+	if err == nil && !until.After(time.Now()) {
+		return
+	}
+	if data.Mode.ValueString() != string(minio.Governance) || !data.BypassGovernanceRetention.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Object retention not removed",
+			fmt.Sprintf("The %s retention of %s/%s stays in place until %s. Only GOVERNANCE retention with bypass_governance_retention can be removed.",
+				data.Mode.ValueString(), data.Bucket.ValueString(), data.Key.ValueString(), data.RetainUntilDate.ValueString()),
+		)
+		return
+	}
+
+	// An empty retention removes it.
+	err = r.client.Minio.PutObjectRetention(ctx, data.Bucket.ValueString(), data.Key.ValueString(), minio.PutObjectRetentionOptions{
+		GovernanceBypass: true,
+		VersionID:        data.VersionId.ValueString(),
+	})
+	if err != nil && !rustfs.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error removing object retention",
+			"Could not remove object retention: "+err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the retention of the current object version by
+// "<bucket>/<key>".
+func (r *ObjectRetentionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importObjectVersion(ctx, req, resp)
+}
+
+// setRetention applies the planned retention.
+func (r *ObjectRetentionResource) setRetention(ctx context.Context, plan objectRetentionResourceModel, summary, detail string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	until, err := time.Parse(time.RFC3339, plan.RetainUntilDate.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("retain_until_date"), "Invalid retain_until_date", "Expected an RFC 3339 date, e.g. 2030-01-01T00:00:00Z: "+err.Error())
+		return diags
+	}
+	mode := minio.RetentionMode(plan.Mode.ValueString())
+	err = r.client.Minio.PutObjectRetention(ctx, plan.Bucket.ValueString(), plan.Key.ValueString(), minio.PutObjectRetentionOptions{
+		GovernanceBypass: plan.BypassGovernanceRetention.ValueBool(),
+		Mode:             &mode,
+		RetainUntilDate:  &until,
+		VersionID:        plan.VersionId.ValueString(),
+	})
+	if err != nil {
+		diags.AddError(summary, detail+err.Error())
+	}
+	return diags
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestObjectRetentionResourceMetadata(t *testing.T) {
+	r := NewObjectRetentionResource()
+	resp := &resource.MetadataResponse{}
+	r.Metadata(nil, resource.MetadataRequest{ProviderTypeName: "rustfs"}, resp)
+
+	if resp.TypeName != "rustfs_object_retention" {
+		t.Errorf("expected rustfs_object_retention, got %s", resp.TypeName)
+	}
+}
+
+func TestObjectRetentionResourceGovernanceBypass(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	versionID := testLockedObject(t, client, "records", "report.pdf")
+	r := NewObjectRetentionResource()
+	until := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	model := objectRetentionResourceModel{
+		Bucket:                    types.StringValue("records"),
+		Key:                       types.StringValue("report.pdf"),
+		VersionId:                 types.StringValue(versionID),
+		Mode:                      types.StringValue("GOVERNANCE"),
+		RetainUntilDate:           types.StringValue(until.Format(time.RFC3339)),
+		BypassGovernanceRetention: types.BoolValue(false),
+	}
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &model)
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	// Shortening governance retention fails without the bypass.
+	model.RetainUntilDate = types.StringValue(until.Add(-time.Hour).Format(time.RFC3339))
+	plan.Set(ctx, &model)
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan(plan), State: createResp.State}, updateResp)
+	if !updateResp.Diagnostics.HasError() {
+		t.Fatal("expected shortening without bypass to fail")
+	}
+
+	model.BypassGovernanceRetention = types.BoolValue(true)
+	plan.Set(ctx, &model)
+	updateResp = &resource.UpdateResponse{State: createResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan(plan), State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("update diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	readResp := &resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var read objectRetentionResourceModel
+	readResp.State.Get(ctx, &read)
+	if read != model {
+		t.Errorf("unexpected state after read:\n got %+v\nwant %+v", read, model)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: updateResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	if err := client.Minio.RemoveObject(ctx, "records", "report.pdf", minio.RemoveObjectOptions{VersionID: versionID}); err != nil {
+		t.Errorf("expected the version to be deletable after removing the retention: %v", err)
+	}
+}
+
+func TestObjectRetentionResourceComplianceDelete(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	versionID := testLockedObject(t, client, "records", "report.pdf")
+	r := NewObjectRetentionResource()
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &objectRetentionResourceModel{
+		Bucket:                    types.StringValue("records"),
+		Key:                       types.StringValue("report.pdf"),
+		VersionId:                 types.StringUnknown(),
+		Mode:                      types.StringValue("COMPLIANCE"),
+		RetainUntilDate:           types.StringValue(time.Now().Add(time.Hour).UTC().Format(time.RFC3339)),
+		BypassGovernanceRetention: types.BoolValue(true),
+	})
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	// Compliance retention stays in place, destroy only warns.
+	deleteResp := &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() || deleteResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", deleteResp.Diagnostics)
+	}
+	mode, _, err := client.Minio.GetObjectRetention(ctx, "records", "report.pdf", versionID)
+	if err != nil || *mode != minio.Compliance {
+		t.Errorf("expected compliance retention to remain, got %v: %v", mode, err)
+	}
+}
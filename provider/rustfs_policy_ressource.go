@@ -3,11 +3,19 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
@@ -15,15 +23,31 @@ import (
 
 // Data models.
 type policyStatementModel struct {
-	Effect    string   `tfsdk:"effect"`
-	Action    []string `tfsdk:"action"`
-	Ressource []string `tfsdk:"ressource"`
+	Sid         types.String           `tfsdk:"sid"`
+	Effect      string                 `tfsdk:"effect"`
+	Principal   map[string][]string    `tfsdk:"principal"`
+	Action      []string               `tfsdk:"action"`
+	NotAction   []string               `tfsdk:"not_action"`
+	Ressource   []string               `tfsdk:"ressource"`
+	NotResource []string               `tfsdk:"not_resource"`
+	Condition   []policyConditionModel `tfsdk:"condition"`
+}
+
+// policyConditionModel is one key of a condition operator, e.g.
+// StringLike on s3:prefix.
+type policyConditionModel struct {
+	Test     string   `tfsdk:"test"`
+	Variable string   `tfsdk:"variable"`
+	Values   []string `tfsdk:"values"`
 }
 
 type policyResourceModel struct {
-	Name      types.String           `tfsdk:"name"`
-	Version   types.String           `tfsdk:"version"`
-	Statement []policyStatementModel `tfsdk:"statement"`
+	Name            types.String           `tfsdk:"name"`
+	Version         types.String           `tfsdk:"version"`
+	Statement       []policyStatementModel `tfsdk:"statement"`
+	Policy          jsontypes.Normalized   `tfsdk:"policy"`
+	CanonicalPolicy types.String           `tfsdk:"canonical_policy"`
+	Timeouts        timeouts.Value         `tfsdk:"timeouts"`
 }
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -48,7 +72,7 @@ func (r *PolicyRessource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 // Schema defines the schema for the resource.
-func (r *PolicyRessource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *PolicyRessource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description:         "Manage S3 policies",
 		MarkdownDescription: "Manage S3 policies",
@@ -61,25 +85,98 @@ func (r *PolicyRessource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed: true,
 				Default:  stringdefault.StaticString("2012-10-17"),
 			},
+			"policy": schema.StringAttribute{
+				CustomType:  jsontypes.NormalizedType{},
+				Optional:    true,
+				Description: "Policy JSON document, e.g. from jsonencode or a file. Exactly one of policy and statement must be set. Documents differing only in whitespace, key order, value order or single string values are equal.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("statement")),
+				},
+			},
+			"canonical_policy": schema.StringAttribute{
+				Computed:    true,
+				Description: "Canonical JSON form of the stored policy document, for either input style.",
+			},
 			"statement": schema.ListNestedAttribute{
-				Required: true,
+				Optional:    true,
+				Description: "Statements of the policy. Exactly one of policy and statement must be set.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
+						"sid": schema.StringAttribute{
+							Optional:    true,
+							Description: "Statement identifier.",
+						},
 						"effect": schema.StringAttribute{
-							Required: true,
+							Required:    true,
+							Description: "Allow or Deny.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("Allow", "Deny"),
+							},
+						},
+						"principal": schema.MapAttribute{
+							ElementType: types.SetType{ElemType: types.StringType},
+							Optional:    true,
+							Description: "Principals by type, e.g. { AWS = [\"*\"] }. The wildcard principal \"*\" is read back as { AWS = [\"*\"] }.",
 						},
 						"action": schema.SetAttribute{
 							ElementType: types.StringType,
-							Required:    true,
+							Optional:    true,
+							Description: "Actions the statement applies to. Exactly one of action and not_action must be set.",
+							Validators: []validator.Set{
+								setvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("not_action")),
+							},
+						},
+						"not_action": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Actions the statement does not apply to.",
 						},
 						"ressource": schema.SetAttribute{
 							ElementType: types.StringType,
-							Required:    true,
+							Optional:    true,
+							Description: "Resources the statement applies to. Exactly one of ressource and not_resource must be set.",
+							Validators: []validator.Set{
+								setvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("not_resource")),
+							},
+						},
+						"not_resource": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Resources the statement does not apply to.",
+						},
+						"condition": schema.SetNestedAttribute{
+							Optional:    true,
+							Description: "Conditions of the statement. All conditions must match.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"test": schema.StringAttribute{
+										Required:    true,
+										Description: "Condition operator, e.g. StringLike or IpAddress.",
+									},
+									"variable": schema.StringAttribute{
+										Required:    true,
+										Description: "Condition key, e.g. s3:prefix or aws:SourceIp.",
+									},
+									"values": schema.SetAttribute{
+										ElementType: types.StringType,
+										Required:    true,
+										Description: "Values of the condition key. Any value may match.",
+									},
+								},
+							},
 						},
 					},
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -110,22 +207,20 @@ func (r *PolicyRessource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	statements := []rustfs.PolicyStatement{}
-	for _, i := range plan.Statement {
-		statements = append(statements,
-			rustfs.PolicyStatement{
-				Effect:   i.Effect,
-				Action:   i.Action,
-				Resource: i.Ressource,
-			},
-		)
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	policy := rustfs.Policy{
-		Version:   plan.Version.ValueString(),
-		Name:      plan.Name.ValueString(),
-		Statement: statements,
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	policy, diags := buildPolicy(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	err := r.client.RustClient.CreatePolicy(policy)
+	err := r.client.RustClient.CreatePolicy(ctx, policy)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating policy",
@@ -134,6 +229,7 @@ func (r *PolicyRessource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 	tflog.Trace(ctx, "created a resource")
+	resp.Diagnostics.Append(setCanonicalPolicy(&plan, policy)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 
 }
@@ -141,21 +237,28 @@ func (r *PolicyRessource) Create(ctx context.Context, req resource.CreateRequest
 // Read refreshes the Terraform state with the latest data.
 func (r *PolicyRessource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state policyResourceModel
+	// Read Terraform prior state data into the model
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	// Make the read request
-	actual, err := r.client.RustClient.ReadPolicy(state.Name.ValueString())
+	actual, err := r.client.RustClient.ReadPolicy(ctx, state.Name.ValueString())
 	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading policy",
 			"Could not read policy, unexpected error: "+err.Error(),
@@ -165,15 +268,13 @@ func (r *PolicyRessource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	state.Name = types.StringValue(actual.Name)
 	state.Version = types.StringValue(actual.Version)
-	state.Statement = []policyStatementModel{}
-	for _, read_statement := range actual.Statement {
-		state.Statement = append(state.Statement,
-			policyStatementModel{
-				Effect:    read_statement.Effect,
-				Action:    read_statement.Action,
-				Ressource: read_statement.Resource,
-			},
-		)
+	resp.Diagnostics.Append(setCanonicalPolicy(&state, actual)...)
+	if state.Policy.IsNull() {
+		state.Statement = flattenPolicyStatements(actual.Statement)
+	} else if !policyEqual(state.Policy.ValueString(), state.CanonicalPolicy.ValueString()) {
+		// The configured document is kept as long as it is equal to the
+		// stored one.
+		state.Policy = jsontypes.NewNormalizedValue(state.CanonicalPolicy.ValueString())
 	}
 	// Save update status
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -191,22 +292,20 @@ func (r *PolicyRessource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	statements := []rustfs.PolicyStatement{}
-	for _, i := range plan.Statement {
-		statements = append(statements,
-			rustfs.PolicyStatement{
-				Effect:   i.Effect,
-				Action:   i.Action,
-				Resource: i.Ressource,
-			},
-		)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	policy := rustfs.Policy{
-		Version:   plan.Version.ValueString(),
-		Name:      plan.Name.ValueString(),
-		Statement: statements,
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	policy, diags := buildPolicy(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	err := r.client.RustClient.CreatePolicy(policy)
+	err := r.client.RustClient.CreatePolicy(ctx, policy)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating policy",
@@ -215,6 +314,7 @@ func (r *PolicyRessource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	resp.Diagnostics.Append(setCanonicalPolicy(&plan, policy)...)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -233,8 +333,19 @@ func (r *PolicyRessource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	err := r.client.RustClient.DeletePolicy(data.Name.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.RustClient.DeletePolicy(ctx, data.Name.ValueString())
 	if err != nil {
+		if rustfs.IsNotFound(err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error deleting policy",
 			"Could not delete policy, unexpected error: "+err.Error(),
@@ -246,3 +357,120 @@ func (r *PolicyRessource) Delete(ctx context.Context, req resource.DeleteRequest
 func (r *PolicyRessource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
+
+// buildPolicy returns the policy from either the policy document or the
+// statements of plan.
+func buildPolicy(plan policyResourceModel) (rustfs.Policy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	policy := rustfs.Policy{
+		Version: plan.Version.ValueString(),
+		Name:    plan.Name.ValueString(),
+	}
+	if plan.Policy.IsNull() {
+		policy.Statement = buildPolicyStatements(plan.Statement)
+		return policy, diags
+	}
+	parsed, err := rustfs.ParsePolicy([]byte(plan.Policy.ValueString()))
+	if err != nil {
+		diags.AddAttributeError(path.Root("policy"), "Invalid policy", "Could not parse the policy document: "+err.Error())
+		return policy, diags
+	}
+	if len(parsed.Statement) == 0 {
+		diags.AddAttributeError(path.Root("policy"), "Invalid policy", "The policy document has no statement.")
+		return policy, diags
+	}
+	policy.Statement = parsed.Statement
+	return policy, diags
+}
+
+func setCanonicalPolicy(model *policyResourceModel, policy rustfs.Policy) diag.Diagnostics {
+	var diags diag.Diagnostics
+	canonical, err := policy.CanonicalJSON()
+	if err != nil {
+		diags.AddError("Error encoding policy", "Could not encode the policy document: "+err.Error())
+		return diags
+	}
+	model.CanonicalPolicy = types.StringValue(string(canonical))
+	return diags
+}
+
+// policyEqual reports whether document has the canonical form canonical.
+func policyEqual(document, canonical string) bool {
+	parsed, err := rustfs.ParsePolicy([]byte(document))
+	if err != nil {
+		return false
+	}
+	parsedCanonical, err := parsed.CanonicalJSON()
+	return err == nil && string(parsedCanonical) == canonical
+}
+
+func buildPolicyStatements(statements []policyStatementModel) []rustfs.PolicyStatement {
+	built := []rustfs.PolicyStatement{}
+	for _, i := range statements {
+		statement := rustfs.PolicyStatement{
+			Sid:         i.Sid.ValueString(),
+			Effect:      i.Effect,
+			Action:      i.Action,
+			NotAction:   i.NotAction,
+			Resource:    i.Ressource,
+			NotResource: i.NotResource,
+		}
+		if len(i.Principal) > 0 {
+			statement.Principal = rustfs.PolicyPrincipal{}
+			for principalType, principals := range i.Principal {
+				statement.Principal[principalType] = principals
+			}
+		}
+		if len(i.Condition) > 0 {
+			statement.Condition = rustfs.PolicyCondition{}
+			for _, condition := range i.Condition {
+				if statement.Condition[condition.Test] == nil {
+					statement.Condition[condition.Test] = map[string]rustfs.ConditionValues{}
+				}
+				statement.Condition[condition.Test][condition.Variable] = append(statement.Condition[condition.Test][condition.Variable], rustfs.ConditionStrings(condition.Values...)...)
+			}
+		}
+		built = append(built, statement)
+	}
+	return built
+}
+
+func flattenPolicyStatements(statements []rustfs.PolicyStatement) []policyStatementModel {
+	flattened := []policyStatementModel{}
+	for _, read_statement := range statements {
+		statement := policyStatementModel{
+			Sid:         stringOrNull(read_statement.Sid),
+			Effect:      read_statement.Effect,
+			Action:      emptyToNil(read_statement.Action),
+			NotAction:   emptyToNil(read_statement.NotAction),
+			Ressource:   emptyToNil(read_statement.Resource),
+			NotResource: emptyToNil(read_statement.NotResource),
+		}
+		if len(read_statement.Principal) > 0 {
+			statement.Principal = map[string][]string{}
+			for principalType, principals := range read_statement.Principal {
+				statement.Principal[principalType] = principals
+			}
+		}
+		for _, test := range slices.Sorted(maps.Keys(read_statement.Condition)) {
+			keys := read_statement.Condition[test]
+			for _, variable := range slices.Sorted(maps.Keys(keys)) {
+				statement.Condition = append(statement.Condition, policyConditionModel{
+					Test:     test,
+					Variable: variable,
+					Values:   keys[variable].Strings(),
+				})
+			}
+		}
+		flattened = append(flattened, statement)
+	}
+	return flattened
+}
+
+// emptyToNil returns nil for an empty list, which is stored as null.
+func emptyToNil(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
@@ -1,9 +1,15 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
 )
 
 func TestAccPolicyResource(t *testing.T) {
@@ -26,3 +32,182 @@ resource "rustfs_policy" "test" {
 				)},
 		}})
 }
+
+func testPolicyStatements() []policyStatementModel {
+	return []policyStatementModel{
+		{
+			Sid:       types.StringValue("ListHome"),
+			Effect:    "Allow",
+			Action:    []string{"s3:ListBucket"},
+			Ressource: []string{"arn:aws:s3:::home"},
+			Condition: []policyConditionModel{
+				{Test: "StringLike", Variable: "s3:prefix", Values: []string{"home/${aws:username}/*"}},
+				{Test: "IpAddress", Variable: "aws:SourceIp", Values: []string{"10.0.0.0/8"}},
+			},
+		},
+		{
+			Sid:         types.StringNull(),
+			Effect:      "Deny",
+			Principal:   map[string][]string{"AWS": {"*"}},
+			NotAction:   []string{"s3:GetObject"},
+			NotResource: []string{"arn:aws:s3:::public/*"},
+		},
+	}
+}
+
+func TestBuildPolicyStatements(t *testing.T) {
+	statements := buildPolicyStatements(testPolicyStatements())
+	if len(statements) != 2 {
+		t.Fatalf("expected 2 statements, got %+v", statements)
+	}
+	if statements[0].Sid != "ListHome" || statements[0].Condition["StringLike"]["s3:prefix"].Strings()[0] != "home/${aws:username}/*" || statements[0].Principal != nil {
+		t.Errorf("unexpected statement %+v", statements[0])
+	}
+	if statements[1].Sid != "" || statements[1].Action != nil || statements[1].NotResource[0] != "arn:aws:s3:::public/*" || statements[1].Principal["AWS"][0] != "*" {
+		t.Errorf("unexpected statement %+v", statements[1])
+	}
+
+	flattened := flattenPolicyStatements(statements)
+	if !flattened[1].Sid.IsNull() || flattened[1].Ressource != nil || flattened[0].NotAction != nil {
+		t.Errorf("expected unset fields to be null, got %+v", flattened)
+	}
+	// Conditions are flattened ordered by operator and key.
+	if len(flattened[0].Condition) != 2 || flattened[0].Condition[0].Test != "IpAddress" || flattened[0].Condition[1].Variable != "s3:prefix" {
+		t.Errorf("unexpected conditions %+v", flattened[0].Condition)
+	}
+}
+
+func TestPolicyRessourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	r := NewPolicyRessource()
+	plan := testResourceState(t, r, client)
+	var model policyResourceModel
+	plan.Get(ctx, &model)
+	model.Name = types.StringValue("conditions")
+	model.Version = types.StringValue("2012-10-17")
+	model.Statement = testPolicyStatements()
+	plan.Set(ctx, &model)
+
+	createResp := &fwresource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	importResp := &fwresource.ImportStateResponse{State: testResourceState(t, r, client)}
+	r.(fwresource.ResourceWithImportState).ImportState(ctx, fwresource.ImportStateRequest{ID: "conditions"}, importResp)
+	readResp := &fwresource.ReadResponse{State: importResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var imported policyResourceModel
+	readResp.State.Get(ctx, &imported)
+	if len(imported.Statement) != 2 || imported.Statement[0].Sid.ValueString() != "ListHome" || len(imported.Statement[0].Condition) != 2 {
+		t.Fatalf("unexpected imported policy %+v", imported.Statement)
+	}
+	if imported.Statement[1].Principal["AWS"][0] != "*" || imported.Statement[1].NotAction[0] != "s3:GetObject" || imported.Statement[1].Action != nil {
+		t.Errorf("unexpected imported statement %+v", imported.Statement[1])
+	}
+
+	deleteResp := &fwresource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	readResp = &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected deleted policy to be removed from state")
+	}
+}
+
+func TestBuildPolicy_Document(t *testing.T) {
+	plan := policyResourceModel{
+		Name:    types.StringValue("document"),
+		Version: types.StringValue("2012-10-17"),
+		Policy:  jsontypes.NewNormalizedValue(`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::bucket/*"}}`),
+	}
+	policy, diags := buildPolicy(plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(policy.Statement) != 1 || policy.Statement[0].Action[0] != "s3:GetObject" || policy.Statement[0].Resource[0] != "arn:aws:s3:::bucket/*" {
+		t.Errorf("unexpected policy %+v", policy)
+	}
+
+	plan.Policy = jsontypes.NewNormalizedValue(`{"Version":"2012-10-17","Statement":[]}`)
+	if _, diags := buildPolicy(plan); !diags.HasError() {
+		t.Error("expected an error for a policy without statements")
+	}
+	plan.Policy = jsontypes.NewNormalizedValue(`{"Statement":[{"Effect":"Allow","Action":{"s3":1}}]}`)
+	if _, diags := buildPolicy(plan); !diags.HasError() {
+		t.Error("expected an error for an invalid action")
+	}
+}
+
+func TestPolicyRessourceCRUD_Document(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	r := NewPolicyRessource()
+	plan := testResourceState(t, r, client)
+	var model policyResourceModel
+	plan.Get(ctx, &model)
+	document := `{
+  "Version": "2012-10-17",
+  "Statement": [{"Effect": "Allow", "Action": ["s3:PutObject", "s3:GetObject"], "Resource": "arn:aws:s3:::bucket/*"}]
+}`
+	model.Name = types.StringValue("document")
+	model.Version = types.StringValue("2012-10-17")
+	model.Policy = jsontypes.NewNormalizedValue(document)
+	model.CanonicalPolicy = types.StringUnknown()
+	plan.Set(ctx, &model)
+
+	createResp := &fwresource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+	var created policyResourceModel
+	createResp.State.Get(ctx, &created)
+	canonical := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:PutObject"],"Resource":["arn:aws:s3:::bucket/*"]}]}`
+	if created.CanonicalPolicy.ValueString() != canonical {
+		t.Errorf("expected canonical policy %s, got %s", canonical, created.CanonicalPolicy.ValueString())
+	}
+
+	// The stored document is equal, so the configured one is kept.
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var refreshed policyResourceModel
+	readResp.State.Get(ctx, &refreshed)
+	if refreshed.Policy.ValueString() != document || refreshed.Statement != nil || refreshed.CanonicalPolicy.ValueString() != canonical {
+		t.Errorf("unexpected refreshed policy %+v", refreshed)
+	}
+
+	// A changed document on the server shows up as drift.
+	changed := rustfs.Policy{
+		Name: "document",
+		Statement: []rustfs.PolicyStatement{
+			{Effect: "Allow", Action: rustfs.StringList{"s3:*"}, Resource: rustfs.StringList{"arn:aws:s3:::bucket/*"}},
+		},
+	}
+	if err := client.RustClient.CreatePolicy(ctx, changed); err != nil {
+		t.Fatal(err)
+	}
+	readResp = &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	readResp.State.Get(ctx, &refreshed)
+	if refreshed.Policy.ValueString() != refreshed.CanonicalPolicy.ValueString() || refreshed.Policy.ValueString() == document {
+		t.Errorf("expected the stored document in state, got %s", refreshed.Policy.ValueString())
+	}
+
+	deleteResp := &fwresource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+}
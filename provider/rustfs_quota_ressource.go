@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -29,8 +30,9 @@ type quotaRessource struct {
 }
 
 type quotaRessourceModel struct {
-	Bucket types.String `tfsdk:"bucket"`
-	Quota  types.Int64  `tfsdk:"quota"`
+	Bucket   types.String   `tfsdk:"bucket"`
+	Quota    types.Int64    `tfsdk:"quota"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -39,7 +41,7 @@ func (r *quotaRessource) Metadata(_ context.Context, req resource.MetadataReques
 }
 
 // Schema defines the schema for the resource.
-func (r *quotaRessource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *quotaRessource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description:         "Manage buckets quota in rustfs",
 		MarkdownDescription: "Manage bucket quota in rustfs",
@@ -53,6 +55,14 @@ func (r *quotaRessource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Description: "Bytes of the quota",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -81,8 +91,17 @@ func (r *quotaRessource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	q := rustfs.Quota{Bucket: plan.Bucket.ValueString(), Quota: int(plan.Quota.ValueInt64()), Quota_Type: "HARD"}
-	_, err := r.client.RustClient.SetQuota(q)
+	_, err := r.client.RustClient.SetQuota(ctx, q)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating bucket quota",
@@ -102,14 +121,22 @@ func (r *quotaRessource) Read(ctx context.Context, req resource.ReadRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Read
-	read, err := r.client.RustClient.ReadQuota(state.Bucket.ValueString())
+	read, err := r.client.RustClient.ReadQuota(ctx, state.Bucket.ValueString())
 	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading bucket quota",
 			"Could not read bucket quota, unexpected error: "+err.Error(),
@@ -133,8 +160,16 @@ func (r *quotaRessource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	quota := rustfs.Quota{Bucket: plan.Bucket.ValueString(), Quota: int(plan.Quota.ValueInt64()), Quota_Type: "HARD"}
-	read, err := r.client.RustClient.SetQuota(quota)
+	read, err := r.client.RustClient.SetQuota(ctx, quota)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating bucket quota",
@@ -161,8 +196,19 @@ func (r *quotaRessource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.RustClient.DeletQuota(data.Bucket.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.RustClient.DeletQuota(ctx, data.Bucket.ValueString())
 	if err != nil {
+		if rustfs.IsNotFound(err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error deleting bucket quota",
 			"Could not delete bucket quota, unexpected error: "+err.Error(),
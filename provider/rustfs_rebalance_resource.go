@@ -59,7 +59,7 @@ func (r *RebalanceResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	if err := r.client.RustClient.StartRebalance(); err != nil {
+	if err := r.client.RustClient.StartRebalance(ctx); err != nil {
 		resp.Diagnostics.AddError("Error starting rebalance", "Could not start rebalance: "+err.Error())
 		return
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -15,11 +16,12 @@ import (
 )
 
 type serviceAccountResourceModel struct {
-	AccessKey   types.String `tfsdk:"access_key"`
-	SecretKey   types.String `tfsdk:"secret_key"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	TargetUser  types.String `tfsdk:"user"`
+	AccessKey   types.String   `tfsdk:"access_key"`
+	SecretKey   types.String   `tfsdk:"secret_key"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	TargetUser  types.String   `tfsdk:"user"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -44,7 +46,7 @@ func (r *ServiceAccountRessource) Metadata(_ context.Context, req resource.Metad
 }
 
 // Schema defines the schema for the resource.
-func (r *ServiceAccountRessource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *ServiceAccountRessource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description:         "Manage ServiceUser/API Keys",
 		MarkdownDescription: "Manage ServiceUser/API Keys",
@@ -73,6 +75,14 @@ func (r *ServiceAccountRessource) Schema(_ context.Context, _ resource.SchemaReq
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -103,6 +113,14 @@ func (r *ServiceAccountRessource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	account := rustfs.ServiceAccount{
 		Name:        plan.Name.ValueString(),
 		AccessKey:   plan.AccessKey.ValueString(),
@@ -110,7 +128,7 @@ func (r *ServiceAccountRessource) Create(ctx context.Context, req resource.Creat
 		Description: plan.Description.ValueString(),
 		TargetUser:  plan.TargetUser.ValueString(),
 	}
-	err := r.client.RustClient.CreateServiceAccount(account)
+	err := r.client.RustClient.CreateServiceAccount(ctx, account)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating service account",
@@ -126,21 +144,28 @@ func (r *ServiceAccountRessource) Create(ctx context.Context, req resource.Creat
 // Read refreshes the Terraform state with the latest data.
 func (r *ServiceAccountRessource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state serviceAccountResourceModel
+	// Read Terraform prior state data into the model
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	// Make the read request
-	actual, err := r.client.RustClient.ReadServiceAccount(state.AccessKey.ValueString())
+	actual, err := r.client.RustClient.ReadServiceAccount(ctx, state.AccessKey.ValueString())
 	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading service account",
 			"Could not read service account, unexpected error: "+err.Error(),
@@ -166,6 +191,14 @@ func (r *ServiceAccountRessource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	account := rustfs.ServiceAccount{
 		Name:        plan.Name.ValueString(),
 		AccessKey:   plan.AccessKey.ValueString(),
@@ -173,7 +206,7 @@ func (r *ServiceAccountRessource) Update(ctx context.Context, req resource.Updat
 		Description: plan.Description.ValueString(),
 		TargetUser:  plan.TargetUser.ValueString(),
 	}
-	err := r.client.RustClient.UpdateServiceAccount(account)
+	err := r.client.RustClient.UpdateServiceAccount(ctx, account)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating service account",
@@ -202,14 +235,26 @@ func (r *ServiceAccountRessource) Delete(ctx context.Context, req resource.Delet
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	account := rustfs.ServiceAccount{
 		Name:        data.Name.ValueString(),
 		AccessKey:   data.AccessKey.ValueString(),
 		SecretKey:   data.SecretKey.ValueString(),
 		Description: data.Description.ValueString(),
 	}
-	err := r.client.RustClient.DeleteServiceAccount(account)
+	err := r.client.RustClient.DeleteServiceAccount(ctx, account)
 	if err != nil {
+		if rustfs.IsNotFound(err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error deleting service account",
 			"Could not delete service account, unexpected error: "+err.Error(),
@@ -4,13 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
 )
 
 var (
@@ -23,11 +32,64 @@ type TierResource struct {
 }
 
 type tierResourceModel struct {
-	Name       types.String `tfsdk:"name"`
-	TierType   types.String `tfsdk:"tier_type"`
-	ConfigJson types.String `tfsdk:"config_json"`
+	Name        types.String    `tfsdk:"name"`
+	TierType    types.String    `tfsdk:"tier_type"`
+	ConfigJson  types.String    `tfsdk:"config_json"`
+	S3          *tierS3Model    `tfsdk:"s3"`
+	Minio       *tierS3Model    `tfsdk:"minio"`
+	Rustfs      *tierS3Model    `tfsdk:"rustfs"`
+	Aliyun      *tierS3Model    `tfsdk:"aliyun"`
+	Tencent     *tierS3Model    `tfsdk:"tencent"`
+	Huaweicloud *tierS3Model    `tfsdk:"huaweicloud"`
+	R2          *tierS3Model    `tfsdk:"r2"`
+	Azure       *tierAzureModel `tfsdk:"azure"`
+	Gcs         *tierGcsModel   `tfsdk:"gcs"`
+	Timeouts    timeouts.Value  `tfsdk:"timeouts"`
 }
 
+// tierS3Model configures backends speaking the S3 API.
+type tierS3Model struct {
+	Endpoint     types.String `tfsdk:"endpoint"`
+	AccessKey    types.String `tfsdk:"access_key"`
+	SecretKey    types.String `tfsdk:"secret_key"`
+	Bucket       types.String `tfsdk:"bucket"`
+	Prefix       types.String `tfsdk:"prefix"`
+	Region       types.String `tfsdk:"region"`
+	StorageClass types.String `tfsdk:"storage_class"`
+}
+
+type tierAzureModel struct {
+	Endpoint     types.String `tfsdk:"endpoint"`
+	AccountName  types.String `tfsdk:"account_name"`
+	AccountKey   types.String `tfsdk:"account_key"`
+	Bucket       types.String `tfsdk:"bucket"`
+	Prefix       types.String `tfsdk:"prefix"`
+	Region       types.String `tfsdk:"region"`
+	StorageClass types.String `tfsdk:"storage_class"`
+}
+
+type tierGcsModel struct {
+	Endpoint     types.String `tfsdk:"endpoint"`
+	Credentials  types.String `tfsdk:"credentials"`
+	Bucket       types.String `tfsdk:"bucket"`
+	Prefix       types.String `tfsdk:"prefix"`
+	Region       types.String `tfsdk:"region"`
+	StorageClass types.String `tfsdk:"storage_class"`
+}
+
+// tierTypes are the backends with a typed block, in the order they are
+// documented.
+var tierTypes = []string{"s3", "minio", "rustfs", "aliyun", "tencent", "huaweicloud", "r2", "azure", "gcs"}
+
+// tierCredentialKeys are the backend settings the tier edit endpoint
+// accepts. Changing any other setting replaces the tier.
+var tierCredentialKeys = []string{"access_key", "secret_key", "account_name", "account_key", "creds"}
+
+var (
+	tierNamePattern     = regexp.MustCompile(`^[A-Z0-9_-]+$`)
+	tierEndpointPattern = regexp.MustCompile(`^https?://[^/\s]+`)
+)
+
 func NewTierResource() resource.Resource {
 	return &TierResource{}
 }
@@ -36,30 +98,194 @@ func (r *TierResource) Metadata(_ context.Context, req resource.MetadataRequest,
 	resp.TypeName = req.ProviderTypeName + "_tier"
 }
 
-func (r *TierResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+// tierBlockPaths returns the paths of the typed backend blocks.
+func tierBlockPaths() []path.Expression {
+	var paths []path.Expression
+	for _, name := range tierTypes {
+		paths = append(paths, path.MatchRoot(name))
+	}
+	return paths
+}
+
+// tierCommonAttributes are the settings shared by all backends. Only
+// credentials can be edited, so they force recreation.
+func tierCommonAttributes(endpointRequired bool, endpointDescription string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"endpoint": schema.StringAttribute{
+			Required:      endpointRequired,
+			Optional:      !endpointRequired,
+			Description:   endpointDescription + " Changing this forces recreation.",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(tierEndpointPattern, "must be a URL starting with http:// or https://"),
+			},
+		},
+		"bucket": schema.StringAttribute{
+			Required:      true,
+			Description:   "Bucket (or container) on the backend the objects are transitioned to. Changing this forces recreation.",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"prefix": schema.StringAttribute{
+			Optional:      true,
+			Description:   "Prefix of the transitioned objects in the bucket. Changing this forces recreation.",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+		},
+		"region": schema.StringAttribute{
+			Optional:      true,
+			Description:   "Region of the bucket. Changing this forces recreation.",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+		},
+		"storage_class": schema.StringAttribute{
+			Optional:      true,
+			Description:   "Storage class of the transitioned objects. Changing this forces recreation.",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+		},
+	}
+}
+
+func tierS3Block(title string) schema.SingleNestedBlock {
+	attributes := tierCommonAttributes(true, "URL of the "+title+" endpoint.")
+	attributes["access_key"] = schema.StringAttribute{
+		Required:    true,
+		Description: "Access key for the backend.",
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+	attributes["secret_key"] = schema.StringAttribute{
+		Required:    true,
+		Sensitive:   true,
+		Description: "Secret key for the backend.",
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+	return schema.SingleNestedBlock{
+		Description: title + " backend.",
+		Attributes:  attributes,
+	}
+}
+
+func (r *TierResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	azure := tierCommonAttributes(false, "URL of the blob service. Defaults to https://<account_name>.blob.core.windows.net.")
+	azure["account_name"] = schema.StringAttribute{
+		Required:    true,
+		Description: "Storage account name.",
+	}
+	azure["account_key"] = schema.StringAttribute{
+		Required:    true,
+		Sensitive:   true,
+		Description: "Storage account key.",
+	}
+	gcs := tierCommonAttributes(false, "URL of the GCS endpoint. Defaults to https://storage.googleapis.com.")
+	gcs["credentials"] = schema.StringAttribute{
+		Required:    true,
+		Sensitive:   true,
+		Description: "Service account credentials as JSON.",
+	}
+
+	blocks := map[string]schema.Block{
+		"s3":          tierS3Block("AWS S3"),
+		"minio":       tierS3Block("MinIO"),
+		"rustfs":      tierS3Block("RustFS"),
+		"aliyun":      tierS3Block("Alibaba Cloud OSS"),
+		"tencent":     tierS3Block("Tencent Cloud COS"),
+		"huaweicloud": tierS3Block("Huawei Cloud OBS"),
+		"r2":          tierS3Block("Cloudflare R2"),
+		"azure": schema.SingleNestedBlock{
+			Description: "Azure Blob Storage backend.",
+			Attributes:  azure,
+		},
+		"gcs": schema.SingleNestedBlock{
+			Description: "Google Cloud Storage backend.",
+			Attributes:  gcs,
+		},
+		"timeouts": timeouts.Block(ctx, timeouts.Opts{
+			Create: true,
+			Update: true,
+			Delete: true,
+		}),
+	}
+
 	resp.Schema = schema.Schema{
 		Description:         "Manage RustFS storage tiers",
-		MarkdownDescription: "Manage RustFS storage tiers for data transition to external backends",
+		MarkdownDescription: "Manage RustFS storage tiers for data transition to external backends. Configure the backend with exactly one typed block or, as an escape hatch, with `config_json`.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				Required:    true,
-				Description: "Tier name (must be uppercase). Changing this forces recreation.",
+				Required:      true,
+				Description:   "Tier name (must be uppercase). Changing this forces recreation.",
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(tierNamePattern, "must consist of uppercase letters, digits, - and _"),
+				},
 			},
 			"tier_type": schema.StringAttribute{
-				Required:    true,
-				Description: "Tier type: s3, minio, azure, gcs, aliyun, tencent, huaweicloud, r2, or rustfs.",
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Optional:    true,
+				Computed:    true,
+				Description: "Tier type: s3, minio, azure, gcs, aliyun, tencent, huaweicloud, r2, or rustfs. Derived from the typed block, required with config_json. Changing this forces recreation.",
+				PlanModifiers: []planmodifier.String{
+					tierTypeModifier{},
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(tierTypes...),
+				},
 			},
 			"config_json": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "Backend-specific configuration as a JSON string. See RustFS tier documentation for the expected format per backend type.",
+				Description: "Backend-specific configuration as a JSON string. See RustFS tier documentation for the expected format per backend type. Use a typed block instead where possible. Changing other settings than the credentials forces recreation.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(tierConfigJsonRequiresReplace, "Changing other settings than the credentials forces recreation.", "Changing other settings than the credentials forces recreation."),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(tierBlockPaths()...),
+					stringvalidator.AlsoRequires(path.MatchRoot("tier_type")),
+				},
 			},
 		},
+		Blocks: blocks,
 	}
 }
 
+// tierTypeModifier plans tier_type from the configured typed block, so that
+// switching backends replaces the tier.
+type tierTypeModifier struct{}
+
+func (m tierTypeModifier) Description(_ context.Context) string {
+	return "Derives the tier type from the configured backend block."
+}
+
+func (m tierTypeModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m tierTypeModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+	for _, name := range tierTypes {
+		var block types.Object
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &block)...)
+		if !block.IsNull() {
+			resp.PlanValue = types.StringValue(name)
+			return
+		}
+	}
+}
+
+// tierConfigJsonRequiresReplace replaces the tier if config_json changes
+// other settings than the credentials.
+func tierConfigJsonRequiresReplace(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !reflect.DeepEqual(
+		tierSettings(json.RawMessage(req.StateValue.ValueString())),
+		tierSettings(json.RawMessage(req.PlanValue.ValueString())),
+	)
+}
+
 func (r *TierResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -79,12 +305,21 @@ func (r *TierResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	if !json.Valid([]byte(plan.ConfigJson.ValueString())) {
-		resp.Diagnostics.AddError("Invalid JSON", "config_json must be valid JSON")
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	config, diags := buildTierConfig(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.client.RustClient.AddTier(json.RawMessage(plan.ConfigJson.ValueString())); err != nil {
+	if err := r.client.RustClient.AddTier(ctx, config); err != nil {
 		resp.Diagnostics.AddError("Error adding tier", "Could not add tier: "+err.Error())
 		return
 	}
@@ -95,6 +330,24 @@ func (r *TierResource) Create(ctx context.Context, req resource.CreateRequest, r
 func (r *TierResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state tierResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tier, err := r.client.RustClient.GetTier(ctx, state.Name.ValueString())
+	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading tier",
+			"Could not read tier: "+err.Error(),
+		)
+		return
+	}
+
+	flattenTierConfig(tier, &state)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -105,12 +358,27 @@ func (r *TierResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	if !json.Valid([]byte(plan.ConfigJson.ValueString())) {
-		resp.Diagnostics.AddError("Invalid JSON", "config_json must be valid JSON")
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	if err := r.client.RustClient.EditTier(plan.Name.ValueString(), json.RawMessage(plan.ConfigJson.ValueString())); err != nil {
+	config, diags := buildTierConfig(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The server only edits credentials, other changes replace the tier.
+	credentials, err := tierCredentials(plan.TierType.ValueString(), config)
+	if err != nil {
+		resp.Diagnostics.AddError("Error editing tier", "Could not read the tier credentials: "+err.Error())
+		return
+	}
+
+	if err := r.client.RustClient.EditTier(ctx, plan.Name.ValueString(), credentials); err != nil {
 		resp.Diagnostics.AddError("Error editing tier", "Could not edit tier: "+err.Error())
 		return
 	}
@@ -125,7 +393,18 @@ func (r *TierResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	if err := r.client.RustClient.RemoveTier(data.Name.ValueString()); err != nil {
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if err := r.client.RustClient.RemoveTier(ctx, data.Name.ValueString()); err != nil {
+		if rustfs.IsNotFound(err) {
+			return
+		}
 		resp.Diagnostics.AddError("Error removing tier", "Could not remove tier: "+err.Error())
 		return
 	}
@@ -134,3 +413,205 @@ func (r *TierResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 func (r *TierResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
+
+// tierBackend returns the type and the settings of the configured typed
+// block, or an empty type if config_json is used.
+func tierBackend(plan tierResourceModel) (string, map[string]types.String) {
+	s3 := func(m *tierS3Model) map[string]types.String {
+		return map[string]types.String{
+			"endpoint":      m.Endpoint,
+			"access_key":    m.AccessKey,
+			"secret_key":    m.SecretKey,
+			"bucket":        m.Bucket,
+			"prefix":        m.Prefix,
+			"region":        m.Region,
+			"storage_class": m.StorageClass,
+		}
+	}
+	for name, m := range map[string]*tierS3Model{
+		"s3":          plan.S3,
+		"minio":       plan.Minio,
+		"rustfs":      plan.Rustfs,
+		"aliyun":      plan.Aliyun,
+		"tencent":     plan.Tencent,
+		"huaweicloud": plan.Huaweicloud,
+		"r2":          plan.R2,
+	} {
+		if m != nil {
+			return name, s3(m)
+		}
+	}
+	if m := plan.Azure; m != nil {
+		return "azure", map[string]types.String{
+			"endpoint":      m.Endpoint,
+			"account_name":  m.AccountName,
+			"account_key":   m.AccountKey,
+			"bucket":        m.Bucket,
+			"prefix":        m.Prefix,
+			"region":        m.Region,
+			"storage_class": m.StorageClass,
+		}
+	}
+	if m := plan.Gcs; m != nil {
+		return "gcs", map[string]types.String{
+			"endpoint":      m.Endpoint,
+			"creds":         m.Credentials,
+			"bucket":        m.Bucket,
+			"prefix":        m.Prefix,
+			"region":        m.Region,
+			"storage_class": m.StorageClass,
+		}
+	}
+	return "", nil
+}
+
+// buildTierConfig returns the tier configuration the admin API expects, e.g.
+// {"version":"v1","tier_type":"s3","s3":{"name":"COLD",...}}. config_json is
+// sent as is.
+func buildTierConfig(plan tierResourceModel) (json.RawMessage, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	tierType, settings := tierBackend(plan)
+	if tierType == "" {
+		if !json.Valid([]byte(plan.ConfigJson.ValueString())) {
+			diags.AddAttributeError(path.Root("config_json"), "Invalid JSON", "config_json must be valid JSON")
+			return nil, diags
+		}
+		return json.RawMessage(plan.ConfigJson.ValueString()), diags
+	}
+	if !plan.TierType.IsUnknown() && !plan.TierType.IsNull() && plan.TierType.ValueString() != tierType {
+		diags.AddAttributeError(path.Root("tier_type"), "Conflicting tier type",
+			fmt.Sprintf("tier_type %q does not match the configured %s block.", plan.TierType.ValueString(), tierType))
+		return nil, diags
+	}
+
+	backend := map[string]string{"name": plan.Name.ValueString()}
+	for key, value := range settings {
+		if value.ValueString() != "" {
+			backend[key] = value.ValueString()
+		}
+	}
+	config, err := json.Marshal(map[string]any{
+		"version":   "v1",
+		"tier_type": tierType,
+		tierType:    backend,
+	})
+	if err != nil {
+		diags.AddError("Error encoding tier", "Could not encode tier configuration: "+err.Error())
+	}
+	return config, diags
+}
+
+// tierCredentials returns the credentials of a tier configuration, e.g.
+// {"access_key":"...","secret_key":"..."}, as the tier edit endpoint expects
+// them.
+func tierCredentials(tierType string, config json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(config, &fields); err != nil {
+		return nil, err
+	}
+	if t, ok := fields["tier_type"]; ok {
+		if err := json.Unmarshal(t, &tierType); err != nil {
+			return nil, err
+		}
+	}
+	var backend map[string]any
+	if err := json.Unmarshal(fields[tierType], &backend); err != nil {
+		return nil, fmt.Errorf("no %q settings in the tier configuration", tierType)
+	}
+	credentials := map[string]any{}
+	for _, key := range tierCredentialKeys {
+		if value, ok := backend[key]; ok {
+			credentials[key] = value
+		}
+	}
+	return json.Marshal(credentials)
+}
+
+// tierSettings returns a tier configuration without credentials, or nil if
+// it is not a JSON object.
+func tierSettings(config json.RawMessage) map[string]any {
+	var fields map[string]any
+	if json.Unmarshal(config, &fields) != nil {
+		return nil
+	}
+	for _, value := range fields {
+		if backend, ok := value.(map[string]any); ok {
+			maps.DeleteFunc(backend, func(key string, _ any) bool {
+				return slices.Contains(tierCredentialKeys, key)
+			})
+		}
+	}
+	return fields
+}
+
+// flattenTierConfig refreshes state from tier. The server redacts secrets, so
+// they are kept from state. A config_json is not compared, imported tiers
+// get the typed block of their type with empty secrets.
+func flattenTierConfig(tier rustfs.TierConfig, state *tierResourceModel) {
+	state.TierType = types.StringValue(tier.Type)
+	if !state.ConfigJson.IsNull() {
+		return
+	}
+	if tierType, _ := tierBackend(*state); tierType != "" && tierType != tier.Type {
+		// The block no longer matches, the changed tier_type replaces it.
+		return
+	}
+
+	refresh := func(value types.String, current string) types.String {
+		if value.ValueString() == current {
+			return value
+		}
+		return stringOrNull(current)
+	}
+	refreshS3 := func(m *tierS3Model) *tierS3Model {
+		if m == nil {
+			m = &tierS3Model{SecretKey: types.StringNull()}
+		}
+		m.Endpoint = refresh(m.Endpoint, tier.Endpoint)
+		m.AccessKey = refresh(m.AccessKey, tier.AccessKey)
+		m.Bucket = refresh(m.Bucket, tier.Bucket)
+		m.Prefix = refresh(m.Prefix, tier.Prefix)
+		m.Region = refresh(m.Region, tier.Region)
+		m.StorageClass = refresh(m.StorageClass, tier.StorageClass)
+		return m
+	}
+	switch tier.Type {
+	case "s3":
+		state.S3 = refreshS3(state.S3)
+	case "minio":
+		state.Minio = refreshS3(state.Minio)
+	case "rustfs":
+		state.Rustfs = refreshS3(state.Rustfs)
+	case "aliyun":
+		state.Aliyun = refreshS3(state.Aliyun)
+	case "tencent":
+		state.Tencent = refreshS3(state.Tencent)
+	case "huaweicloud":
+		state.Huaweicloud = refreshS3(state.Huaweicloud)
+	case "r2":
+		state.R2 = refreshS3(state.R2)
+	case "azure":
+		m := state.Azure
+		if m == nil {
+			m = &tierAzureModel{AccountKey: types.StringNull()}
+		}
+		m.Endpoint = refresh(m.Endpoint, tier.Endpoint)
+		m.AccountName = refresh(m.AccountName, tier.AccessKey)
+		m.Bucket = refresh(m.Bucket, tier.Bucket)
+		m.Prefix = refresh(m.Prefix, tier.Prefix)
+		m.Region = refresh(m.Region, tier.Region)
+		m.StorageClass = refresh(m.StorageClass, tier.StorageClass)
+		state.Azure = m
+	case "gcs":
+		m := state.Gcs
+		if m == nil {
+			m = &tierGcsModel{Credentials: types.StringNull()}
+		}
+		m.Endpoint = refresh(m.Endpoint, tier.Endpoint)
+		m.Bucket = refresh(m.Bucket, tier.Bucket)
+		m.Prefix = refresh(m.Prefix, tier.Prefix)
+		m.Region = refresh(m.Region, tier.Region)
+		m.StorageClass = refresh(m.StorageClass, tier.StorageClass)
+		state.Gcs = m
+	}
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
+)
+
+var _ datasource.DataSource = &TiersDataSource{}
+
+type TiersDataSource struct {
+	client *AllClient
+}
+
+type TiersDataSourceModel struct {
+	Tiers []tierDataModel `tfsdk:"tiers"`
+}
+
+type tierDataModel struct {
+	Name        types.String `tfsdk:"name"`
+	TierType    types.String `tfsdk:"tier_type"`
+	Endpoint    types.String `tfsdk:"endpoint"`
+	Bucket      types.String `tfsdk:"bucket"`
+	Prefix      types.String `tfsdk:"prefix"`
+	TotalSize   types.Int64  `tfsdk:"total_size"`
+	NumVersions types.Int64  `tfsdk:"num_versions"`
+	NumObjects  types.Int64  `tfsdk:"num_objects"`
+}
+
+func NewTiersDataSource() datasource.DataSource {
+	return &TiersDataSource{}
+}
+
+func (d *TiersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tiers"
+}
+
+func (d *TiersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "List RustFS storage tiers",
+		MarkdownDescription: "List all RustFS storage tiers with their backend and usage. Secrets of the backends are not returned by the server.",
+		Attributes: map[string]schema.Attribute{
+			"tiers": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Tiers ordered by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Tier name.",
+						},
+						"tier_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "Tier type, e.g. s3 or azure.",
+						},
+						"endpoint": schema.StringAttribute{
+							Computed:    true,
+							Description: "URL of the backend.",
+						},
+						"bucket": schema.StringAttribute{
+							Computed:    true,
+							Description: "Bucket on the backend.",
+						},
+						"prefix": schema.StringAttribute{
+							Computed:    true,
+							Description: "Prefix of the transitioned objects in the bucket.",
+						},
+						"total_size": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Total size of the transitioned objects in bytes.",
+						},
+						"num_versions": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of transitioned object versions.",
+						},
+						"num_objects": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of transitioned objects.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TiersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*AllClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AllClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *TiersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config TiersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tiers, err := d.client.RustClient.ListTiers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing tiers",
+			"Could not list tiers: "+err.Error(),
+		)
+		return
+	}
+	stats, err := d.client.RustClient.GetTierStats(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading tier stats",
+			"Could not read tier stats: "+err.Error(),
+		)
+		return
+	}
+	usage := map[string]rustfs.TierUsage{}
+	for _, s := range stats {
+		usage[s.Name] = s.Stats
+	}
+
+	config.Tiers = []tierDataModel{}
+	for _, t := range tiers {
+		config.Tiers = append(config.Tiers, tierDataModel{
+			Name:        types.StringValue(t.Name),
+			TierType:    types.StringValue(t.Type),
+			Endpoint:    stringOrNull(t.Endpoint),
+			Bucket:      stringOrNull(t.Bucket),
+			Prefix:      stringOrNull(t.Prefix),
+			TotalSize:   types.Int64Value(usage[t.Name].TotalSize),
+			NumVersions: types.Int64Value(usage[t.Name].NumVersions),
+			NumObjects:  types.Int64Value(usage[t.Name].NumObjects),
+		})
+	}
+	slices.SortFunc(config.Tiers, func(a, b tierDataModel) int {
+		return strings.Compare(a.Name.ValueString(), b.Name.ValueString())
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
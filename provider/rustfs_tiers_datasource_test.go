@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs/rustfstest"
+)
+
+func TestTiersDataSourceMetadata(t *testing.T) {
+	d := NewTiersDataSource()
+	resp := &datasource.MetadataResponse{}
+	d.Metadata(nil, datasource.MetadataRequest{ProviderTypeName: "rustfs"}, resp)
+
+	if resp.TypeName != "rustfs_tiers" {
+		t.Errorf("expected rustfs_tiers, got %s", resp.TypeName)
+	}
+}
+
+func TestTiersDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	client, server := testProviderClient(t)
+	for _, config := range []string{
+		`{"tier_type":"s3","s3":{"name":"WARM","endpoint":"https://s3.amazonaws.com","access_key":"AKIA","secret_key":"secret","bucket":"warm"}}`,
+		`{"tier_type":"azure","azure":{"name":"COLD","account_name":"account","account_key":"key","bucket":"archive","prefix":"tier/"}}`,
+	} {
+		if err := client.RustClient.AddTier(ctx, []byte(config)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	server.SetTierUsage("COLD", rustfstest.TierUsage{TotalSize: 4096, NumVersions: 3, NumObjects: 2})
+
+	d := NewTiersDataSource()
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: testNullObject(schemaResp.Schema.Type())}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: testNullObject(schemaResp.Schema.Type())}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", resp.Diagnostics)
+	}
+
+	var model TiersDataSourceModel
+	resp.State.Get(ctx, &model)
+	if len(model.Tiers) != 2 {
+		t.Fatalf("expected 2 tiers, got %+v", model.Tiers)
+	}
+	cold, warm := model.Tiers[0], model.Tiers[1]
+	if cold.Name.ValueString() != "COLD" || cold.TierType.ValueString() != "azure" || cold.Bucket.ValueString() != "archive" || cold.Prefix.ValueString() != "tier/" || !cold.Endpoint.IsNull() {
+		t.Errorf("unexpected tier %+v", cold)
+	}
+	if cold.TotalSize.ValueInt64() != 4096 || cold.NumVersions.ValueInt64() != 3 || cold.NumObjects.ValueInt64() != 2 {
+		t.Errorf("unexpected tier usage %+v", cold)
+	}
+	if warm.Name.ValueString() != "WARM" || warm.Endpoint.ValueString() != "https://s3.amazonaws.com" || warm.TotalSize.ValueInt64() != 0 {
+		t.Errorf("unexpected tier %+v", warm)
+	}
+}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -105,7 +106,7 @@ func testAccCheckUserDestroy(s *terraform.State) error {
 		if accessKey == "" {
 			continue
 		}
-		_, err := client.ReadUserAccount(accessKey)
+		_, err := client.ReadUserAccount(context.Background(), accessKey)
 		if err == nil {
 			return fmt.Errorf("user %s still exists", accessKey)
 		}
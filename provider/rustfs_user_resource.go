@@ -3,7 +3,10 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -25,11 +28,12 @@ type RustfsUserRessource struct {
 }
 
 type RustfsUserRessourceModel struct {
-	Name      types.String `tfsdk:"name"`
-	AccessKey types.String `tfsdk:"access_key"`
-	SecretKey types.String `tfsdk:"secret_key"`
-	Status    types.String `tfsdk:"status"`
-	Policy    types.String `tfsdk:"policy"`
+	Name      types.String   `tfsdk:"name"`
+	AccessKey types.String   `tfsdk:"access_key"`
+	SecretKey types.String   `tfsdk:"secret_key"`
+	Status    types.String   `tfsdk:"status"`
+	Policy    types.String   `tfsdk:"policy"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewUserRessource() resource.Resource {
@@ -72,12 +76,17 @@ func (r *RustfsUserRessource) Schema(ctx context.Context, req resource.SchemaReq
 			},
 			"policy": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "User policy. Changing this forces a new user to be created (the underlying API does not support updating a user's policy in-place).",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				MarkdownDescription: "Comma separated policies of the user. Conflicts with `rustfs_iam_user_policy_attachment` for the same user, use either of them.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 func (r *RustfsUserRessource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -107,13 +116,21 @@ func (r *RustfsUserRessource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	account := rustfs.UserAccount{
 		AccessKey: plan.AccessKey.ValueString(),
 		SecretKey: plan.SecretKey.ValueString(),
 		Policy:    plan.Policy.ValueString(),
 	}
 
-	err := r.client.RustClient.CreateUserAccount(account)
+	err := r.client.RustClient.CreateUserAccount(ctx, account)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating user",
@@ -130,19 +147,27 @@ func (r *RustfsUserRessource) Create(ctx context.Context, req resource.CreateReq
 
 func (r *RustfsUserRessource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state RustfsUserRessourceModel
+	// Read Terraform prior state data into the model
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	read, err := r.client.RustClient.ReadUserAccount(state.AccessKey.ValueString())
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	read, err := r.client.RustClient.ReadUserAccount(ctx, state.AccessKey.ValueString())
 	if err != nil {
+		if rustfs.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading user",
 			"Could not read user: "+err.Error(),
@@ -152,7 +177,12 @@ func (r *RustfsUserRessource) Read(ctx context.Context, req resource.ReadRequest
 	state.Status = types.StringValue(read.Status)
 	state.AccessKey = types.StringValue(state.AccessKey.ValueString())
 	state.SecretKey = types.StringValue(state.SecretKey.ValueString())
-	state.Policy = types.StringValue(read.Policy)
+	// Policies are only refreshed if managed here, they may be attached by
+	// rustfs_iam_user_policy_attachment otherwise. The order of the policies
+	// does not matter.
+	if !state.Policy.IsNull() && !slices.Equal(policyNames(state.Policy.ValueString()), policyNames(read.Policy)) {
+		state.Policy = types.StringValue(read.Policy)
+	}
 	if state.Name.IsNull() || state.Name.ValueString() == "" {
 		state.Name = types.StringValue(state.AccessKey.ValueString())
 	}
@@ -171,6 +201,14 @@ func (r *RustfsUserRessource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	account := rustfs.UserAccount{
 		AccessKey: plan.AccessKey.ValueString(),
 		SecretKey: plan.SecretKey.ValueString(),
@@ -178,7 +216,7 @@ func (r *RustfsUserRessource) Update(ctx context.Context, req resource.UpdateReq
 		Status:    plan.Status.ValueString(),
 	}
 
-	err := r.client.RustClient.UpdateUserAccount(account)
+	err := r.client.RustClient.UpdateUserAccount(ctx, account)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating user",
@@ -187,6 +225,22 @@ func (r *RustfsUserRessource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	// An empty policy is not sent on update, detach the previous policies.
+	var state RustfsUserRessourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Policy.ValueString() == "" && state.Policy.ValueString() != "" {
+		if err := r.client.RustClient.SetUserPolicies(ctx, account.AccessKey, nil); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating user",
+				"Could not detach user policies: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -203,11 +257,23 @@ func (r *RustfsUserRessource) Delete(ctx context.Context, req resource.DeleteReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	account := rustfs.UserAccount{
 		AccessKey: data.AccessKey.ValueString(),
 	}
-	err := r.client.RustClient.DeleteUserAccount(account)
+	err := r.client.RustClient.DeleteUserAccount(ctx, account)
 	if err != nil {
+		if rustfs.IsNotFound(err) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error deleting user",
 			"Could not delete user, unexpected error: "+err.Error(),
@@ -219,3 +285,15 @@ func (r *RustfsUserRessource) Delete(ctx context.Context, req resource.DeleteReq
 func (r *RustfsUserRessource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("access_key"), req, resp)
 }
+
+// policyNames returns the sorted names of a comma separated policy list.
+func policyNames(policy string) []string {
+	names := []string{}
+	for _, name := range strings.Split(policy, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
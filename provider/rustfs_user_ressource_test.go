@@ -1,9 +1,14 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/weinmann-emt/terraform-provider-rustfs/pkg/rustfs"
 )
 
 const (
@@ -45,3 +50,108 @@ resource "rustfs_user" "test" {
 				)},
 		}})
 }
+
+func TestUserResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	r := NewUserRessource()
+	empty := testResourceState(t, r, client)
+
+	var model RustfsUserRessourceModel
+	if diags := empty.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("get diagnostics: %v", diags)
+	}
+	model.AccessKey = types.StringValue("alice")
+	model.SecretKey = types.StringValue("alice-secret")
+	model.Status = types.StringValue("enabled")
+	model.Policy = types.StringValue("readonly")
+	plan := empty
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("set diagnostics: %v", diags)
+	}
+
+	createResp := &fwresource.CreateResponse{State: empty}
+	r.Create(ctx, fwresource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+	user, err := client.RustClient.ReadUserAccount(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Policy != "readonly" {
+		t.Errorf("expected readonly policy, got %q", user.Policy)
+	}
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var read RustfsUserRessourceModel
+	readResp.State.Get(ctx, &read)
+	if read.Name.ValueString() != "alice" || read.Status.ValueString() != "enabled" {
+		t.Errorf("unexpected state %+v", read)
+	}
+
+	// Policies are updated in place.
+	model.Name = types.StringValue("alice")
+	model.Policy = types.StringValue("readwrite,diagnostics")
+	updatePlan := readResp.State
+	updatePlan.Set(ctx, &model)
+	updateResp := &fwresource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: tfsdk.Plan(updatePlan), State: readResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("update diagnostics: %v", updateResp.Diagnostics)
+	}
+	if user, _ := client.RustClient.ReadUserAccount(ctx, "alice"); user.Policy != "readwrite,diagnostics" {
+		t.Errorf("expected updated policies, got %q", user.Policy)
+	}
+
+	// A reordered policy list on the server is no change.
+	if err := client.RustClient.SetUserPolicies(ctx, "alice", []string{"diagnostics", "readwrite"}); err != nil {
+		t.Fatal(err)
+	}
+	readResp = &fwresource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: updateResp.State}, readResp)
+	readResp.State.Get(ctx, &read)
+	if read.Policy.ValueString() != "readwrite,diagnostics" {
+		t.Errorf("expected the configured policy order, got %s", read.Policy)
+	}
+	if err := client.RustClient.SetUserPolicies(ctx, "alice", []string{"readonly"}); err != nil {
+		t.Fatal(err)
+	}
+	readResp = &fwresource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: updateResp.State}, readResp)
+	readResp.State.Get(ctx, &read)
+	if read.Policy.ValueString() != "readonly" {
+		t.Errorf("expected the policy changed on the server, got %s", read.Policy)
+	}
+
+	model.Policy = types.StringNull()
+	clearPlan := updateResp.State
+	clearPlan.Set(ctx, &model)
+	clearResp := &fwresource.UpdateResponse{State: updateResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: tfsdk.Plan(clearPlan), State: updateResp.State}, clearResp)
+	if clearResp.Diagnostics.HasError() {
+		t.Fatalf("update diagnostics: %v", clearResp.Diagnostics)
+	}
+	if user, _ := client.RustClient.ReadUserAccount(ctx, "alice"); user.Policy != "" {
+		t.Errorf("expected the policies to be detached, got %q", user.Policy)
+	}
+
+	deleteResp := &fwresource.DeleteResponse{State: readResp.State}
+	r.Delete(ctx, fwresource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+	if _, err := client.RustClient.ReadUserAccount(ctx, "alice"); !rustfs.IsNotFound(err) {
+		t.Errorf("expected deleted user to be not found, got %v", err)
+	}
+
+	readResp = &fwresource.ReadResponse{State: createResp.State}
+	r.Read(ctx, fwresource.ReadRequest{State: createResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected deleted user to be removed from state")
+	}
+}
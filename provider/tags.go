@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+// Taggable resources carry two attributes: tags as configured on the
+// resource and tags_all, the tags actually applied after merging the
+// provider default_tags.
+
+func tagsAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		ElementType: types.StringType,
+		Optional:    true,
+		Description: "Tags of the bucket. Tags with the same key as a provider default tag override it.",
+	}
+}
+
+func tagsAllAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		ElementType: types.StringType,
+		Computed:    true,
+		Description: "All tags of the bucket including the provider default_tags.",
+	}
+}
+
+// mergeTags returns defaults overridden by tags.
+func mergeTags(defaults, tags map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults)+len(tags))
+	maps.Copy(merged, defaults)
+	maps.Copy(merged, tags)
+	return merged
+}
+
+// planTagsAll sets tags_all in the plan to the merged tags. It is called
+// from ModifyPlan of every taggable resource.
+func planTagsAll(ctx context.Context, client *AllClient, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy or before the provider is configured.
+	if req.Plan.Raw.IsNull() || client == nil {
+		return
+	}
+	var configured types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &configured)...)
+	if resp.Diagnostics.HasError() || configured.IsUnknown() {
+		return
+	}
+	var own map[string]string
+	resp.Diagnostics.Append(configured.ElementsAs(ctx, &own, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tagsAll, diags := types.MapValueFrom(ctx, types.StringType, mergeTags(client.DefaultTags, own))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+}
+
+// expandTags returns the tags to apply for the configured tags.
+func expandTags(ctx context.Context, client *AllClient, configured types.Map) (map[string]string, diag.Diagnostics) {
+	var own map[string]string
+	diags := configured.ElementsAs(ctx, &own, false)
+	return mergeTags(client.DefaultTags, own), diags
+}
+
+// flattenTags splits the applied tags into tags and tags_all. Default tags
+// whose value was not overridden are not part of tags. tags stays null if
+// it was null before and only default tags are applied.
+func flattenTags(ctx context.Context, client *AllClient, applied map[string]string, prior types.Map) (types.Map, types.Map, diag.Diagnostics) {
+	own := map[string]string{}
+	for key, value := range applied {
+		if defaultValue, ok := client.DefaultTags[key]; !ok || defaultValue != value {
+			own[key] = value
+		}
+	}
+	// Keep configured tags which repeat a default tag.
+	var configured map[string]string
+	diags := prior.ElementsAs(ctx, &configured, false)
+	for key, value := range configured {
+		if applied[key] == value {
+			own[key] = value
+		}
+	}
+
+	tagsValue := types.MapNull(types.StringType)
+	if !prior.IsNull() || len(own) > 0 {
+		var d diag.Diagnostics
+		tagsValue, d = types.MapValueFrom(ctx, types.StringType, own)
+		diags.Append(d...)
+	}
+	tagsAll, d := types.MapValueFrom(ctx, types.StringType, applied)
+	diags.Append(d...)
+	return tagsValue, tagsAll, diags
+}
+
+// setBucketTags replaces the tags of bucket. An empty map removes them.
+func setBucketTags(ctx context.Context, client *minio.Client, bucket string, applied map[string]string) error {
+	if len(applied) == 0 {
+		return client.RemoveBucketTagging(ctx, bucket)
+	}
+	t, err := tags.NewTags(applied, false)
+	if err != nil {
+		return err
+	}
+	return client.SetBucketTagging(ctx, bucket, t)
+}
+
+// getBucketTags returns the tags of bucket, which are empty if none are set.
+func getBucketTags(ctx context.Context, client *minio.Client, bucket string) (map[string]string, error) {
+	t, err := client.GetBucketTagging(ctx, bucket)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchTagSet" {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	return t.ToMap(), nil
+}
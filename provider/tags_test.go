@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/minio/minio-go/v7"
+)
+
+func testTagsMap(t *testing.T, m map[string]string) types.Map {
+	t.Helper()
+	value, diags := types.MapValueFrom(context.Background(), types.StringType, m)
+	if diags.HasError() {
+		t.Fatalf("map diagnostics: %v", diags)
+	}
+	return value
+}
+
+func TestMergeTags(t *testing.T) {
+	merged := mergeTags(
+		map[string]string{"owner": "platform", "cost-center": "42"},
+		map[string]string{"owner": "team-a", "env": "prod"},
+	)
+	expected := map[string]string{"owner": "team-a", "cost-center": "42", "env": "prod"}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+}
+
+func TestFlattenTags(t *testing.T) {
+	ctx := context.Background()
+	client := &AllClient{DefaultTags: map[string]string{"owner": "platform", "env": "prod"}}
+	applied := map[string]string{"owner": "team-a", "env": "prod", "app": "web"}
+
+	tests := []struct {
+		name     string
+		prior    types.Map
+		expected types.Map
+	}{
+		{"overrides and own tags", testTagsMap(t, map[string]string{"owner": "team-a", "app": "web"}), testTagsMap(t, map[string]string{"owner": "team-a", "app": "web"})},
+		{"repeated default tag", testTagsMap(t, map[string]string{"env": "prod"}), testTagsMap(t, map[string]string{"owner": "team-a", "app": "web", "env": "prod"})},
+		{"import", types.MapNull(types.StringType), testTagsMap(t, map[string]string{"owner": "team-a", "app": "web"})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, tagsAll, diags := flattenTags(ctx, client, applied, tt.prior)
+			if diags.HasError() {
+				t.Fatalf("flatten diagnostics: %v", diags)
+			}
+			if !tags.Equal(tt.expected) {
+				t.Errorf("expected tags %v, got %v", tt.expected, tags)
+			}
+			if !tagsAll.Equal(testTagsMap(t, applied)) {
+				t.Errorf("expected tags_all %v, got %v", applied, tagsAll)
+			}
+		})
+	}
+
+	tags, _, _ := flattenTags(ctx, client, map[string]string{"owner": "platform"}, types.MapNull(types.StringType))
+	if !tags.IsNull() {
+		t.Errorf("expected tags to stay null with only default tags, got %v", tags)
+	}
+}
+
+func TestPlanTagsAll(t *testing.T) {
+	ctx := context.Background()
+	client := &AllClient{DefaultTags: map[string]string{"owner": "platform"}}
+	r := NewBucketTaggingResource()
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &BucketTaggingResourceModel{
+		Bucket:  types.StringValue("tagged"),
+		Tags:    testTagsMap(t, map[string]string{"env": "prod"}),
+		TagsAll: types.MapUnknown(types.StringType),
+	})
+
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan(plan)}
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: tfsdk.Plan(plan)}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("modify plan diagnostics: %v", resp.Diagnostics)
+	}
+	var planned BucketTaggingResourceModel
+	resp.Plan.Get(ctx, &planned)
+	if !planned.TagsAll.Equal(testTagsMap(t, map[string]string{"owner": "platform", "env": "prod"})) {
+		t.Errorf("unexpected tags_all %v", planned.TagsAll)
+	}
+}
+
+func TestBucketTags(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	client.DefaultTags = map[string]string{"cost-center": "42", "owner": "platform"}
+	r := NewBucketRessource()
+	state := testCreateBucket(t, r, client, "tagged", func(m *bucketResourceModel) {
+		m.Tags = testTagsMap(t, map[string]string{"owner": "team-a"})
+	})
+
+	applied, err := getBucketTags(ctx, client.Minio, "tagged")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(applied, map[string]string{"cost-center": "42", "owner": "team-a"}) {
+		t.Errorf("unexpected bucket tags %v", applied)
+	}
+
+	// Tags changed outside of Terraform show up as drift.
+	if err := setBucketTags(ctx, client.Minio, "tagged", map[string]string{"owner": "team-b"}); err != nil {
+		t.Fatal(err)
+	}
+	readResp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var read bucketResourceModel
+	readResp.State.Get(ctx, &read)
+	if !read.Tags.Equal(testTagsMap(t, map[string]string{"owner": "team-b"})) {
+		t.Errorf("unexpected tags %v", read.Tags)
+	}
+	if !read.TagsAll.Equal(testTagsMap(t, map[string]string{"owner": "team-b"})) {
+		t.Errorf("unexpected tags_all %v", read.TagsAll)
+	}
+
+	plan := readResp.State
+	read.Tags = types.MapNull(types.StringType)
+	plan.Set(ctx, &read)
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: tfsdk.Plan(plan), State: readResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("update diagnostics: %v", updateResp.Diagnostics)
+	}
+	applied, _ = getBucketTags(ctx, client.Minio, "tagged")
+	if !reflect.DeepEqual(applied, client.DefaultTags) {
+		t.Errorf("expected only default tags, got %v", applied)
+	}
+}
+
+func TestBucketTaggingResourceCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := testProviderClient(t)
+	if err := client.Minio.MakeBucket(ctx, "tagged", minio.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	r := NewBucketTaggingResource()
+	plan := testResourceState(t, r, client)
+	plan.Set(ctx, &BucketTaggingResourceModel{
+		Bucket:  types.StringValue("tagged"),
+		Tags:    testTagsMap(t, map[string]string{"env": "prod"}),
+		TagsAll: types.MapUnknown(types.StringType),
+	})
+
+	createResp := &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	var read BucketTaggingResourceModel
+	readResp.State.Get(ctx, &read)
+	if !read.Tags.Equal(testTagsMap(t, map[string]string{"env": "prod"})) {
+		t.Errorf("unexpected tags %v", read.Tags)
+	}
+
+	if err := client.Minio.RemoveBucketTagging(ctx, "tagged"); err != nil {
+		t.Fatal(err)
+	}
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if !readResp.State.Raw.IsNull() {
+		t.Error("expected removed tags to be removed from state")
+	}
+
+	deleteResp := &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+
+	// Empty tags without default tags apply no tags and stay in state.
+	plan.Set(ctx, &BucketTaggingResourceModel{
+		Bucket:  types.StringValue("tagged"),
+		Tags:    testTagsMap(t, map[string]string{}),
+		TagsAll: testTagsMap(t, map[string]string{}),
+	})
+	createResp = &resource.CreateResponse{State: testResourceState(t, r, client)}
+	r.Create(ctx, resource.CreateRequest{Plan: tfsdk.Plan(plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create diagnostics: %v", createResp.Diagnostics)
+	}
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read diagnostics: %v", readResp.Diagnostics)
+	}
+	if readResp.State.Raw.IsNull() {
+		t.Fatal("expected empty tags to stay in state")
+	}
+	readResp.State.Get(ctx, &read)
+	if !read.Tags.Equal(testTagsMap(t, map[string]string{})) || len(read.TagsAll.Elements()) != 0 {
+		t.Errorf("unexpected tags %v and tags_all %v", read.Tags, read.TagsAll)
+	}
+}